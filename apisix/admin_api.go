@@ -0,0 +1,93 @@
+package apisix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// adminResponse is the envelope of a single object returned by the APISIX Admin API.
+type adminResponse struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// adminRequest sends a request the api_client package has no method for to
+// the APISIX Admin API, e.g. PATCH. The objectPath is relative to
+// `/apisix/admin`, like `routes/1`. When out is not nil, the object in the
+// response value is decoded into it.
+func (c *apisixClient) adminRequest(ctx context.Context, method string, objectPath string, body any, out any) error {
+	resBody, err := c.adminDo(ctx, method, objectPath, body)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	var response adminResponse
+	if err := json.Unmarshal(resBody, &response); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(response.Value, out)
+}
+
+// adminDo sends a request to the APISIX Admin API and returns the raw response body.
+func (c *apisixClient) adminDo(ctx context.Context, method string, objectPath string, body any) ([]byte, error) {
+	resBody, _, err := c.adminDoResponse(ctx, method, objectPath, body)
+	return resBody, err
+}
+
+// adminDoResponse sends a request to the APISIX Admin API and returns the raw
+// response body with the response headers, like the Server header holding
+// the APISIX version.
+func (c *apisixClient) adminDoResponse(ctx context.Context, method string, objectPath string, body any) ([]byte, http.Header, error) {
+	var requestBody io.Reader
+	if body != nil {
+		rb, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		requestBody = bytes.NewReader(rb)
+	}
+
+	url := fmt.Sprintf("%s/apisix/admin/%s", c.Endpoint, strings.TrimPrefix(objectPath, "/"))
+	req, err := http.NewRequestWithContext(ctx, method, url, requestBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if isFreshRead(ctx) {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
+
+	tflog.Debug(ctx, "Sending APISIX Admin API request", map[string]any{"method": method, "path": objectPath})
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Keep the error format of the api_client package
+	if res.StatusCode >= http.StatusBadRequest {
+		return resBody, res.Header, fmt.Errorf("status: %d, body: %s", res.StatusCode, resBody)
+	}
+
+	return resBody, res.Header, nil
+}
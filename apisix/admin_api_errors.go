@@ -0,0 +1,299 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// validationErrorStep is a step of the property path in an APISIX JSON schema
+// validation error, either a property name, a 0-based array index or a map key.
+type validationErrorStep struct {
+	Name  string
+	Index int
+	Key   string
+	Kind  validationErrorStepKind
+}
+
+type validationErrorStepKind int
+
+const (
+	validationErrorProperty validationErrorStepKind = iota
+	validationErrorItem
+	validationErrorKey
+)
+
+// validationError is an APISIX JSON schema validation error, e.g.
+// `invalid configuration: property "timeout" validation failed: property "connect" validation failed: expected 0 to be greater than 0`.
+type validationError struct {
+	// Steps is the property path of the invalid value
+	Steps []validationErrorStep
+	// Plugin is the name of the plugin with the invalid configuration, if any
+	Plugin string
+	// Message is the reason without the property path
+	Message string
+}
+
+var (
+	validationErrorPrefixPattern   = regexp.MustCompile(`^invalid (?:\S+ )?configuration: `)
+	validationErrorPluginPattern   = regexp.MustCompile(`^failed to check the configuration of (?:stream )?plugin (\S+) err: `)
+	validationErrorUnknownPattern  = regexp.MustCompile(`^unknown plugin \[?([^\]\s]+)\]?`)
+	validationErrorPropertyPattern = regexp.MustCompile(`^property "([^"]+)" validation failed: `)
+	validationErrorItemPattern     = regexp.MustCompile(`^failed to validate item (\d+): `)
+	validationErrorKeyPattern      = regexp.MustCompile(`^failed to validate (.+?) \(matching "[^"]*"\): `)
+)
+
+// parseValidationError extracts the APISIX JSON schema validation error
+// from an error returned by the Admin API client, which has the format
+// `status: 400, body: {"error_msg": "..."}`.
+func parseValidationError(err error) (*validationError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	_, body, found := strings.Cut(err.Error(), "body: ")
+	if !found {
+		return nil, false
+	}
+
+	var response struct {
+		ErrorMsg string `json:"error_msg"`
+	}
+	if json.Unmarshal([]byte(body), &response) != nil || response.ErrorMsg == "" {
+		return nil, false
+	}
+
+	message := validationErrorPrefixPattern.ReplaceAllString(response.ErrorMsg, "")
+	result := &validationError{}
+
+	if match := validationErrorUnknownPattern.FindStringSubmatch(message); match != nil {
+		result.Steps = append(result.Steps, validationErrorStep{Name: "plugins"})
+		result.Plugin = match[1]
+		result.Message = message
+		return result, true
+	}
+
+	for {
+		if match := validationErrorPluginPattern.FindStringSubmatch(message); match != nil {
+			result.Steps = append(result.Steps, validationErrorStep{Name: "plugins"}, validationErrorStep{Key: match[1], Kind: validationErrorKey})
+			result.Plugin = match[1]
+			message = message[len(match[0]):]
+			continue
+		}
+		if match := validationErrorPropertyPattern.FindStringSubmatch(message); match != nil {
+			result.Steps = append(result.Steps, validationErrorStep{Name: match[1]})
+			message = message[len(match[0]):]
+			continue
+		}
+		if match := validationErrorItemPattern.FindStringSubmatch(message); match != nil {
+			// APISIX counts the items from 1
+			index, _ := strconv.Atoi(match[1])
+			result.Steps = append(result.Steps, validationErrorStep{Index: index - 1, Kind: validationErrorItem})
+			message = message[len(match[0]):]
+			continue
+		}
+		if match := validationErrorKeyPattern.FindStringSubmatch(message); match != nil {
+			result.Steps = append(result.Steps, validationErrorStep{Key: match[1], Kind: validationErrorKey})
+			message = message[len(match[0]):]
+			continue
+		}
+		break
+	}
+
+	if len(result.Steps) == 0 {
+		return nil, false
+	}
+	result.Message = message
+
+	return result, true
+}
+
+// PropertyPath returns the property path in the APISIX object, e.g. `nodes[0].host`.
+func (e *validationError) PropertyPath() string {
+	var builder strings.Builder
+	for _, step := range e.Steps {
+		switch step.Kind {
+		case validationErrorItem:
+			builder.WriteString("[" + strconv.Itoa(step.Index) + "]")
+		case validationErrorKey:
+			builder.WriteString("[" + strconv.Quote(step.Key) + "]")
+		default:
+			if builder.Len() > 0 {
+				builder.WriteString(".")
+			}
+			builder.WriteString(step.Name)
+		}
+	}
+
+	return builder.String()
+}
+
+// apiAttributeNames maps the APISIX property names to the attribute names
+// when they differ.
+var apiAttributeNames = map[string]string{
+	"cert": "certificate",
+	"key":  "private_key",
+}
+
+// pluginsAttributeNames lists the attributes configuring the plugins, in the
+// order they are merged into the plugins sent to APISIX.
+var pluginsAttributeNames = []string{"plugins", "plugin", "plugins_typed", "sensitive_plugins"}
+
+// pluginAttribute returns the attribute of the plan configuring the plugin,
+// or `plugins` when none of them does.
+func pluginAttribute(ctx context.Context, plan tfsdk.Plan, name string) string {
+	for _, attributeName := range pluginsAttributeNames {
+		if _, ok := plan.Schema.GetAttributes()[attributeName]; !ok {
+			continue
+		}
+
+		var value types.String
+		switch attributeName {
+		case "plugin":
+			var plugin types.Dynamic
+			if plan.GetAttribute(ctx, path.Root(attributeName), &plugin).HasError() {
+				continue
+			}
+			value = model.PluginsValue(ctx, types.StringNull(), plugin)
+		case "plugins_typed":
+			pluginsTyped := types.ObjectNull(nil)
+			if plan.GetAttribute(ctx, path.Root(attributeName), &pluginsTyped).HasError() || pluginsTyped.IsUnknown() {
+				continue
+			}
+			value = model.PluginsTypedValue(ctx, types.StringNull(), pluginsTyped)
+		default:
+			if plan.GetAttribute(ctx, path.Root(attributeName), &value).HasError() {
+				continue
+			}
+		}
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		var pluginsJSON map[string]json.RawMessage
+		if json.Unmarshal([]byte(value.ValueString()), &pluginsJSON) != nil {
+			continue
+		}
+		if _, ok := pluginsJSON[name]; ok {
+			return attributeName
+		}
+	}
+
+	return "plugins"
+}
+
+// AttributePath returns the longest Terraform attribute path of the planned
+// resource matching the property path. The attributes holding JSON, like
+// `plugins`, and the sets end the path, the plugin errors point at the attribute
+// configuring the plugin, and the upstream nodes are keyed by their address.
+// It reports false when no attribute matches.
+func (e *validationError) AttributePath(ctx context.Context, plan tfsdk.Plan) (path.Path, bool) {
+	var attributePath path.Path
+	found := false
+
+	for i, step := range e.Steps {
+		var next path.Path
+		switch {
+		case i == 0 && step.Kind == validationErrorProperty:
+			name := step.Name
+			if _, ok := plan.Schema.GetAttributes()[name]; !ok {
+				name = apiAttributeNames[name]
+			}
+			if name == "plugins" && e.Plugin != "" {
+				name = pluginAttribute(ctx, plan, e.Plugin)
+			}
+			next = path.Root(name)
+		case i == 0:
+			return attributePath, false
+		case step.Kind == validationErrorItem:
+			attributeType, diags := plan.Schema.TypeAtPath(ctx, attributePath)
+			if diags.HasError() {
+				return attributePath, found
+			}
+			switch attributeType.(type) {
+			case basetypes.MapTypable:
+				// The maps sent as arrays, like the upstream nodes, are sent in the order of their keys
+				key, ok := mapKeyAtIndex(ctx, plan, attributePath, step.Index)
+				if !ok {
+					return attributePath, found
+				}
+				next = attributePath.AtMapKey(key)
+			case basetypes.SetTypable:
+				// The set elements are addressed by their value, the set itself is the closest attribute
+				return attributePath, found
+			default:
+				next = attributePath.AtListIndex(step.Index)
+			}
+		case step.Kind == validationErrorKey:
+			next = attributePath.AtMapKey(step.Key)
+		default:
+			next = attributePath.AtName(step.Name)
+		}
+
+		if _, diags := plan.Schema.TypeAtPath(ctx, next); diags.HasError() {
+			break
+		}
+		attributePath, found = next, true
+	}
+
+	return attributePath, found
+}
+
+// mapKeyAtIndex returns the key of the planned map at the index of its sorted keys.
+func mapKeyAtIndex(ctx context.Context, plan tfsdk.Plan, attributePath path.Path, index int) (string, bool) {
+	var value types.Map
+	if plan.GetAttribute(ctx, attributePath, &value).HasError() || value.IsNull() || value.IsUnknown() {
+		return "", false
+	}
+
+	keys := make([]string, 0, len(value.Elements()))
+	for key := range value.Elements() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if index < 0 || index >= len(keys) {
+		return "", false
+	}
+
+	return keys[index], true
+}
+
+// addAdminAPIError reports an error returned by the Admin API on create or
+// update. The JSON schema validation errors are attached to the offending
+// attribute with a readable summary, the raw message is kept in the detail.
+func addAdminAPIError(ctx context.Context, diags *diag.Diagnostics, plan tfsdk.Plan, err error, summary string, detail string) {
+	validationErr, ok := parseValidationError(err)
+	if !ok {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	readableSummary := summary + ": invalid " + validationErr.PropertyPath()
+	switch {
+	case strings.HasPrefix(validationErr.Message, "unknown plugin"):
+		readableSummary = summary + ": unknown plugin " + validationErr.Plugin
+	case validationErr.Plugin != "":
+		readableSummary = summary + ": invalid " + validationErr.Plugin + " plugin configuration"
+	}
+	readableDetail := validationErr.Message + "\n\n" + detail
+
+	attributePath, found := validationErr.AttributePath(ctx, plan)
+	if !found {
+		diags.AddError(readableSummary, readableDetail)
+		return
+	}
+
+	diags.AddAttributeError(attributePath, readableSummary, readableDetail)
+}
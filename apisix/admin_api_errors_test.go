@@ -0,0 +1,187 @@
+package apisix
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAddAdminAPIError(t *testing.T) {
+	testCases := map[string]struct {
+		schema   schema.Schema
+		values   map[string]tftypes.Value
+		errorMsg string
+		path     path.Path
+		summary  string
+		message  string
+	}{
+		"timeout": {
+			schema:   model.RouteSchema,
+			errorMsg: `invalid configuration: property \"timeout\" validation failed: property \"connect\" validation failed: expected 0 to be greater than 0`,
+			path:     path.Root("timeout").AtName("connect"),
+			summary:  "Error creating: invalid timeout.connect",
+			message:  "expected 0 to be greater than 0",
+		},
+		"plugin": {
+			schema:   model.RouteSchema,
+			errorMsg: `failed to check the configuration of plugin limit-count err: property \"count\" validation failed: expected 0 to be greater than 0`,
+			path:     path.Root("plugins"),
+			summary:  "Error creating: invalid limit-count plugin configuration",
+			message:  "expected 0 to be greater than 0",
+		},
+		"sensitive plugin": {
+			schema: model.ConsumerSchema,
+			values: map[string]tftypes.Value{
+				"plugins":           tftypes.NewValue(tftypes.String, `{"limit-count":{"count":1}}`),
+				"sensitive_plugins": tftypes.NewValue(tftypes.String, `{"key-auth":{"key":""}}`),
+			},
+			errorMsg: `failed to check the configuration of plugin key-auth err: property \"key\" validation failed: string too short, expected at least 1, got 0`,
+			path:     path.Root("sensitive_plugins"),
+			summary:  "Error creating: invalid key-auth plugin configuration",
+			message:  "string too short, expected at least 1, got 0",
+		},
+		"dynamic plugin": {
+			schema: model.RouteSchema,
+			values: map[string]tftypes.Value{
+				"plugin": tftypes.NewValue(
+					tftypes.Object{AttributeTypes: map[string]tftypes.Type{"limit-count": tftypes.Object{AttributeTypes: map[string]tftypes.Type{"count": tftypes.Number}}}},
+					map[string]tftypes.Value{"limit-count": tftypes.NewValue(
+						tftypes.Object{AttributeTypes: map[string]tftypes.Type{"count": tftypes.Number}},
+						map[string]tftypes.Value{"count": tftypes.NewValue(tftypes.Number, 0)},
+					)},
+				),
+			},
+			errorMsg: `failed to check the configuration of plugin limit-count err: property \"count\" validation failed: expected 0 to be greater than 0`,
+			path:     path.Root("plugin"),
+			summary:  "Error creating: invalid limit-count plugin configuration",
+			message:  "expected 0 to be greater than 0",
+		},
+		"unknown plugin": {
+			schema:   model.ConsumerSchema,
+			errorMsg: `unknown plugin [limit-counts]`,
+			path:     path.Root("plugins"),
+			summary:  "Error creating: unknown plugin limit-counts",
+			message:  "unknown plugin [limit-counts]",
+		},
+		"nodes": {
+			schema: model.UpstreamSchema,
+			values: map[string]tftypes.Value{
+				"nodes": tftypes.NewValue(
+					tftypes.Map{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"weight": tftypes.Number}}},
+					map[string]tftypes.Value{
+						"127.0.0.1:1980": tftypes.NewValue(
+							tftypes.Object{AttributeTypes: map[string]tftypes.Type{"weight": tftypes.Number}},
+							map[string]tftypes.Value{"weight": tftypes.NewValue(tftypes.Number, 1)},
+						),
+						"10.0.0.2:0": tftypes.NewValue(
+							tftypes.Object{AttributeTypes: map[string]tftypes.Type{"weight": tftypes.Number}},
+							map[string]tftypes.Value{"weight": tftypes.NewValue(tftypes.Number, 1)},
+						),
+					},
+				),
+			},
+			errorMsg: `invalid configuration: property \"nodes\" validation failed: failed to validate item 1: property \"port\" validation failed: expected 0 to be at least 1`,
+			// The nodes are sent in the order of their addresses
+			path:    path.Root("nodes").AtMapKey("10.0.0.2:0"),
+			summary: "Error creating: invalid nodes[0].port",
+			message: "expected 0 to be at least 1",
+		},
+		"route uris": {
+			schema: model.RouteSchema,
+			values: map[string]tftypes.Value{
+				"uris": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "/status"),
+					tftypes.NewValue(tftypes.String, ""),
+				}),
+			},
+			errorMsg: `invalid configuration: property \"uris\" validation failed: failed to validate item 2: string too short, expected at least 1, got 0`,
+			path:     path.Root("uris"),
+			summary:  "Error creating: invalid uris[1]",
+			message:  "string too short, expected at least 1, got 0",
+		},
+		"ssl snis": {
+			schema:   model.SSLCertificateSchema,
+			errorMsg: `invalid configuration: property \"snis\" validation failed: failed to validate item 1: failed to match pattern \"^[0-9a-zA-Z-._]+$\" with \"example com\"`,
+			path:     path.Root("snis"),
+			summary:  "Error creating: invalid snis[0]",
+			message:  `failed to match pattern "^[0-9a-zA-Z-._]+$" with "example com"`,
+		},
+		"checks": {
+			schema:   model.UpstreamSchema,
+			errorMsg: `invalid configuration: property \"checks\" validation failed: property \"active\" validation failed: property \"healthy\" validation failed: property \"interval\" validation failed: expected 0 to be at least 1`,
+			path:     path.Root("checks").AtName("active").AtName("healthy").AtName("interval"),
+			summary:  "Error creating: invalid checks.active.healthy.interval",
+			message:  "expected 0 to be at least 1",
+		},
+		"labels": {
+			schema:   model.ServiceSchema,
+			errorMsg: `invalid configuration: property \"labels\" validation failed: failed to validate my key (matching \"^\\S+$\"): wrong type`,
+			path:     path.Root("labels").AtMapKey("my key"),
+			summary:  `Error creating: invalid labels["my key"]`,
+			message:  "wrong type",
+		},
+		"renamed attribute": {
+			schema:   model.SSLCertificateSchema,
+			errorMsg: `invalid configuration: property \"cert\" validation failed: string too short, expected at least 128, got 10`,
+			path:     path.Root("certificate"),
+			summary:  "Error creating: invalid cert",
+			message:  "string too short, expected at least 128, got 10",
+		},
+		"unmodeled property": {
+			schema:   model.RouteSchema,
+			errorMsg: `invalid configuration: property \"upstream\" validation failed: property \"type\" validation failed: wrong type`,
+			summary:  "Error creating: invalid upstream.type",
+			message:  "wrong type",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := errors.New(`status: 400, body: {"error_msg":"` + testCase.errorMsg + `"}`)
+
+			config := testConfig(t, testCase.schema, testCase.values)
+			plan := tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+
+			var diags diag.Diagnostics
+			addAdminAPIError(context.Background(), &diags, plan, err, "Error creating", "Could not create, unexpected error: "+err.Error())
+
+			if len(diags) != 1 {
+				t.Fatalf("expected one diagnostic, got %v", diags)
+			}
+			if diags[0].Summary() != testCase.summary {
+				t.Errorf("expected summary %q, got %q", testCase.summary, diags[0].Summary())
+			}
+			if expected := testCase.message + "\n\nCould not create, unexpected error: " + err.Error(); diags[0].Detail() != expected {
+				t.Errorf("expected detail %q, got %q", expected, diags[0].Detail())
+			}
+
+			withPath, ok := diags[0].(diag.DiagnosticWithPath)
+			if len(testCase.path.Steps()) == 0 {
+				if ok {
+					t.Errorf("expected no attribute path, got %s", withPath.Path())
+				}
+				return
+			}
+			if !ok || !withPath.Path().Equal(testCase.path) {
+				t.Errorf("expected attribute path %s, got %v", testCase.path, diags[0])
+			}
+		})
+	}
+}
+
+func TestAddAdminAPIErrorUnexpected(t *testing.T) {
+	var diags diag.Diagnostics
+	addAdminAPIError(context.Background(), &diags, tfsdk.Plan{Schema: model.RouteSchema}, errors.New("connection refused"), "Error creating", "Could not create")
+
+	if len(diags) != 1 || diags[0].Summary() != "Error creating" || diags[0].Detail() != "Could not create" {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
@@ -0,0 +1,75 @@
+package apisix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// objectFields returns the top-level fields of an API object, without its identifier.
+func objectFields(object any) (map[string]interface{}, bool) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, false
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, false
+	}
+	delete(fields, "id")
+
+	return fields, true
+}
+
+// objectDifferences lists the top-level fields that differ between an object
+// read from APISIX and the one about to be written. It reports false when the
+// objects can't be compared.
+func objectDifferences(existing, planned any) ([]string, bool) {
+	existingFields, ok := objectFields(existing)
+	if !ok {
+		return nil, false
+	}
+
+	plannedFields, ok := objectFields(planned)
+	if !ok {
+		return nil, false
+	}
+
+	differences := []string{}
+	for name, value := range plannedFields {
+		if !reflect.DeepEqual(existingFields[name], value) {
+			differences = append(differences, name)
+		}
+	}
+	for name := range existingFields {
+		if _, ok := plannedFields[name]; !ok {
+			differences = append(differences, name)
+		}
+	}
+	sort.Strings(differences)
+
+	return differences, true
+}
+
+// addAdoptionWarning reports that an existing object was taken over on create.
+// Only the field names are listed, as the values may hold credentials.
+func addAdoptionWarning(diags *diag.Diagnostics, objectType string, id string, existing, planned any) {
+	detail := fmt.Sprintf("The %s %s already existed in APISIX and is now managed by Terraform.", objectType, id)
+
+	differences, ok := objectDifferences(existing, planned)
+	switch {
+	case !ok:
+		detail += " The existing object couldn't be compared with the configuration, it was overwritten."
+	case len(differences) == 0:
+		detail += " It already matched the configuration."
+	default:
+		detail += " The following fields differed from the configuration and were overwritten: " + strings.Join(differences, ", ") + "."
+	}
+
+	diags.AddWarning("Adopted Existing "+objectType, detail)
+}
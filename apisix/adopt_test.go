@@ -0,0 +1,186 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestObjectDifferences(t *testing.T) {
+	existing := map[string]interface{}{
+		"id":     "1",
+		"desc":   "Managed by the dashboard",
+		"labels": map[string]string{"team": "payments"},
+		"status": 1,
+	}
+	planned := map[string]interface{}{
+		"desc":   "Managed by Terraform",
+		"labels": map[string]string{"team": "payments"},
+		"name":   "checkout-api",
+	}
+
+	differences, ok := objectDifferences(existing, planned)
+	if !ok {
+		t.Fatal("expected the objects to be comparable")
+	}
+
+	expected := []string{"desc", "name", "status"}
+	if !reflect.DeepEqual(differences, expected) {
+		t.Errorf("expected %v, got %v", expected, differences)
+	}
+}
+
+// testPlan returns the plan of the schema with the given values, the other
+// attributes being null.
+func testPlan(t *testing.T, planSchema schema.Schema, values map[string]tftypes.Value) tfsdk.Plan {
+	t.Helper()
+
+	return tfsdk.Plan(testConfig(t, planSchema, values))
+}
+
+// emptyState returns the state of a resource not created yet.
+func emptyState(stateSchema schema.Schema) tfsdk.State {
+	return tfsdk.State{Schema: stateSchema, Raw: tftypes.NewValue(stateSchema.Type().TerraformType(context.Background()), nil)}
+}
+
+func TestCreateExistingObject(t *testing.T) {
+	testCases := map[string]struct {
+		existing      string
+		readStatus    int
+		adoptExisting bool
+		expectedError bool
+		expectedDesc  string
+	}{
+		"new consumer": {
+			expectedDesc: "Managed by Terraform",
+		},
+		"consumer of another owner": {
+			existing:      `{"username":"jack","desc":"Managed by the dashboard"}`,
+			expectedError: true,
+			expectedDesc:  "Managed by the dashboard",
+		},
+		"adopted consumer": {
+			existing:      `{"username":"jack","desc":"Managed by the dashboard"}`,
+			adoptExisting: true,
+			expectedDesc:  "Managed by Terraform",
+		},
+		"repeated create": {
+			existing:     `{"username":"jack","desc":"Managed by Terraform"}`,
+			expectedDesc: "Managed by Terraform",
+		},
+		"unreadable consumer": {
+			readStatus:    http.StatusInternalServerError,
+			expectedError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := newFakeAdminServer(t)
+			if testCase.readStatus != 0 {
+				server.failedReads = map[string]int{"consumers/jack": testCase.readStatus}
+			}
+			if testCase.existing != "" {
+				server.setObject("consumers/jack", testCase.existing)
+			}
+
+			consumer := &consumerResource{client: server.client()}
+			req := resource.CreateRequest{Plan: testPlan(t, model.ConsumerSchema, map[string]tftypes.Value{
+				"username":       tftypes.NewValue(tftypes.String, "jack"),
+				"desc":           tftypes.NewValue(tftypes.String, "Managed by Terraform"),
+				"adopt_existing": tftypes.NewValue(tftypes.Bool, testCase.adoptExisting),
+			})}
+			resp := &resource.CreateResponse{State: emptyState(model.ConsumerSchema)}
+			consumer.Create(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectedError {
+				t.Fatalf("expected error %t, got %v", testCase.expectedError, resp.Diagnostics)
+			}
+			if testCase.adoptExisting && resp.Diagnostics.WarningsCount() != 1 {
+				t.Errorf("expected the adoption warning, got %v", resp.Diagnostics)
+			}
+
+			stored, _ := server.object("consumers/jack")
+			var object struct {
+				Desc string `json:"desc"`
+			}
+			_ = json.Unmarshal(stored, &object)
+			if object.Desc != testCase.expectedDesc {
+				t.Errorf("expected the consumer description %q, got %q", testCase.expectedDesc, object.Desc)
+			}
+		})
+	}
+}
+
+func TestCreateRepeatedObject(t *testing.T) {
+	server := newFakeAdminServer(t)
+	server.onWrite = func(objectPath string, object map[string]interface{}) {
+		switch {
+		case strings.HasPrefix(objectPath, "upstreams/"):
+			object["scheme"] = "http"
+			object["pass_host"] = "pass"
+			object["hash_on"] = "vars"
+		case strings.HasPrefix(objectPath, "routes/"):
+			plugins := object["plugins"].(map[string]interface{})
+			limitCount := plugins["limit-count"].(map[string]interface{})
+			limitCount["policy"] = "local"
+			limitCount["key"] = "remote_addr"
+		}
+	}
+	client := server.client()
+
+	upstreamConfig := testConfig(t, model.UpstreamSchema, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "checkout"),
+		"type": tftypes.NewValue(tftypes.String, "roundrobin"),
+		"nodes": tftypes.NewValue(
+			tftypes.Map{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"weight": tftypes.Number}}},
+			map[string]tftypes.Value{"127.0.0.1:1980": tftypes.NewValue(
+				tftypes.Object{AttributeTypes: map[string]tftypes.Type{"weight": tftypes.Number}},
+				map[string]tftypes.Value{"weight": tftypes.NewValue(tftypes.Number, 1)},
+			)},
+		),
+	})
+	routeConfig := testConfig(t, model.RouteSchema, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "checkout"),
+		"uri":         tftypes.NewValue(tftypes.String, "/checkout"),
+		"upstream_id": tftypes.NewValue(tftypes.String, "checkout"),
+		"plugins":     tftypes.NewValue(tftypes.String, `{"limit-count":{"count":2,"time_window":60}}`),
+	})
+
+	// The apply is interrupted after the writes, and run again with the same configuration
+	for attempt := range 2 {
+		upstreamResp := &resource.CreateResponse{State: emptyState(model.UpstreamSchema)}
+		(&upstreamResource{client: client}).Create(context.Background(), resource.CreateRequest{Config: upstreamConfig, Plan: tfsdk.Plan(upstreamConfig)}, upstreamResp)
+		if upstreamResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error creating the upstream, attempt %d: %v", attempt, upstreamResp.Diagnostics)
+		}
+
+		routeResp := &resource.CreateResponse{State: emptyState(model.RouteSchema)}
+		(&routeResource{client: client}).Create(context.Background(), resource.CreateRequest{Config: routeConfig, Plan: tfsdk.Plan(routeConfig)}, routeResp)
+		if routeResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error creating the route, attempt %d: %v", attempt, routeResp.Diagnostics)
+		}
+	}
+
+	// A different configuration still collides with the existing object
+	otherRouteConfig := testConfig(t, model.RouteSchema, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "checkout"),
+		"uri":         tftypes.NewValue(tftypes.String, "/payments"),
+		"upstream_id": tftypes.NewValue(tftypes.String, "checkout"),
+	})
+	routeResp := &resource.CreateResponse{State: emptyState(model.RouteSchema)}
+	(&routeResource{client: client}).Create(context.Background(), resource.CreateRequest{Config: otherRouteConfig, Plan: tfsdk.Plan(otherRouteConfig)}, routeResp)
+	if !routeResp.Diagnostics.HasError() {
+		t.Error("expected the ID collision error")
+	}
+}
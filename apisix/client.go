@@ -0,0 +1,43 @@
+package apisix
+
+import (
+	"time"
+
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+// apisixClient wraps the APISIX Admin API client together with the
+// provider-level settings the resources need at plan and apply time.
+// It is passed to the resources as the provider ResourceData.
+type apisixClient struct {
+	*api_client.ApiClient
+
+	// apiVersion is the Admin API generation of the target cluster,
+	// either apiVersionV2 or apiVersionV3.
+	apiVersion string
+
+	// updateStrategy is the provider-wide way of updating the objects,
+	// either updateStrategyPut or updateStrategyPatch.
+	updateStrategy string
+
+	// versions holds the last seen version of the objects, recorded by objectVersionTransport.
+	versions *objectVersions
+
+	// checkReferences enables the plan-time checks of the referenced objects.
+	checkReferences bool
+
+	// deriveIDs derives the identifiers of the new objects from their configuration.
+	deriveIDs bool
+
+	// planned holds the objects planned for creation, which the references may point to.
+	planned *plannedObjects
+
+	// deleteRetryTimeout is how long the deletes of the objects still in use are retried.
+	deleteRetryTimeout time.Duration
+
+	// locks serialises the writes of the resources to the same object.
+	locks *objectLocks
+
+	// pluginSchemas resolves the JSON schemas of the plugins to validate them.
+	pluginSchemas *pluginSchemaStore
+}
@@ -0,0 +1,257 @@
+package apisix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	apiVersionV2 = "v2"
+	apiVersionV3 = "v3"
+)
+
+// v2UnsupportedAttribute describes an attribute that APISIX 2.x can't store.
+type v2UnsupportedAttribute struct {
+	Path path.Path
+	// Value limits the check to a specific attribute value. An empty Value
+	// means that any non-null value is unsupported.
+	Value string
+}
+
+// v2UnsupportedResources lists the resources without an APISIX 2.x Admin API counterpart.
+var v2UnsupportedResources = map[string]bool{
+	"apisix_consumer_group": true,
+	"apisix_plugin_config":  true,
+	"apisix_secret":         true,
+}
+
+// v2UnsupportedAttributes lists the attributes introduced with APISIX 3.x, per resource type.
+var v2UnsupportedAttributes = map[string][]v2UnsupportedAttribute{
+	"apisix_route": {
+		{Path: path.Root("plugin_config_id")},
+	},
+	"apisix_consumer": {
+		{Path: path.Root("group_id")},
+	},
+	"apisix_upstream": {
+		{Path: path.Root("tls").AtName("client_cert_id")},
+	},
+	"apisix_ssl_certificate": {
+		{Path: path.Root("type"), Value: "client"},
+	},
+}
+
+// checkAPIVersionSupport reports the planned values the configured
+// Admin API generation is not able to store.
+func (c *apisixClient) checkAPIVersionSupport(ctx context.Context, resourceType string, plan tfsdk.Plan, diags *diag.Diagnostics) {
+	// The provider isn't configured yet or the resource is planned for destruction
+	if c == nil || c.apiVersion != apiVersionV2 || plan.Raw.IsNull() {
+		return
+	}
+
+	if v2UnsupportedResources[resourceType] {
+		diags.AddError(
+			"Resource Not Supported by APISIX 2.x",
+			fmt.Sprintf("The %s resource has no counterpart in the APISIX 2.x Admin API. "+
+				"Remove it from the configuration or set the provider api_version to %q.", resourceType, apiVersionV3),
+		)
+		return
+	}
+
+	for _, attribute := range v2UnsupportedAttributes[resourceType] {
+		var value types.String
+		diags.Append(plan.GetAttribute(ctx, attribute.Path, &value)...)
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if attribute.Value != "" && value.ValueString() != attribute.Value {
+			continue
+		}
+
+		diags.AddAttributeError(
+			attribute.Path,
+			"Attribute Not Supported by APISIX 2.x",
+			fmt.Sprintf("The value %q of the attribute %s can't be stored by the APISIX 2.x Admin API. "+
+				"Remove it from the configuration or set the provider api_version to %q.", value.ValueString(), attribute.Path, apiVersionV3),
+		)
+	}
+}
+
+// adminAPIV2Transport translates the requests sent by the api_client package
+// to the APISIX 2.x Admin API, and its responses back to the 3.x format.
+type adminAPIV2Transport struct {
+	Nested http.RoundTripper
+}
+
+func (t adminAPIV2Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if v2Path, ok := translateV2SSLPath(r.URL.Path); ok {
+		r = r.Clone(r.Context())
+		r.URL.Path = v2Path
+		r.URL.RawPath = ""
+
+		if r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				return nil, err
+			}
+			r.Body.Close()
+
+			body = translateV2SSLRequest(body)
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+		}
+	}
+
+	res, err := t.Nested.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	body = translateV2Response(body)
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	res.ContentLength = int64(len(body))
+	res.Header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return res, nil
+}
+
+// translateV2SSLPath returns the path of the SSL objects in the APISIX 2.x
+// Admin API, `/apisix/admin/ssl`, for the paths of the 3.x one, `/apisix/admin/ssls`.
+func translateV2SSLPath(urlPath string) (string, bool) {
+	const v3Path, v2Path = "/apisix/admin/ssls", "/apisix/admin/ssl"
+
+	index := strings.Index(urlPath, v3Path)
+	if index < 0 {
+		return urlPath, false
+	}
+	rest := urlPath[index+len(v3Path):]
+	if rest != "" && !strings.HasPrefix(rest, "/") {
+		return urlPath, false
+	}
+
+	return urlPath[:index] + v2Path + rest, true
+}
+
+// translateV2SSLRequest drops the SSL type, which APISIX 2.x doesn't know.
+// Only server certificates exist there, the client ones are rejected at plan time.
+func translateV2SSLRequest(body []byte) []byte {
+	var ssl map[string]interface{}
+	if err := json.Unmarshal(body, &ssl); err != nil {
+		return body
+	}
+
+	if ssl["type"] != "server" {
+		return body
+	}
+	delete(ssl, "type")
+
+	translated, err := json.Marshal(ssl)
+	if err != nil {
+		return body
+	}
+
+	return translated
+}
+
+// v2Node is an etcd node as returned by the APISIX 2.x Admin API.
+type v2Node struct {
+	Key           string                 `json:"key"`
+	Value         map[string]interface{} `json:"value,omitempty"`
+	Dir           bool                   `json:"dir,omitempty"`
+	Nodes         []v2Node               `json:"nodes,omitempty"`
+	ModifiedIndex json.Number            `json:"modifiedIndex,omitempty"`
+}
+
+// translateV2Response unwraps the `node` envelope of an APISIX 2.x Admin API
+// response, so it matches the 3.x format of `{"key": ..., "value": ...}`, along
+// with the `modifiedIndex` of the node, for single objects and `{"total": ..., "list": [...]}`
+// for collections, each item keeping its own `modifiedIndex`.
+func translateV2Response(body []byte) []byte {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body
+	}
+
+	rawNode, ok := response["node"]
+	if !ok {
+		return body
+	}
+
+	var node v2Node
+	if err := json.Unmarshal(rawNode, &node); err != nil {
+		return body
+	}
+
+	translated := map[string]interface{}{}
+	for key, value := range response {
+		switch key {
+		case "node", "action", "count":
+		default:
+			translated[key] = value
+		}
+	}
+
+	if node.Dir {
+		list := []map[string]interface{}{}
+		for _, item := range node.Nodes {
+			translatedItem := map[string]interface{}{
+				"key":   item.Key,
+				"value": v2ValueWithID(item.Key, item.Value),
+			}
+			if item.ModifiedIndex != "" {
+				translatedItem["modifiedIndex"] = item.ModifiedIndex
+			}
+			list = append(list, translatedItem)
+		}
+		translated["total"] = len(list)
+		translated["list"] = list
+	} else {
+		if node.Key != "" {
+			translated["key"] = node.Key
+		}
+		if node.Value != nil {
+			translated["value"] = v2ValueWithID(node.Key, node.Value)
+		}
+		if node.ModifiedIndex != "" {
+			translated["modifiedIndex"] = node.ModifiedIndex
+		}
+	}
+
+	result, err := json.Marshal(translated)
+	if err != nil {
+		return body
+	}
+
+	return result
+}
+
+// v2ValueWithID fills in the object ID from the etcd key, e.g. `/apisix/routes/1`,
+// when APISIX 2.x omits it from the value.
+func v2ValueWithID(key string, value map[string]interface{}) map[string]interface{} {
+	if value == nil {
+		return nil
+	}
+
+	if _, ok := value["id"]; !ok && key != "" && !strings.HasPrefix(key, "/apisix/consumers/") {
+		value["id"] = key[strings.LastIndex(key, "/")+1:]
+	}
+
+	return value
+}
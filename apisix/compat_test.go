@@ -0,0 +1,153 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+func TestTranslateV2Response(t *testing.T) {
+	testCases := map[string]struct {
+		response string
+		expected string
+	}{
+		"object": {
+			response: `{"action":"get","count":"1","node":{"key":"/apisix/routes/1","value":{"id":"1","uri":"/status"}}}`,
+			expected: `{"key":"/apisix/routes/1","value":{"id":"1","uri":"/status"}}`,
+		},
+		"object without id": {
+			response: `{"action":"set","node":{"key":"/apisix/upstreams/00000000000000000042","value":{"type":"roundrobin"}}}`,
+			expected: `{"key":"/apisix/upstreams/00000000000000000042","value":{"id":"00000000000000000042","type":"roundrobin"}}`,
+		},
+		"object with modified index": {
+			response: `{"action":"get","node":{"key":"/apisix/routes/1","value":{"id":"1","uri":"/status"},"modifiedIndex":42,"createdIndex":40}}`,
+			expected: `{"key":"/apisix/routes/1","value":{"id":"1","uri":"/status"},"modifiedIndex":42}`,
+		},
+		"consumer": {
+			response: `{"action":"get","node":{"key":"/apisix/consumers/jack","value":{"username":"jack"}}}`,
+			expected: `{"key":"/apisix/consumers/jack","value":{"username":"jack"}}`,
+		},
+		"delete": {
+			response: `{"action":"delete","deleted":"1","key":"/apisix/routes/1","node":{"key":"/apisix/routes/1"}}`,
+			expected: `{"deleted":"1","key":"/apisix/routes/1"}`,
+		},
+		"list": {
+			response: `{"action":"get","count":"1","node":{"key":"/apisix/routes","dir":true,"nodes":[{"key":"/apisix/routes/1","value":{"uri":"/status"}}]}}`,
+			expected: `{"total":1,"list":[{"key":"/apisix/routes/1","value":{"id":"1","uri":"/status"}}]}`,
+		},
+		"list with modified indexes": {
+			response: `{"action":"get","node":{"key":"/apisix/routes","dir":true,"nodes":[{"key":"/apisix/routes/1","value":{"uri":"/status"},"modifiedIndex":42,"createdIndex":40},{"key":"/apisix/routes/2","value":{"uri":"/health"},"modifiedIndex":57,"createdIndex":57}]}}`,
+			expected: `{"total":2,"list":[{"key":"/apisix/routes/1","value":{"id":"1","uri":"/status"},"modifiedIndex":42},{"key":"/apisix/routes/2","value":{"id":"2","uri":"/health"},"modifiedIndex":57}]}`,
+		},
+		"v3 response": {
+			response: `{"key":"/apisix/routes/1","value":{"id":"1","uri":"/status"}}`,
+			expected: `{"key":"/apisix/routes/1","value":{"id":"1","uri":"/status"}}`,
+		},
+		"not json": {
+			response: `404 Not Found`,
+			expected: `404 Not Found`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := translateV2Response([]byte(testCase.response))
+
+			var gotValue, expectedValue interface{}
+			if err := json.Unmarshal([]byte(testCase.expected), &expectedValue); err != nil {
+				if string(got) != testCase.expected {
+					t.Fatalf("expected %s, got %s", testCase.expected, got)
+				}
+				return
+			}
+			if err := json.Unmarshal(got, &gotValue); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(gotValue, expectedValue) {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestTranslateV2SSLRequest(t *testing.T) {
+	got := translateV2SSLRequest([]byte(`{"cert":"crt","key":"key","type":"server"}`))
+	if string(got) != `{"cert":"crt","key":"key"}` {
+		t.Errorf("unexpected request body: %s", got)
+	}
+}
+
+func TestAdminAPIV2TransportSSLPath(t *testing.T) {
+	server := newFakeAdminServer(t)
+	server.apiVersion2 = true
+
+	client := &http.Client{Transport: adminAPIV2Transport{Nested: http.DefaultTransport}}
+	requests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPut, "/apisix/admin/ssls/1", `{"cert":"crt","key":"key","type":"server"}`},
+		{http.MethodGet, "/apisix/admin/ssls", ""},
+		{http.MethodGet, "/apisix/admin/routes/1", ""},
+	}
+	for _, request := range requests {
+		req, err := http.NewRequest(request.method, server.URL+request.path, strings.NewReader(request.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	expectedRequests := []string{"PUT /apisix/admin/ssl/1", "GET /apisix/admin/ssl", "GET /apisix/admin/routes/1"}
+	if got := server.requests(); !reflect.DeepEqual(got, expectedRequests) {
+		t.Errorf("expected the requests %q, got %q", expectedRequests, got)
+	}
+	if ssl, _ := server.object("ssl/1"); string(ssl) != `{"cert":"crt","key":"key"}` {
+		t.Errorf("unexpected SSL request body: %s", ssl)
+	}
+}
+
+func TestAdminAPIV2TransportList(t *testing.T) {
+	server := newFakeAdminServer(t)
+	server.apiVersion2 = true
+	server.withVersions = true
+	server.setObject("routes/1", `{"uri":"/status"}`)
+	server.setObject("routes/2", `{"uri":"/health"}`)
+
+	client := &apisixClient{ApiClient: &api_client.ApiClient{
+		Endpoint:   server.URL,
+		HTTPClient: &http.Client{Transport: adminAPIV2Transport{Nested: http.DefaultTransport}},
+	}}
+
+	// APISIX 2.x lists all the objects at once, with their IDs in the keys only
+	objects, err := listObjects(context.Background(), "routes", func(query string) ([]byte, error) {
+		return client.adminDo(context.Background(), http.MethodGet, "routes?"+query, nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var route struct {
+		Value         map[string]interface{} `json:"value"`
+		ModifiedIndex int                    `json:"modifiedIndex"`
+	}
+	if err := json.Unmarshal(objects["2"], &route); err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 || route.Value["id"] != "2" || route.ModifiedIndex != 2 {
+		t.Errorf("expected the translated routes, got %s", objects["2"])
+	}
+	if count := server.count("GET /apisix/admin/routes"); count != 1 {
+		t.Errorf("expected the routes to be listed at once, got %d requests", count)
+	}
+}
@@ -0,0 +1,220 @@
+package apisix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// objectVersionPrivateStateKey is the private state key holding the version
+// of the object at the last refresh.
+const objectVersionPrivateStateKey = "object_version"
+
+// objectVersion identifies a revision of an APISIX object. ModifiedIndex is the
+// etcd revision of the last change, UpdateTime its timestamp in seconds.
+// APISIX doesn't return both in every response, a zero value means unknown.
+// CreateTime is kept along for the create_time attribute, it isn't compared.
+type objectVersion struct {
+	ModifiedIndex int64 `json:"modified_index,omitempty"`
+	UpdateTime    int64 `json:"update_time,omitempty"`
+	CreateTime    int64 `json:"create_time,omitempty"`
+}
+
+// IsZero reports whether nothing is known about the version.
+func (v objectVersion) IsZero() bool {
+	return v.ModifiedIndex == 0 && v.UpdateTime == 0
+}
+
+// Differs reports whether two versions are known to be different revisions.
+// Only the parts known on both sides are compared.
+func (v objectVersion) Differs(other objectVersion) bool {
+	if v.ModifiedIndex != 0 && other.ModifiedIndex != 0 && v.ModifiedIndex != other.ModifiedIndex {
+		return true
+	}
+
+	return v.UpdateTime != 0 && other.UpdateTime != 0 && v.UpdateTime != other.UpdateTime
+}
+
+// parseObjectVersion reads the version of the object in an Admin API response.
+func parseObjectVersion(body []byte) (objectVersion, bool) {
+	var response struct {
+		ModifiedIndex json.Number `json:"modifiedIndex"`
+		Value         *struct {
+			CreateTime json.Number `json:"create_time"`
+			UpdateTime json.Number `json:"update_time"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || response.Value == nil {
+		return objectVersion{}, false
+	}
+
+	version := objectVersion{}
+	version.ModifiedIndex, _ = strconv.ParseInt(response.ModifiedIndex.String(), 10, 64)
+	version.UpdateTime, _ = strconv.ParseInt(response.Value.UpdateTime.String(), 10, 64)
+	version.CreateTime, _ = strconv.ParseInt(response.Value.CreateTime.String(), 10, 64)
+
+	return version, !version.IsZero()
+}
+
+// privateState is the part of the framework private state the resources use,
+// shared by the request and the response private data.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// objectVersions remembers the last seen version of each object, keyed by the
+// object path relative to `/apisix/admin`, e.g. `routes/1`.
+type objectVersions struct {
+	mu       sync.Mutex
+	versions map[string]objectVersion
+}
+
+func newObjectVersions() *objectVersions {
+	return &objectVersions{versions: map[string]objectVersion{}}
+}
+
+func (v *objectVersions) get(objectPath string) (objectVersion, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	version, ok := v.versions[objectPath]
+
+	return version, ok
+}
+
+func (v *objectVersions) set(objectPath string, version objectVersion) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.versions[objectPath] = version
+}
+
+// objectVersionTransport records the version of the objects returned by the
+// Admin API, as the api_client package drops it from the objects it decodes.
+type objectVersionTransport struct {
+	Nested   http.RoundTripper
+	versions *objectVersions
+}
+
+func (t objectVersionTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	res, err := t.Nested.RoundTrip(r)
+	if err != nil || res.StatusCode >= http.StatusBadRequest || r.Method == http.MethodDelete {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	if version, ok := parseObjectVersion(body); ok {
+		t.versions.set(responseObjectPath(r.URL.Path, body), version)
+	}
+
+	return res, nil
+}
+
+// adminObjectPath returns the object path relative to `/apisix/admin` of an Admin API URL path.
+func adminObjectPath(urlPath string) string {
+	if index := strings.Index(urlPath, "/apisix/admin/"); index >= 0 {
+		urlPath = urlPath[index+len("/apisix/admin/"):]
+	}
+
+	return strings.Trim(urlPath, "/")
+}
+
+// responseObjectPath returns the object path relative to `/apisix/admin` of
+// the object in an Admin API response, from its etcd key like
+// `/apisix/routes/1`. The objects created without their ID in the URL, like
+// the consumers, are only identified by the key.
+func responseObjectPath(urlPath string, body []byte) string {
+	var response struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || !strings.HasPrefix(response.Key, "/apisix/") {
+		return adminObjectPath(urlPath)
+	}
+
+	return strings.Trim(strings.TrimPrefix(response.Key, "/apisix/"), "/")
+}
+
+// recordObjectVersion saves the last seen version of the object into the
+// private state, to detect the changes made outside Terraform on the next write.
+func (c *apisixClient) recordObjectVersion(ctx context.Context, objectPath string, private privateState, diags *diag.Diagnostics) {
+	version, ok := c.versions.get(objectPath)
+	if !ok {
+		return
+	}
+
+	value, err := json.Marshal(version)
+	if err != nil {
+		return
+	}
+
+	diags.Append(private.SetKey(ctx, objectVersionPrivateStateKey, value)...)
+}
+
+// checkConcurrentChanges re-reads the object and fails when it was modified
+// since the version recorded in the private state at the last refresh,
+// unless ignoreConcurrentChanges is set.
+func (c *apisixClient) checkConcurrentChanges(ctx context.Context, objectType string, objectPath string, ignoreConcurrentChanges types.Bool, private privateState, diags *diag.Diagnostics) {
+	if ignoreConcurrentChanges.ValueBool() {
+		return
+	}
+
+	value, getDiags := private.GetKey(ctx, objectVersionPrivateStateKey)
+	diags.Append(getDiags...)
+	if getDiags.HasError() || len(value) == 0 {
+		return
+	}
+
+	var recorded objectVersion
+	if err := json.Unmarshal(value, &recorded); err != nil || recorded.IsZero() {
+		return
+	}
+
+	body, err := c.adminDo(withFreshRead(ctx), http.MethodGet, objectPath, nil)
+	if err != nil {
+		// The object is gone or APISIX is unavailable, the write reports it
+		tflog.Debug(ctx, "Could not read the object version", map[string]any{"path": objectPath, "error": err.Error()})
+		return
+	}
+
+	current, ok := parseObjectVersion(body)
+	if !ok || !current.Differs(recorded) {
+		return
+	}
+
+	diags.AddError(
+		"APISIX "+objectType+" Modified Outside Terraform",
+		fmt.Sprintf("The %s at %s was modified outside Terraform since last refresh. "+
+			"Refresh the state and review the plan to keep the other changes, "+
+			"or set ignore_concurrent_changes = true to overwrite them.", strings.ToLower(objectType), objectPath),
+	)
+}
+
+// readClient returns the client refreshing an object. The objects without a
+// recorded version, like the ones just imported, are read from APISIX rather
+// than from the list snapshots.
+func (c *apisixClient) readClient(ctx context.Context, private privateState, diags *diag.Diagnostics) *apisixClient {
+	value, getDiags := private.GetKey(ctx, objectVersionPrivateStateKey)
+	diags.Append(getDiags...)
+	if len(value) == 0 {
+		return c.withFreshReads(ctx)
+	}
+
+	return c
+}
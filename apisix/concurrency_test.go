@@ -0,0 +1,72 @@
+package apisix
+
+import (
+	"testing"
+)
+
+func TestParseObjectVersion(t *testing.T) {
+	testCases := map[string]struct {
+		response string
+		expected objectVersion
+		ok       bool
+	}{
+		"object": {
+			response: `{"key":"/apisix/upstreams/1","value":{"id":"1","create_time":1600000000,"update_time":1700000000},"modifiedIndex":42}`,
+			expected: objectVersion{ModifiedIndex: 42, UpdateTime: 1700000000, CreateTime: 1600000000},
+			ok:       true,
+		},
+		"without modified index": {
+			response: `{"key":"/apisix/upstreams/1","value":{"id":"1","update_time":1700000000}}`,
+			expected: objectVersion{UpdateTime: 1700000000},
+			ok:       true,
+		},
+		"list": {
+			response: `{"total":1,"list":[{"key":"/apisix/upstreams/1","value":{"id":"1"}}]}`,
+			ok:       false,
+		},
+		"not json": {
+			response: `404 Not Found`,
+			ok:       false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseObjectVersion([]byte(testCase.response))
+			if ok != testCase.ok || got != testCase.expected {
+				t.Errorf("expected %+v (%t), got %+v (%t)", testCase.expected, testCase.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestObjectVersionDiffers(t *testing.T) {
+	recorded := objectVersion{ModifiedIndex: 42, UpdateTime: 1700000000}
+
+	testCases := map[string]struct {
+		current  objectVersion
+		expected bool
+	}{
+		"same":                   {current: recorded, expected: false},
+		"modified index changed": {current: objectVersion{ModifiedIndex: 43, UpdateTime: 1700000000}, expected: true},
+		"update time changed":    {current: objectVersion{ModifiedIndex: 42, UpdateTime: 1700000100}, expected: true},
+		"only update time known": {current: objectVersion{UpdateTime: 1700000000}, expected: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := testCase.current.Differs(recorded); got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestAdminObjectPath(t *testing.T) {
+	if got := adminObjectPath("/apisix/admin/secrets/vault/1"); got != "secrets/vault/1" {
+		t.Errorf("unexpected object path: %s", got)
+	}
+	if got := adminObjectPath("/gateway/apisix/admin/routes/1/"); got != "routes/1" {
+		t.Errorf("unexpected object path: %s", got)
+	}
+}
@@ -4,21 +4,24 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/holubovskyi/apisix-client-go"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &consumerGroupResource{}
-	_ resource.ResourceWithConfigure   = &consumerGroupResource{}
-	_ resource.ResourceWithImportState = &consumerGroupResource{}
+	_ resource.Resource                   = &consumerGroupResource{}
+	_ resource.ResourceWithConfigure      = &consumerGroupResource{}
+	_ resource.ResourceWithImportState    = &consumerGroupResource{}
+	_ resource.ResourceWithIdentity       = &consumerGroupResource{}
+	_ resource.ResourceWithUpgradeState   = &consumerGroupResource{}
+	_ resource.ResourceWithValidateConfig = &consumerGroupResource{}
+	_ resource.ResourceWithModifyPlan     = &consumerGroupResource{}
 )
 
 // NewConsumerGroupResource is a helper function to simplify the provider implementation.
@@ -28,7 +31,7 @@ func NewConsumerGroupResource() resource.Resource {
 
 // consumerGroupResource is the resource implementation.
 type consumerGroupResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -41,18 +44,49 @@ func (r *consumerGroupResource) Schema(_ context.Context, _ resource.SchemaReque
 	resp.Schema = model.ConsumerGroupSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *consumerGroupResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.ObjectIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *consumerGroupResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.ConsumerGroupStateUpgrades)
+}
+
+// Implement config validation
+func (r *consumerGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Reject the unknown plugins and the plugin configurations not matching their JSON schema
+	r.client.checkPluginSchemas(ctx, req.Config, "", &resp.Diagnostics)
+}
+
+// Implement plan modification
+func (r *consumerGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_consumer_group", req.Plan, &resp.Diagnostics)
+
+	// Expect a new update time for the consumer groups about to be updated
+	planUpdateTime(ctx, req, resp)
+
+	// Make sure the objects referenced in the plugins exist before anything is applied
+	r.client.checkPluginReferences(ctx, resp.Plan, &resp.Diagnostics)
+
+	// Let the references to the new consumer groups pass before they are created
+	r.client.registerPlannedObject(ctx, "consumer_groups", req, resp, path.Root("id"))
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *consumerGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -71,13 +105,48 @@ func (r *consumerGroupResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Consumer Group", plan.ID, createTimeout)
+
 	// Generate API request body from plan
 	newConsumerGroupRequest := model.ConsumerGroupFromTerraformToApi(ctx, &plan)
 
+	// Keep the other resources writing the consumer group waiting until it's created
+	unlock := client.lockObject(ctx, "Consumer Group", "consumer_groups/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing consumer group when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetConsumerGroup(plan.ID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Consumer Group",
+			"Could not read APISIX Consumer Group by ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Consumer Group", plan.ID.ValueString(), existing, newConsumerGroupRequest)
+		} else if !sameObject(existing, newConsumerGroupRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "Consumer Group", plan.ID.ValueString())
+			return
+		}
+	}
+
 	// Create new consumer group
-	newConsumerGroupResponse, err := r.client.CreateConsumerGroup(plan.ID.ValueString(), newConsumerGroupRequest)
+	newConsumerGroupResponse, err := client.CreateConsumerGroup(plan.ID.ValueString(), newConsumerGroupRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Consumer Group",
 			"Could not create Consumer Group, unexpected error: "+err.Error(),
 		)
@@ -86,10 +155,22 @@ func (r *consumerGroupResource) Create(ctx context.Context, req resource.CreateR
 
 	// Map response body to schema and populate Computed attribute values
 	newState := model.ConsumerGroupFromApiToTerraform(ctx, newConsumerGroupResponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("consumer_groups/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins)
 	}
 
+	// Remember the version of the consumer group to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "consumer_groups/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the consumer group by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -110,7 +191,7 @@ func (r *consumerGroupResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	// Get refreshed consumer group from the APISIX
-	consumerGroupStateResponse, err := r.client.GetConsumerGroup(state.ID.ValueString())
+	consumerGroupStateResponse, err := r.client.readClient(ctx, req.Private, &resp.Diagnostics).GetConsumerGroup(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Consumer Group",
@@ -121,10 +202,23 @@ func (r *consumerGroupResource) Read(ctx context.Context, req resource.ReadReque
 
 	// Overwrite with refreshed state
 	newState := model.ConsumerGroupFromApiToTerraform(ctx, consumerGroupStateResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.ForceDelete = state.ForceDelete
+	newState.CreateTime, newState.UpdateTime = r.client.objectTimestamps("consumer_groups/" + state.ID.ValueString())
+	newState.UpdateStrategy = state.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+		// Compare with the plugins of APISIX without the defaults it sets
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins, _ = r.client.readPlugins(ctx, "", newState.Plugins, state.Plugins, state.Plugin, state.SensitivePlugins, types.ObjectNull(nil))
 	}
 
+	// Remember the version of the consumer group to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "consumer_groups/"+state.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the consumer group by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -144,34 +238,74 @@ func (r *consumerGroupResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Consumer Group", plan.ID, updateTimeout)
+
+	// Keep the other resources writing the consumer group waiting until it's updated
+	unlock := client.lockObject(ctx, "Consumer Group", "consumer_groups/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the consumer group wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Consumer Group", "consumer_groups/"+plan.ID.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	updateConsumerGroupRequest := model.ConsumerGroupFromTerraformToApi(ctx, &plan)
 
-	// Update existing consumer group
-	_, err := r.client.UpdateConsumerGroup(plan.ID.ValueString(), updateConsumerGroupRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating APISIX Consumer Group",
-			"Could not update Consumer Group, unexpected error: "+err.Error(),
-		)
+	// Retrieve values from state, the patch update strategy sends only the changes
+	var state model.ConsumerGroupResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Fetch updated consumer group
-	updatedConsumerGroup, err := r.client.GetConsumerGroup(plan.ID.ValueString())
+	// Generate API request body from state
+	priorConsumerGroupRequest := model.ConsumerGroupFromTerraformToApi(ctx, &state)
+
+	// Update existing consumer group, the response holds the updated consumer group
+	updatedConsumerGroup, err := updateObject(ctx, client, plan.UpdateStrategy, "consumer_groups/"+plan.ID.ValueString(), priorConsumerGroupRequest, updateConsumerGroupRequest, func() (*api_client.ConsumerGroup, error) {
+		return client.UpdateConsumerGroup(plan.ID.ValueString(), updateConsumerGroupRequest)
+	}, func() (*api_client.ConsumerGroup, error) {
+		return client.GetConsumerGroup(plan.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Consumer Group",
-			"Could not read APISIX Consumer Group by ID "+plan.ID.ValueString()+": "+err.Error(),
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
+			"Error Updating APISIX Consumer Group",
+			"Could not update Consumer Group, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
 	newState := model.ConsumerGroupFromApiToTerraform(ctx, updatedConsumerGroup)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("consumer_groups/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins)
 	}
 
+	// Remember the version of the consumer group to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "consumer_groups/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the consumer group by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -191,10 +325,35 @@ func (r *consumerGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Consumer Group", state.ID, deleteTimeout)
+
+	// Keep the other resources writing the consumer group waiting until it's deleted
+	unlock := client.lockObject(ctx, "Consumer Group", "consumer_groups/"+state.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the consumer group wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Consumer Group", "consumer_groups/"+state.ID.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the consumer group
-	err := r.client.DeleteConsumerGroup(state.ID.ValueString())
+	err := client.deleteObject(ctx, "consumer_groups/"+state.ID.ValueString(), state.ForceDelete, func() error {
+		return client.DeleteConsumerGroup(state.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		client.addDeleteError(ctx, &resp.Diagnostics, "consumer_groups/"+state.ID.ValueString(), err,
 			"Error Deleting APISIX Consumer Group",
 			"Could not delete Consumer Group, unexpected error: "+err.Error(),
 		)
@@ -205,6 +364,8 @@ func (r *consumerGroupResource) Delete(ctx context.Context, req resource.DeleteR
 // Import resource into state
 func (r *consumerGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the consumer group importing")
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Retrieve import ID or identity and save to 'id' attribute
+	id := importIdentifier(ctx, req, path.Root("id"), &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: types.StringValue(id)}, &resp.Diagnostics)
 }
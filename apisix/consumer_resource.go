@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -16,9 +14,14 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &consumerResource{}
-	_ resource.ResourceWithConfigure   = &consumerResource{}
-	_ resource.ResourceWithImportState = &consumerResource{}
+	_ resource.Resource                   = &consumerResource{}
+	_ resource.ResourceWithConfigure      = &consumerResource{}
+	_ resource.ResourceWithImportState    = &consumerResource{}
+	_ resource.ResourceWithIdentity       = &consumerResource{}
+	_ resource.ResourceWithUpgradeState   = &consumerResource{}
+	_ resource.ResourceWithMoveState      = &consumerResource{}
+	_ resource.ResourceWithValidateConfig = &consumerResource{}
+	_ resource.ResourceWithModifyPlan     = &consumerResource{}
 )
 
 // NewConsumerResource is a helper function to simplify the provider implementation.
@@ -28,7 +31,7 @@ func NewConsumerResource() resource.Resource {
 
 // consumerResource is the resource implementation.
 type consumerResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -41,18 +44,57 @@ func (r *consumerResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	resp.Schema = model.ConsumerSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *consumerResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.ConsumerIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *consumerResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.ConsumerStateUpgrades)
+}
+
+// MoveState moves the state of the consumers of the other APISIX providers.
+func (r *consumerResource) MoveState(_ context.Context) []resource.StateMover {
+	return moveStateFrom(model.ConsumerSchema, []string{"consumer"}, moveJSONStrings("plugins"))
+}
+
+// Implement config validation
+func (r *consumerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Reject the unknown plugins and the plugin configurations not matching their JSON schema
+	r.client.checkPluginSchemas(ctx, req.Config, pluginSchemaTypeConsumer, &resp.Diagnostics)
+}
+
+// Implement plan modification
+func (r *consumerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_consumer", req.Plan, &resp.Diagnostics)
+
+	// Expect a new update time for the consumers about to be updated
+	planUpdateTime(ctx, req, resp)
+
+	// Make sure the referenced objects exist before anything is applied
+	r.client.checkObjectReferences(ctx, "apisix_consumer", resp.Plan, &resp.Diagnostics)
+
+	// Make sure the objects referenced in the plugins exist before anything is applied
+	r.client.checkPluginReferences(ctx, resp.Plan, &resp.Diagnostics)
+
+	// Let the references to the new consumers pass before they are created
+	r.client.registerPlannedObject(ctx, "consumers", req, resp, path.Root("username"))
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *consumerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -71,13 +113,48 @@ func (r *consumerResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Consumer", plan.Username, createTimeout)
+
 	// Generate API request body from plan
 	newConsumerRequest := model.ConsumerFromTerraformToApi(ctx, &plan)
 
+	// Keep the other resources writing the consumer waiting until it's created
+	unlock := client.lockObject(ctx, "Consumer", "consumers/"+plan.Username.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing consumer when requested, or make sure the username isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetConsumer(plan.Username.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Consumer",
+			"Could not read APISIX Consumer by name "+plan.Username.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Consumer", plan.Username.ValueString(), existing, newConsumerRequest)
+		} else if !sameObject(existing, newConsumerRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("username"), "Consumer", plan.Username.ValueString())
+			return
+		}
+	}
+
 	// Create new consumer
-	newConsumerResponse, err := r.client.CreateConsumer(newConsumerRequest)
+	newConsumerResponse, err := client.CreateConsumer(newConsumerRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Consumer",
 			"Could not create Consumer, unexpected error: "+err.Error(),
 		)
@@ -86,10 +163,21 @@ func (r *consumerResource) Create(ctx context.Context, req resource.CreateReques
 
 	// Map response body to schema and populate Computed attribute values
 	newState := model.ConsumerFromApiToTerraform(ctx, newConsumerResponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("consumers/" + plan.Username.ValueString())
+	newState.PluginsTyped = plan.PluginsTyped
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins, plan.PluginsTyped)
 	}
 
+	// Remember the version of the consumer to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "consumers/"+plan.Username.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the consumer by its username
+	setIdentity(ctx, resp.Identity, model.ConsumerIdentityModel{Username: newState.Username}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -110,7 +198,7 @@ func (r *consumerResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Get refreshed service from the APISIX
-	consumerStateResponse, err := r.client.GetConsumer(state.Username.ValueString())
+	consumerStateResponse, err := r.client.readClient(ctx, req.Private, &resp.Diagnostics).GetConsumer(state.Username.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Consumer",
@@ -121,10 +209,21 @@ func (r *consumerResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	// Overwrite with refreshed state
 	newState := model.ConsumerFromApiToTerraform(ctx, consumerStateResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.CreateTime, newState.UpdateTime = r.client.objectTimestamps("consumers/" + state.Username.ValueString())
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+		// Compare with the plugins of APISIX without the defaults it sets
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins, newState.PluginsTyped = r.client.readPlugins(ctx, pluginSchemaTypeConsumer, newState.Plugins, state.Plugins, state.Plugin, state.SensitivePlugins, state.PluginsTyped)
 	}
 
+	// Remember the version of the consumer to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "consumers/"+state.Username.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the consumer by its username
+	setIdentity(ctx, resp.Identity, model.ConsumerIdentityModel{Username: newState.Username}, &resp.Diagnostics)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -144,34 +243,58 @@ func (r *consumerResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Consumer", plan.Username, updateTimeout)
+
+	// Keep the other resources writing the consumer waiting until it's updated
+	unlock := client.lockObject(ctx, "Consumer", "consumers/"+plan.Username.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the consumer wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Consumer", "consumers/"+plan.Username.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	updateConsumerRequest := model.ConsumerFromTerraformToApi(ctx, &plan)
 
-	// Update existing consumer
-	_, err := r.client.UpdateConsumer(updateConsumerRequest)
+	// Update existing consumer, the response holds the updated consumer
+	updatedConsumer, err := client.UpdateConsumer(updateConsumerRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error Updating APISIX Consumer",
 			"Could not update Consumer, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
-	// Fetch updated consumer
-	updatedConsumer, err := r.client.GetConsumer(plan.Username.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Consumer",
-			"Could not read APISIX Consumer by name "+plan.Username.ValueString()+": "+err.Error(),
-		)
-		return
-	}
-
 	newState := model.ConsumerFromApiToTerraform(ctx, updatedConsumer)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("consumers/" + plan.Username.ValueString())
+	newState.PluginsTyped = plan.PluginsTyped
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins, plan.PluginsTyped)
 	}
 
+	// Remember the version of the consumer to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "consumers/"+plan.Username.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the consumer by its username
+	setIdentity(ctx, resp.Identity, model.ConsumerIdentityModel{Username: newState.Username}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -192,8 +315,31 @@ func (r *consumerResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Consumer", state.Username, deleteTimeout)
+
+	// Keep the other resources writing the consumer waiting until it's deleted
+	unlock := client.lockObject(ctx, "Consumer", "consumers/"+state.Username.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the consumer wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Consumer", "consumers/"+state.Username.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the consumer
-	err := r.client.DeleteConsumer(state.Username.ValueString())
+	err := client.DeleteConsumer(state.Username.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Consumer",
@@ -206,6 +352,8 @@ func (r *consumerResource) Delete(ctx context.Context, req resource.DeleteReques
 // Import resource into state
 func (r *consumerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the consumer importing")
-	// Retrieve import user name and save to 'username' attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("username"), req, resp)
+	// Retrieve import user name or identity and save to 'username' attribute
+	username := importIdentifier(ctx, req, path.Root("username"), &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("username"), username)...)
+	setIdentity(ctx, resp.Identity, model.ConsumerIdentityModel{Username: types.StringValue(username)}, &resp.Diagnostics)
 }
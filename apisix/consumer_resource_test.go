@@ -0,0 +1,104 @@
+package apisix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestConsumerResourceUnsetOptionalAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with all the optional attributes set
+			{
+				Config: providerConfig + `
+resource "apisix_consumer" "test" {
+	username = "unset"
+	desc     = "Example of the consumer resource usage"
+	labels = {
+		version = "v1"
+		team    = "payments"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_consumer.test", "labels.%", "2"),
+				),
+			},
+			// Remove a single label and the description
+			{
+				Config: providerConfig + `
+resource "apisix_consumer" "test" {
+	username = "unset"
+	labels = {
+		version = "v1"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_consumer.test", "labels.%", "1"),
+					resource.TestCheckNoResourceAttr("apisix_consumer.test", "labels.team"),
+					resource.TestCheckNoResourceAttr("apisix_consumer.test", "desc"),
+				),
+			},
+			// Verify the attributes are removed in APISIX too
+			{
+				ResourceName:                         "apisix_consumer.test",
+				ImportState:                          true,
+				ImportStateId:                        "unset",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "username",
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestConsumerResourceSensitivePlugins(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with the credentials in the sensitive plugins
+			{
+				Config: providerConfig + `
+resource "apisix_consumer" "test" {
+	username = "sensitive"
+	plugins = jsonencode({
+		basic-auth = {
+			username = "sensitive"
+		}
+	})
+	sensitive_plugins = jsonencode({
+		basic-auth = {
+			password = "changeme"
+		}
+	})
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_consumer.test", "plugins", `{"basic-auth":{"username":"sensitive"}}`),
+					resource.TestCheckResourceAttr("apisix_consumer.test", "sensitive_plugins", `{"basic-auth":{"password":"changeme"}}`),
+				),
+			},
+			// Only the sensitive plugins
+			{
+				Config: providerConfig + `
+resource "apisix_consumer" "test" {
+	username = "sensitive"
+	sensitive_plugins = jsonencode({
+		key-auth = {
+			key = "auth-sensitive"
+		}
+	})
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("apisix_consumer.test", "plugins"),
+					resource.TestCheckResourceAttr("apisix_consumer.test", "sensitive_plugins", `{"key-auth":{"key":"auth-sensitive"}}`),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,197 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// deleteRetryInitialBackoff and deleteRetryMaxBackoff bound the wait between
+// two delete attempts while the object is still referenced.
+var (
+	deleteRetryInitialBackoff = time.Second
+	deleteRetryMaxBackoff     = 15 * time.Second
+)
+
+// referencingCollections lists the collections holding the objects which may
+// reference an object of a collection, either with an attribute or in the plugins.
+var referencingCollections = map[string][]string{
+	"upstreams":       {"routes", "services", "stream_routes", "plugin_configs", "global_rules", "consumers", "consumer_groups"},
+	"services":        {"routes", "stream_routes"},
+	"plugin_configs":  {"routes"},
+	"consumer_groups": {"consumers"},
+	"ssls":            {"upstreams"},
+	"secrets":         {"routes", "services", "consumers", "consumer_groups", "plugin_configs", "global_rules"},
+}
+
+// referenceAttributes maps the attributes holding the identifier of another
+// object, in their Admin API form, to the collection of the referenced objects.
+var referenceAttributes = map[string]string{
+	"upstream_id":      "upstreams",
+	"service_id":       "services",
+	"plugin_config_id": "plugin_configs",
+	"group_id":         "consumer_groups",
+}
+
+// deleteObject deletes an object with del. When force is set, the object is
+// deleted with the `force=true` parameter instead, which makes APISIX skip the
+// check of the objects still using it. Otherwise, while the object is still
+// in use, the delete is retried with an exponential backoff for the provider
+// delete_retry_timeout, as the referencing objects may be destroyed meanwhile.
+func (c *apisixClient) deleteObject(ctx context.Context, objectPath string, force types.Bool, del func() error) error {
+	if force.ValueBool() {
+		_, err := c.adminDo(ctx, http.MethodDelete, objectPath+"?force=true", nil)
+		return err
+	}
+
+	deadline := time.Now().Add(c.deleteRetryTimeout)
+	backoff := deleteRetryInitialBackoff
+	for {
+		err := del()
+		if err == nil || !isInUseError(err) || time.Now().Add(backoff).After(deadline) {
+			return err
+		}
+
+		tflog.Debug(ctx, "The object is still in use, retrying the delete", map[string]any{"path": objectPath, "backoff": backoff.String()})
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, deleteRetryMaxBackoff)
+	}
+}
+
+// addDeleteError reports an error returned by the Admin API on delete. When
+// the object is still in use, the objects referencing it are listed.
+func (c *apisixClient) addDeleteError(ctx context.Context, diags *diag.Diagnostics, objectPath string, err error, summary string, detail string) {
+	if !isInUseError(err) {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	usedBy := "other objects"
+	referencing, listErr := c.referencingObjects(ctx, objectPath)
+	if len(referencing) > 0 {
+		usedBy = strings.Join(referencing, ", ")
+	}
+
+	diags.AddError(
+		summary+": still in use",
+		fmt.Sprintf("The object at %s is still used by %s. "+
+			"Remove the references first, make the provider wait for them to disappear with delete_retry_timeout, "+
+			"or set force_delete = true to delete it anyway.\n\n%s", objectPath, usedBy, detail),
+	)
+	if listErr != nil {
+		diags.AddWarning(
+			"Could not list the objects using "+objectPath,
+			"The objects still using it may not all be reported above: "+listErr.Error(),
+		)
+	}
+}
+
+// isInUseError reports whether the Admin API refused to delete an object
+// because other objects still use it, e.g.
+// `can not delete this upstream, route [1] is still using it now`.
+func isInUseError(err error) bool {
+	return err != nil &&
+		strings.HasPrefix(err.Error(), fmt.Sprintf("status: %d", http.StatusBadRequest)) &&
+		strings.Contains(err.Error(), "is still using it")
+}
+
+// referencingObjects lists the paths of the objects referencing the object,
+// e.g. `routes/1`, with the errors of the collections that couldn't be listed.
+func (c *apisixClient) referencingObjects(ctx context.Context, objectPath string) ([]string, error) {
+	collection, _, _ := strings.Cut(objectPath, "/")
+
+	var referencing []string
+	var errs []error
+	for _, candidates := range referencingCollections[collection] {
+		items, err := listObjects(ctx, candidates, func(query string) ([]byte, error) {
+			return c.adminDo(ctx, http.MethodGet, candidates+"?"+query, nil)
+		})
+		if isNotFoundError(err) {
+			// The collection isn't enabled, e.g. the stream routes
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("listing the %s: %w", candidates, err))
+			continue
+		}
+
+		for _, rawItem := range items {
+			var item adminResponse
+			if err := json.Unmarshal(rawItem, &item); err != nil {
+				errs = append(errs, fmt.Errorf("decoding the %s: %w", candidates, err))
+				continue
+			}
+			// Keep the numeric identifiers as they are
+			var object map[string]interface{}
+			if err := decodeJSONNumbers(item.Value, &object); err != nil {
+				errs = append(errs, fmt.Errorf("decoding %s: %w", item.Key, err))
+				continue
+			}
+
+			for _, referenced := range objectReferencePaths(object) {
+				if referenced == objectPath {
+					referencing = append(referencing, candidates+"/"+objectIdentifier(object))
+					break
+				}
+			}
+		}
+	}
+
+	sort.Strings(referencing)
+
+	return referencing, errors.Join(errs...)
+}
+
+// objectReferencePaths returns the paths of the objects an object read from
+// the Admin API references, in its attributes and in its plugins.
+func objectReferencePaths(object map[string]interface{}) []string {
+	var paths []string
+	for attribute, collection := range referenceAttributes {
+		if id, ok := referenceID(object[attribute]); ok {
+			paths = append(paths, collection+"/"+id)
+		}
+	}
+
+	if tls, ok := object["tls"].(map[string]interface{}); ok {
+		if id, ok := referenceID(tls["client_cert_id"]); ok {
+			paths = append(paths, "ssls/"+id)
+		}
+	}
+
+	if plugins, ok := object["plugins"]; ok {
+		if body, err := json.Marshal(plugins); err == nil {
+			for _, reference := range pluginReferences(string(body)) {
+				paths = append(paths, reference.Collection+"/"+reference.ID)
+			}
+		}
+	}
+
+	return paths
+}
+
+// objectIdentifier returns the identifier of an object read from the Admin
+// API, the username for the consumers.
+func objectIdentifier(object map[string]interface{}) string {
+	if id, ok := referenceID(object["id"]); ok {
+		return id
+	}
+
+	id, _ := referenceID(object["username"])
+
+	return id
+}
@@ -0,0 +1,116 @@
+package apisix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testInUseError = `status: 400, body: {"error_msg":"can not delete this upstream, route [1] is still using it now"}`
+
+func TestDeleteObjectRetry(t *testing.T) {
+	deleteRetryInitialBackoff = time.Millisecond
+	defer func() { deleteRetryInitialBackoff = time.Second }()
+
+	testCases := map[string]struct {
+		timeout  time.Duration
+		failures int
+		attempts int
+		inUse    bool
+	}{
+		"no retry":             {timeout: 0, failures: 1, attempts: 1, inUse: true},
+		"references disappear": {timeout: time.Minute, failures: 2, attempts: 3},
+		"not in use":           {timeout: time.Minute, failures: 0, attempts: 1},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := &apisixClient{deleteRetryTimeout: testCase.timeout}
+
+			attempts := 0
+			err := client.deleteObject(context.Background(), "upstreams/1", types.BoolNull(), func() error {
+				attempts++
+				if attempts <= testCase.failures {
+					return errors.New(testInUseError)
+				}
+				return nil
+			})
+
+			if attempts != testCase.attempts || isInUseError(err) != testCase.inUse {
+				t.Errorf("expected %d attempts (in use: %t), got %d (%v)", testCase.attempts, testCase.inUse, attempts, err)
+			}
+		})
+	}
+}
+
+func TestDeleteObjectForce(t *testing.T) {
+	server := newFakeAdminServer(t)
+	server.setObject("upstreams/1", `{"id":"1","type":"roundrobin"}`)
+	client := server.client()
+
+	err := client.deleteObject(context.Background(), "upstreams/1", types.BoolValue(true), func() error {
+		t.Fatal("expected the forced delete to skip the api_client call")
+		return nil
+	})
+	if requests := server.requests(); err != nil || len(requests) != 1 || requests[0] != "DELETE /apisix/admin/upstreams/1?force=true" {
+		t.Errorf("expected a forced delete, got %v (%v)", requests, err)
+	}
+}
+
+func TestAddDeleteError(t *testing.T) {
+	server := newFakeAdminServer(t)
+	// 600 routes, listed in 2 pages
+	for id := 1; id <= 600; id++ {
+		value := fmt.Sprintf(`{"id":"%d","upstream_id":"2"}`, id)
+		switch id {
+		case 1, 600:
+			value = fmt.Sprintf(`{"id":"%d","upstream_id":"1"}`, id)
+		case 3:
+			value = `{"id":"3","plugins":{"traffic-split":{"rules":[{"weighted_upstreams":[{"upstream_id":1}]}]}}}`
+		}
+		server.setObject(fmt.Sprintf("routes/%d", id), value)
+	}
+	server.setObject("stream_routes/4", `{"id":4,"upstream_id":1}`)
+	client := server.client()
+
+	var diags diag.Diagnostics
+	client.addDeleteError(context.Background(), &diags, "upstreams/1", errors.New(testInUseError), "Error Deleting APISIX Upstream", "Could not delete upstream")
+
+	if len(diags) != 1 || diags.ErrorsCount() != 1 {
+		t.Fatalf("expected an error, got %v", diags)
+	}
+	detail := diags[0].Detail()
+	if !strings.Contains(detail, "still used by routes/1, routes/3, routes/600, stream_routes/4.") {
+		t.Errorf("expected the referencing objects in the detail, got %q", detail)
+	}
+	if count := server.count("GET /apisix/admin/routes"); count != 2 {
+		t.Errorf("expected the routes to be listed in 2 pages, got %d requests", count)
+	}
+}
+
+func TestAddDeleteErrorUnlisted(t *testing.T) {
+	server := newFakeAdminServer(t)
+	server.setObject("routes/1", `{"id":"1","upstream_id":"1"}`)
+	server.failedReads = map[string]int{"services": http.StatusInternalServerError}
+	client := server.client()
+
+	var diags diag.Diagnostics
+	client.addDeleteError(context.Background(), &diags, "upstreams/1", errors.New(testInUseError), "Error Deleting APISIX Upstream", "Could not delete upstream")
+
+	if diags.ErrorsCount() != 1 || diags.WarningsCount() != 1 {
+		t.Fatalf("expected an error and a warning, got %v", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "still used by routes/1.") {
+		t.Errorf("expected the listed referencing objects in the detail, got %q", detail)
+	}
+	if detail := diags[1].Detail(); !strings.Contains(detail, "listing the services") {
+		t.Errorf("expected the services listing error in the warning, got %q", detail)
+	}
+}
@@ -0,0 +1,282 @@
+package apisix
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+// fakeAdminServer is a minimal APISIX Admin API storing the objects written to
+// it as is, to drive the resources and the client through their real methods.
+// The collections are listed page by page, like APISIX does.
+type fakeAdminServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]json.RawMessage
+	// modifiedIndexes holds the etcd modified index of the objects, bumped on every write
+	modifiedIndexes map[string]int
+	modifiedIndex   int
+	// requestLog holds the requests received, like `GET /apisix/admin/routes?page=1&page_size=500`
+	requestLog []string
+
+	// readDelay slows down the reads, so that the unserialised writes interleave
+	readDelay time.Duration
+	// failedReads fails the reads of the objects or collections with the given status, like APISIX losing etcd
+	failedReads map[string]int
+	// defaultObject is read in place of the objects not written yet, instead of a not found error
+	defaultObject json.RawMessage
+	// onWrite edits the objects before they are stored, like APISIX setting the defaults
+	onWrite func(objectPath string, object map[string]interface{})
+	// withVersions adds the modified index of the objects to the responses
+	withVersions bool
+	// apiVersion2 answers in the APISIX 2.x format, wrapped in a `node` envelope and without pagination
+	apiVersion2 bool
+}
+
+func newFakeAdminServer(t *testing.T) *fakeAdminServer {
+	t.Helper()
+
+	fake := &fakeAdminServer{objects: map[string]json.RawMessage{}, modifiedIndexes: map[string]int{}}
+	fake.Server = httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(fake.Close)
+
+	return fake
+}
+
+func (s *fakeAdminServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	objectPath := adminObjectPath(r.URL.Path)
+
+	s.mu.Lock()
+	s.requestLog = append(s.requestLog, r.Method+" "+r.URL.RequestURI())
+	status := s.failedReads[objectPath]
+	s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(s.readDelay):
+		}
+		if status != 0 {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error_msg":"failed to fetch data from etcd"}`))
+			return
+		}
+		if !strings.Contains(objectPath, "/") {
+			s.writeList(w, r, objectPath)
+			return
+		}
+		object, ok := s.object(objectPath)
+		if !ok && s.defaultObject == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Key not found"}`))
+			return
+		}
+		if !ok {
+			object = s.defaultObject
+		}
+		s.writeObject(w, "get", objectPath, object)
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		if objectPath == "consumers" {
+			// The consumers are created without their username in the URL
+			var consumer struct {
+				Username string `json:"username"`
+			}
+			_ = json.Unmarshal(body, &consumer)
+			objectPath += "/" + consumer.Username
+		}
+		if s.onWrite != nil {
+			var object map[string]interface{}
+			_ = decodeJSONNumbers(body, &object)
+			s.onWrite(objectPath, object)
+			body, _ = json.Marshal(object)
+		}
+		s.setObject(objectPath, string(body))
+		s.writeObject(w, "set", objectPath, body)
+	case http.MethodPatch:
+		body, _ := io.ReadAll(r.Body)
+		stored, ok := s.object(objectPath)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Key not found"}`))
+			return
+		}
+		var object, patch map[string]interface{}
+		_ = decodeJSONNumbers(stored, &object)
+		_ = decodeJSONNumbers(body, &patch)
+		patched, _ := json.Marshal(applyMergePatch(object, patch))
+		s.setObject(objectPath, string(patched))
+		s.writeObject(w, "compareAndSwap", objectPath, patched)
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.objects, objectPath)
+		delete(s.modifiedIndexes, objectPath)
+		s.mu.Unlock()
+		_, _ = w.Write([]byte(`{"deleted":"1","key":"/apisix/` + objectPath + `"}`))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// applyMergePatch applies a JSON merge patch to an object, like APISIX does on PATCH.
+func applyMergePatch(object map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	if object == nil {
+		object = map[string]interface{}{}
+	}
+	for name, value := range patch {
+		switch value := value.(type) {
+		case nil:
+			delete(object, name)
+		case map[string]interface{}:
+			prior, _ := object[name].(map[string]interface{})
+			object[name] = applyMergePatch(prior, value)
+		default:
+			object[name] = value
+		}
+	}
+
+	return object
+}
+
+// fakeListItem is an object of an Admin API list response.
+type fakeListItem struct {
+	Key           string          `json:"key"`
+	Value         json.RawMessage `json:"value"`
+	ModifiedIndex int             `json:"modifiedIndex,omitempty"`
+}
+
+// writeObject answers with an object, like `{"key": ..., "value": ...}`.
+func (s *fakeAdminServer) writeObject(w http.ResponseWriter, action string, objectPath string, object json.RawMessage) {
+	item := s.listItem(objectPath, object)
+	if s.apiVersion2 {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"action": action, "node": item})
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(item)
+}
+
+// writeList answers with the objects of a collection in the order they were
+// created, one page at a time when the request is paginated. APISIX encodes
+// an empty list as an empty object.
+func (s *fakeAdminServer) writeList(w http.ResponseWriter, r *http.Request, collection string) {
+	s.mu.Lock()
+	var objectPaths []string
+	for objectPath := range s.objects {
+		if strings.HasPrefix(objectPath, collection+"/") {
+			objectPaths = append(objectPaths, objectPath)
+		}
+	}
+	sort.Slice(objectPaths, func(i, j int) bool {
+		return s.modifiedIndexes[objectPaths[i]] < s.modifiedIndexes[objectPaths[j]]
+	})
+	s.mu.Unlock()
+
+	total := len(objectPaths)
+	var page, pageSize int
+	_, _ = fmt.Sscan(r.URL.Query().Get("page"), &page)
+	_, _ = fmt.Sscan(r.URL.Query().Get("page_size"), &pageSize)
+	if page > 0 && pageSize > 0 && !s.apiVersion2 {
+		objectPaths = objectPaths[min((page-1)*pageSize, total):min(page*pageSize, total)]
+	}
+
+	items := []fakeListItem{}
+	for _, objectPath := range objectPaths {
+		object, _ := s.object(objectPath)
+		items = append(items, s.listItem(objectPath, object))
+	}
+
+	if s.apiVersion2 {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"action": "get",
+			"count":  len(items),
+			"node":   map[string]interface{}{"key": "/apisix/" + collection, "dir": true, "nodes": items},
+		})
+		return
+	}
+	if len(items) == 0 {
+		_, _ = fmt.Fprintf(w, `{"total":%d,"list":{}}`, total)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"total": total, "list": items})
+}
+
+func (s *fakeAdminServer) listItem(objectPath string, object json.RawMessage) fakeListItem {
+	item := fakeListItem{Key: "/apisix/" + objectPath, Value: object}
+	if s.withVersions {
+		s.mu.Lock()
+		item.ModifiedIndex = s.modifiedIndexes[objectPath]
+		s.mu.Unlock()
+	}
+
+	return item
+}
+
+func (s *fakeAdminServer) object(objectPath string) (json.RawMessage, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[objectPath]
+	return object, ok
+}
+
+func (s *fakeAdminServer) setObject(objectPath string, object string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.modifiedIndex++
+	s.objects[objectPath] = json.RawMessage(object)
+	s.modifiedIndexes[objectPath] = s.modifiedIndex
+}
+
+// count returns the number of requests received with the method and path,
+// whatever their query, e.g. `GET /apisix/admin/routes`.
+func (s *fakeAdminServer) count(request string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, received := range s.requestLog {
+		if received, _, _ := strings.Cut(received, "?"); received == request {
+			count++
+		}
+	}
+
+	return count
+}
+
+// requests returns the requests received, with their query.
+func (s *fakeAdminServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requestLog...)
+}
+
+// client returns a provider client of the fake server, configured like the
+// provider does.
+func (s *fakeAdminServer) client() *apisixClient {
+	versions := newObjectVersions()
+	httpClient := &http.Client{Transport: objectVersionTransport{Nested: s.Server.Client().Transport, versions: versions}}
+
+	return &apisixClient{
+		ApiClient:      &api_client.ApiClient{Endpoint: s.URL, HTTPClient: httpClient},
+		apiVersion:     apiVersionV3,
+		updateStrategy: updateStrategyPut,
+		versions:       versions,
+		planned:        newPlannedObjects(),
+		locks:          newObjectLocks(),
+	}
+}
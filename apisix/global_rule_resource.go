@@ -4,21 +4,25 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/holubovskyi/apisix-client-go"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &globalRuleResource{}
-	_ resource.ResourceWithConfigure   = &globalRuleResource{}
-	_ resource.ResourceWithImportState = &globalRuleResource{}
+	_ resource.Resource                   = &globalRuleResource{}
+	_ resource.ResourceWithConfigure      = &globalRuleResource{}
+	_ resource.ResourceWithImportState    = &globalRuleResource{}
+	_ resource.ResourceWithIdentity       = &globalRuleResource{}
+	_ resource.ResourceWithUpgradeState   = &globalRuleResource{}
+	_ resource.ResourceWithMoveState      = &globalRuleResource{}
+	_ resource.ResourceWithValidateConfig = &globalRuleResource{}
+	_ resource.ResourceWithModifyPlan     = &globalRuleResource{}
 )
 
 // NewGlobalRuleResource is a helper function to simplify the provider implementation.
@@ -28,7 +32,7 @@ func NewGlobalRuleResource() resource.Resource {
 
 // globalRuleResource is the resource implementation.
 type globalRuleResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -41,18 +45,51 @@ func (r *globalRuleResource) Schema(_ context.Context, _ resource.SchemaRequest,
 	resp.Schema = model.GlobalRuleSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *globalRuleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.ObjectIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *globalRuleResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.GlobalRuleStateUpgrades)
+}
+
+// MoveState moves the state of the global rules of the other APISIX providers.
+func (r *globalRuleResource) MoveState(_ context.Context) []resource.StateMover {
+	return moveStateFrom(model.GlobalRuleSchema, []string{"global_rule"}, moveJSONStrings("plugins"))
+}
+
+// Implement config validation
+func (r *globalRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Reject the unknown plugins and the plugin configurations not matching their JSON schema
+	r.client.checkPluginSchemas(ctx, req.Config, "", &resp.Diagnostics)
+}
+
+// Implement plan modification
+func (r *globalRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_global_rule", req.Plan, &resp.Diagnostics)
+
+	// Expect a new update time for the global rules about to be updated
+	planUpdateTime(ctx, req, resp)
+
+	// Make sure the objects referenced in the plugins exist before anything is applied
+	r.client.checkPluginReferences(ctx, resp.Plan, &resp.Diagnostics)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *globalRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -71,13 +108,48 @@ func (r *globalRuleResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Global Rule", plan.ID, createTimeout)
+
 	// Generate API request body from plan
 	newGlobalRuleRequest := model.GlobalRuleFromTerraformToApi(ctx, &plan)
 
+	// Keep the other resources writing the global rule waiting until it's created
+	unlock := client.lockObject(ctx, "Global Rule", "global_rules/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing global rule when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetGlobalRule(plan.ID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Global Rule",
+			"Could not read APISIX Global Rule by ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Global Rule", plan.ID.ValueString(), existing, newGlobalRuleRequest)
+		} else if !sameObject(existing, newGlobalRuleRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "Global Rule", plan.ID.ValueString())
+			return
+		}
+	}
+
 	// Create new global rule
-	newGlobalRuleReponse, err := r.client.CreateGlobalRule(plan.ID.ValueString(), newGlobalRuleRequest)
+	newGlobalRuleReponse, err := client.CreateGlobalRule(plan.ID.ValueString(), newGlobalRuleRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Global Rule",
 			"Could not create Global Rule, unexpected error: "+err.Error(),
 		)
@@ -86,10 +158,21 @@ func (r *globalRuleResource) Create(ctx context.Context, req resource.CreateRequ
 
 	// Map response body to schema and populate Computed attribute values
 	newState := model.GlobalRuleFromApiToTerraform(ctx, newGlobalRuleReponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("global_rules/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins)
 	}
 
+	// Remember the version of the global rule to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "global_rules/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the global rule by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -110,7 +193,7 @@ func (r *globalRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Get refreshed global rule from the APISIX
-	globalRuleStateResponse, err := r.client.GetGlobalRule(state.ID.ValueString())
+	globalRuleStateResponse, err := r.client.readClient(ctx, req.Private, &resp.Diagnostics).GetGlobalRule(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Global Rule",
@@ -121,10 +204,22 @@ func (r *globalRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	// Overwrite with refreshed state
 	newState := model.GlobalRuleFromApiToTerraform(ctx, globalRuleStateResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.CreateTime, newState.UpdateTime = r.client.objectTimestamps("global_rules/" + state.ID.ValueString())
+	newState.UpdateStrategy = state.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+		// Compare with the plugins of APISIX without the defaults it sets
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins, _ = r.client.readPlugins(ctx, "", newState.Plugins, state.Plugins, state.Plugin, state.SensitivePlugins, types.ObjectNull(nil))
 	}
 
+	// Remember the version of the global rule to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "global_rules/"+state.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the global rule by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -144,34 +239,73 @@ func (r *globalRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Global Rule", plan.ID, updateTimeout)
+
+	// Keep the other resources writing the global rule waiting until it's updated
+	unlock := client.lockObject(ctx, "Global Rule", "global_rules/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the global rule wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Global Rule", "global_rules/"+plan.ID.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	updateGlobalRuleRequest := model.GlobalRuleFromTerraformToApi(ctx, &plan)
 
-	// Update existing rule
-	_, err := r.client.UpdateGlobalRule(plan.ID.ValueString(), updateGlobalRuleRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating APISIX Global Rule",
-			"Could not update Global Rule, unexpected error: "+err.Error(),
-		)
+	// Retrieve values from state, the patch update strategy sends only the changes
+	var state model.GlobalRuleResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Fetch updated rule
-	updatedGlobalRule, err := r.client.GetGlobalRule(plan.ID.ValueString())
+	// Generate API request body from state
+	priorGlobalRuleRequest := model.GlobalRuleFromTerraformToApi(ctx, &state)
+
+	// Update existing rule, the response holds the updated rule
+	updatedGlobalRule, err := updateObject(ctx, client, plan.UpdateStrategy, "global_rules/"+plan.ID.ValueString(), priorGlobalRuleRequest, updateGlobalRuleRequest, func() (*api_client.GlobalRule, error) {
+		return client.UpdateGlobalRule(plan.ID.ValueString(), updateGlobalRuleRequest)
+	}, func() (*api_client.GlobalRule, error) {
+		return client.GetGlobalRule(plan.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Global Rule",
-			"Could not read APISIX Global Rule by ID "+plan.ID.ValueString()+": "+err.Error(),
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
+			"Error Updating APISIX Global Rule",
+			"Could not update Global Rule, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
 	newState := model.GlobalRuleFromApiToTerraform(ctx, updatedGlobalRule)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("global_rules/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins)
 	}
 
+	// Remember the version of the global rule to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "global_rules/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the global rule by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -191,8 +325,31 @@ func (r *globalRuleResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Global Rule", state.ID, deleteTimeout)
+
+	// Keep the other resources writing the global rule waiting until it's deleted
+	unlock := client.lockObject(ctx, "Global Rule", "global_rules/"+state.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the global rule wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Global Rule", "global_rules/"+state.ID.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the global rule
-	err := r.client.DeleteGlobalRule(state.ID.ValueString())
+	err := client.DeleteGlobalRule(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Global Rule",
@@ -205,6 +362,8 @@ func (r *globalRuleResource) Delete(ctx context.Context, req resource.DeleteRequ
 // Import resource into state
 func (r *globalRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the rule importing")
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Retrieve import ID or identity and save to 'id' attribute
+	id := importIdentifier(ctx, req, path.Root("id"), &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: types.StringValue(id)}, &resp.Diagnostics)
 }
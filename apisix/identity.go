@@ -0,0 +1,34 @@
+package apisix
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// importIdentifier returns the import ID, or the identity attribute at
+// identityPath when the object is imported by identity, with Terraform 1.12+.
+func importIdentifier(ctx context.Context, req resource.ImportStateRequest, identityPath path.Path, diags *diag.Diagnostics) string {
+	if req.ID != "" || req.Identity == nil {
+		return req.ID
+	}
+
+	var value types.String
+	diags.Append(req.Identity.GetAttribute(ctx, identityPath, &value)...)
+
+	return value.ValueString()
+}
+
+// setIdentity stores the identity of the object. The identity is nil when
+// the Terraform version doesn't support it.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, value any, diags *diag.Diagnostics) {
+	if identity == nil {
+		return
+	}
+
+	diags.Append(identity.Set(ctx, value)...)
+}
@@ -0,0 +1,148 @@
+package apisix
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/go-uuid"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// derivedObjectID returns an identifier derived from the resource type and the
+// seed values, so the same configuration gets the same ID across plans, applies
+// and environments. It reports false when a seed value is not known yet or when
+// all of them are null.
+func derivedObjectID(resourceType string, seeds ...attr.Value) (string, bool) {
+	hash := sha256.New()
+	hash.Write([]byte(resourceType))
+
+	hasValue := false
+	for _, seed := range seeds {
+		if seed.IsUnknown() {
+			return "", false
+		}
+
+		hash.Write([]byte{0})
+		if !seed.IsNull() {
+			hasValue = true
+			hash.Write([]byte(seed.String()))
+		}
+	}
+
+	if !hasValue {
+		return "", false
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)[:16]), true
+}
+
+// planObjectID fills in the identifier of a new object planned without one,
+// using the values at seedPaths to derive it, when the provider derives the
+// identifiers. Otherwise, or when the seeds aren't known at plan time, the
+// identifier is assigned by newObjectID on create.
+func (c *apisixClient) planObjectID(ctx context.Context, resourceType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, seedPaths ...path.Path) {
+	// Only the objects to be created need an identifier
+	if c == nil || !c.deriveIDs || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() || !id.IsUnknown() {
+		return
+	}
+
+	seeds := make([]attr.Value, 0, len(seedPaths))
+	for _, seedPath := range seedPaths {
+		var seed attr.Value
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, seedPath, &seed)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		seeds = append(seeds, seed)
+	}
+
+	if derivedID, ok := derivedObjectID(resourceType, seeds...); ok {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), derivedID)...)
+	}
+}
+
+// newObjectID returns the planned identifier of a new object or generates
+// a random one, before the object is created with a PUT request.
+func newObjectID(planned types.String) (string, error) {
+	if !planned.IsNull() && !planned.IsUnknown() {
+		return planned.ValueString(), nil
+	}
+
+	return uuid.GenerateUUID()
+}
+
+// sameObject compares an object read from APISIX with the one about to be
+// written, ignoring the identifier. It tells a repeated create of the same
+// configuration, e.g. after an interrupted apply, from an ID collision. Only
+// the fields set in the object written are compared, as APISIX sets the
+// defaults of the others on write, like the upstream `scheme` or the plugin
+// defaults.
+func sameObject(existing, planned any) bool {
+	existingFields, ok := objectFields(existing)
+	if !ok {
+		return false
+	}
+
+	plannedFields, ok := objectFields(planned)
+	if !ok {
+		return false
+	}
+
+	return holdsConfiguredValue(existingFields, plannedFields)
+}
+
+// holdsConfiguredValue reports whether a JSON value read from APISIX holds
+// the configured one, recursively, ignoring the fields not configured.
+func holdsConfiguredValue(existing interface{}, configured interface{}) bool {
+	switch configured := configured.(type) {
+	case map[string]interface{}:
+		existingObject, ok := existing.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for name, value := range configured {
+			if value != nil && !holdsConfiguredValue(existingObject[name], value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		existingArray, ok := existing.([]interface{})
+		if !ok || len(existingArray) != len(configured) {
+			return false
+		}
+		for i, item := range configured {
+			if !holdsConfiguredValue(existingArray[i], item) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(existing, configured)
+}
+
+// addObjectIDTakenError reports that a new object can't be created because
+// a different object already uses its identifier, set at attributePath.
+func addObjectIDTakenError(diags *diag.Diagnostics, attributePath path.Path, objectType string, id string) {
+	diags.AddAttributeError(
+		attributePath,
+		"Error creating "+objectType,
+		fmt.Sprintf("Could not create %s, another object with the ID %s already exists in APISIX. "+
+			"Import it with `terraform import`, take it over with `adopt_existing = true`, or set a different %s in the configuration.", objectType, id, attributePath),
+	)
+}
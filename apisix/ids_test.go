@@ -0,0 +1,87 @@
+package apisix
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDerivedObjectID(t *testing.T) {
+	id, ok := derivedObjectID("apisix_route", types.StringValue("checkout-api"))
+	if !ok {
+		t.Fatal("expected an identifier for a known seed")
+	}
+	if len(id) != 32 {
+		t.Errorf("expected a 32 characters identifier, got %q", id)
+	}
+
+	sameID, _ := derivedObjectID("apisix_route", types.StringValue("checkout-api"))
+	if id != sameID {
+		t.Errorf("expected the identifier to be stable, got %q and %q", id, sameID)
+	}
+
+	otherTypeID, _ := derivedObjectID("apisix_service", types.StringValue("checkout-api"))
+	if id == otherTypeID {
+		t.Errorf("expected different identifiers for different resource types, got %q", id)
+	}
+
+	if _, ok := derivedObjectID("apisix_route", types.StringUnknown()); ok {
+		t.Error("expected no identifier for an unknown seed")
+	}
+
+	if _, ok := derivedObjectID("apisix_stream_route", types.StringNull(), types.Int64Null()); ok {
+		t.Error("expected no identifier for null seeds")
+	}
+}
+
+func TestSameObject(t *testing.T) {
+	name := "checkout-api"
+	otherName := "payments-api"
+	id := "1"
+
+	existing := struct {
+		ID   *string `json:"id,omitempty"`
+		Name *string `json:"name,omitempty"`
+	}{ID: &id, Name: &name}
+
+	planned := existing
+	planned.ID = nil
+	if !sameObject(existing, planned) {
+		t.Error("expected the objects to be the same regardless of the identifier")
+	}
+
+	planned.Name = &otherName
+	if sameObject(existing, planned) {
+		t.Error("expected the objects to differ")
+	}
+}
+
+func TestPlanObjectID(t *testing.T) {
+	config := testConfig(t, model.RouteSchema, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name": tftypes.NewValue(tftypes.String, "checkout-api"),
+	})
+	state := tfsdk.State{Schema: model.RouteSchema, Raw: tftypes.NewValue(config.Raw.Type(), nil)}
+
+	for _, deriveIDs := range []bool{false, true} {
+		req := resource.ModifyPlanRequest{State: state, Plan: tfsdk.Plan(config)}
+		resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan(config)}
+		(&apisixClient{deriveIDs: deriveIDs}).planObjectID(context.Background(), "apisix_route", req, resp, path.Root("name"))
+
+		var id types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(context.Background(), path.Root("id"), &id)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if id.IsUnknown() == deriveIDs {
+			t.Errorf("expected a derived identifier %t, got %s", deriveIDs, id)
+		}
+	}
+}
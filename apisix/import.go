@@ -0,0 +1,119 @@
+package apisix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// importSelector matches the objects an import ID refers to.
+type importSelector struct {
+	Name   string
+	Labels map[string]string
+	SNI    string
+}
+
+// parseImportSelector parses the import IDs selecting an object by its
+// attributes: `name=<name>`, `labels:<key>=<value>,...` and, with sni set,
+// `sni=<host>`. It reports false for the other import IDs, which are the
+// identifier of the object.
+func parseImportSelector(importID string, sni bool) (importSelector, bool, error) {
+	switch {
+	case strings.HasPrefix(importID, "name="):
+		return importSelector{Name: strings.TrimPrefix(importID, "name=")}, true, nil
+	case sni && strings.HasPrefix(importID, "sni="):
+		return importSelector{SNI: strings.TrimPrefix(importID, "sni=")}, true, nil
+	case strings.HasPrefix(importID, "labels:"):
+		labels := map[string]string{}
+		for _, label := range strings.Split(strings.TrimPrefix(importID, "labels:"), ",") {
+			key, value, found := strings.Cut(label, "=")
+			if !found || key == "" {
+				return importSelector{}, true, fmt.Errorf("invalid label %q in the import ID %q, expected labels:<key>=<value>,<key>=<value>", label, importID)
+			}
+			labels[key] = value
+		}
+		return importSelector{Labels: labels}, true, nil
+	}
+
+	return importSelector{}, false, nil
+}
+
+// Matches reports whether an object read from the Admin API is selected.
+func (s importSelector) Matches(object map[string]interface{}) bool {
+	if s.Name != "" {
+		name, _ := object["name"].(string)
+		return name == s.Name
+	}
+
+	if s.SNI != "" {
+		if sni, _ := object["sni"].(string); sni == s.SNI {
+			return true
+		}
+		snis, _ := object["snis"].([]interface{})
+		return slices.Contains(snis, interface{}(s.SNI))
+	}
+
+	labels, _ := object["labels"].(map[string]interface{})
+	for key, value := range s.Labels {
+		if labels[key] != value {
+			return false
+		}
+	}
+
+	return len(s.Labels) > 0
+}
+
+// resolveImportID returns the identifier of the object an import ID refers
+// to. The selectors parsed by parseImportSelector are resolved by listing the
+// objects of the collection, and must match exactly one of them. The other
+// import IDs are returned as is.
+func (c *apisixClient) resolveImportID(ctx context.Context, objectType string, collection string, importID string, sni bool) (string, error) {
+	selector, ok, err := parseImportSelector(importID, sni)
+	if err != nil || !ok {
+		return importID, err
+	}
+
+	body, err := c.adminDo(ctx, http.MethodGet, collection, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not list the %ss to resolve the import ID %q: %w", strings.ToLower(objectType), importID, err)
+	}
+
+	var response struct {
+		List []adminResponse `json:"list"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("could not decode the %ss to resolve the import ID %q: %w", strings.ToLower(objectType), importID, err)
+	}
+
+	var matches []string
+	for _, item := range response.List {
+		// Keep the numeric identifiers as they are
+		decoder := json.NewDecoder(bytes.NewReader(item.Value))
+		decoder.UseNumber()
+
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			continue
+		}
+
+		if selector.Matches(object) {
+			matches = append(matches, objectIdentifier(object))
+		}
+	}
+	sort.Strings(matches)
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s matches the import ID %q. Check the selector, or import the %s by its ID", strings.ToLower(objectType), importID, strings.ToLower(objectType))
+	case 1:
+		return matches[0], nil
+	}
+
+	return "", fmt.Errorf("%d %ss match the import ID %q, with the IDs %s. Narrow the selector, or import the %s by its ID",
+		len(matches), strings.ToLower(objectType), importID, strings.Join(matches, ", "), strings.ToLower(objectType))
+}
@@ -0,0 +1,50 @@
+package apisix
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestResolveImportID(t *testing.T) {
+	server := newFakeAdminServer(t)
+	server.setObject("routes/1", `{"id":"1","name":"checkout-api","labels":{"team":"payments","app":"checkout"}}`)
+	server.setObject("routes/2", `{"id":"2","name":"refund-api","labels":{"team":"payments","app":"refund"}}`)
+	server.setObject("routes/3", `{"id":3,"name":"search-api"}`)
+	server.setObject("ssls/1", `{"id":"1","snis":["api.example.com","www.example.com"]}`)
+	client := server.client()
+
+	testCases := map[string]struct {
+		collection string
+		importID   string
+		sni        bool
+		expected   string
+		err        string
+	}{
+		"id":              {collection: "routes", importID: "42", expected: "42"},
+		"name":            {collection: "routes", importID: "name=checkout-api", expected: "1"},
+		"numeric id":      {collection: "routes", importID: "name=search-api", expected: "3"},
+		"labels":          {collection: "routes", importID: "labels:team=payments,app=refund", expected: "2"},
+		"no match":        {collection: "routes", importID: "name=unknown", err: "no route matches"},
+		"several matches": {collection: "routes", importID: "labels:team=payments", err: "2 routes match the import ID \"labels:team=payments\", with the IDs 1, 2"},
+		"invalid labels":  {collection: "routes", importID: "labels:team", err: "invalid label"},
+		"sni":             {collection: "ssls", importID: "sni=www.example.com", sni: true, expected: "1"},
+		"sni unsupported": {collection: "routes", importID: "sni=www.example.com", expected: "sni=www.example.com"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := client.resolveImportID(context.Background(), "Route", testCase.collection, testCase.importID, testCase.sni)
+
+			if testCase.err != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.err) {
+					t.Errorf("expected an error containing %q, got %v", testCase.err, err)
+				}
+				return
+			}
+			if err != nil || got != testCase.expected {
+				t.Errorf("expected %q, got %q (%v)", testCase.expected, got, err)
+			}
+		})
+	}
+}
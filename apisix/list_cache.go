@@ -0,0 +1,278 @@
+package apisix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// listCachePageSize is the number of objects listed per Admin API request,
+// the largest page size APISIX accepts.
+const listCachePageSize = 500
+
+// listCacheCollections are the collections whose objects are read from the
+// list snapshots. The secrets, nested under their secret manager, and the
+// plugin metadata are always read one by one.
+var listCacheCollections = map[string]bool{
+	"consumer_groups": true,
+	"consumers":       true,
+	"global_rules":    true,
+	"plugin_configs":  true,
+	"routes":          true,
+	"services":        true,
+	"ssls":            true,
+	"stream_routes":   true,
+	"upstreams":       true,
+}
+
+// listItem is an object of an Admin API list response. It has the format of a
+// single object response, and is returned as is for the reads it serves.
+type listItem struct {
+	Key string `json:"key"`
+}
+
+// listSnapshot holds the objects of a collection, keyed by their ID, as listed
+// at fetched. A zero fetched time means the snapshot must be listed again.
+type listSnapshot struct {
+	mu      sync.Mutex
+	fetched time.Time
+	objects map[string][]byte
+}
+
+// listCache serves the reads of single objects from a snapshot of their
+// collection, listed once for all the objects, so that refreshing many
+// resources doesn't send an Admin API request per resource. The snapshots
+// expire after ttl, and the writes to a collection invalidate its snapshot.
+type listCache struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	snapshots map[string]*listSnapshot
+}
+
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{ttl: ttl, snapshots: map[string]*listSnapshot{}}
+}
+
+func (c *listCache) snapshot(collection string) *listSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot, ok := c.snapshots[collection]
+	if !ok {
+		snapshot = &listSnapshot{}
+		c.snapshots[collection] = snapshot
+	}
+
+	return snapshot
+}
+
+// invalidate drops the snapshot of a collection, so the next read lists it again.
+func (c *listCache) invalidate(collection string) {
+	snapshot := c.snapshot(collection)
+
+	snapshot.mu.Lock()
+	defer snapshot.mu.Unlock()
+
+	snapshot.fetched = time.Time{}
+	snapshot.objects = nil
+}
+
+// object returns the object with the given ID from the snapshot of its
+// collection, listing the collection with list when the snapshot expired.
+// The concurrent reads of a collection wait for a single listing.
+func (c *listCache) object(collection string, id string, list func() (map[string][]byte, error)) ([]byte, bool, error) {
+	snapshot := c.snapshot(collection)
+
+	snapshot.mu.Lock()
+	defer snapshot.mu.Unlock()
+
+	if snapshot.fetched.IsZero() || time.Since(snapshot.fetched) > c.ttl {
+		objects, err := list()
+		if err != nil {
+			return nil, false, err
+		}
+		snapshot.objects = objects
+		snapshot.fetched = time.Now()
+	}
+
+	object, ok := snapshot.objects[id]
+
+	return object, ok, nil
+}
+
+// freshReadKey marks the contexts of the reads which must not be served from
+// the list snapshots, e.g. to detect the concurrent changes.
+type freshReadKey struct{}
+
+// withFreshRead marks the Admin API requests sent with the context to bypass the list snapshots.
+func withFreshRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshReadKey{}, true)
+}
+
+// withFreshReads returns a copy of the client sending all its requests with
+// the context, marked to bypass the list snapshots, e.g. to check whether an
+// object exists right before creating it.
+func (c *apisixClient) withFreshReads(ctx context.Context) *apisixClient {
+	transport := c.HTTPClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	apiClient := *c.ApiClient
+	apiClient.HTTPClient = &http.Client{Transport: contextTransport{Nested: transport, ctx: withFreshRead(ctx)}}
+
+	client := *c
+	client.ApiClient = &apiClient
+
+	return &client
+}
+
+// isFreshRead reports whether the context requires reading the object from APISIX.
+func isFreshRead(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshReadKey{}).(bool)
+
+	return fresh
+}
+
+// listCacheTransport serves the reads of single objects from the list cache,
+// and invalidates the snapshot of the collection of every object written.
+// The requests sent with `Cache-Control: no-cache` or with a context marked by
+// withFreshRead are always sent to APISIX.
+type listCacheTransport struct {
+	Nested http.RoundTripper
+	cache  *listCache
+}
+
+func (t listCacheTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	objectPath := adminObjectPath(r.URL.Path)
+	collection, id, _ := strings.Cut(objectPath, "/")
+
+	if r.Method != http.MethodGet {
+		res, err := t.Nested.RoundTrip(r)
+		t.cache.invalidate(collection)
+		return res, err
+	}
+
+	if !listCacheCollections[collection] || id == "" || strings.Contains(id, "/") || r.URL.RawQuery != "" ||
+		r.Header.Get("Cache-Control") == "no-cache" || isFreshRead(r.Context()) {
+		return t.Nested.RoundTrip(r)
+	}
+
+	object, ok, err := t.cache.object(collection, id, func() (map[string][]byte, error) {
+		return t.list(r, collection)
+	})
+	if err != nil {
+		// Let the read report the error, or succeed when only the listing failed
+		tflog.Debug(r.Context(), "Could not list the objects", map[string]any{"collection": collection, "error": err.Error()})
+		return t.Nested.RoundTrip(r)
+	}
+
+	if !ok {
+		return listCacheResponse(r, http.StatusNotFound, []byte(`{"message":"Key not found"}`)), nil
+	}
+
+	return listCacheResponse(r, http.StatusOK, object), nil
+}
+
+// list lists all the objects of a collection, page by page, keyed by their ID.
+func (t listCacheTransport) list(r *http.Request, collection string) (map[string][]byte, error) {
+	return listObjects(r.Context(), collection, func(query string) ([]byte, error) {
+		listURL := *r.URL
+		listURL.Path = strings.TrimSuffix(r.URL.Path, "/")
+		listURL.Path = listURL.Path[:strings.LastIndex(listURL.Path, "/")]
+		listURL.RawPath = ""
+		listURL.RawQuery = query
+
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, listURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header = r.Header.Clone()
+
+		res, err := t.Nested.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode >= http.StatusBadRequest {
+			return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		}
+
+		return body, nil
+	})
+}
+
+// listObjects lists all the objects of a collection, page by page, keyed by
+// their ID. getPage returns the Admin API response to the list request with
+// the query of the page.
+func listObjects(ctx context.Context, collection string, getPage func(query string) ([]byte, error)) (map[string][]byte, error) {
+	objects := map[string][]byte{}
+
+	for page := 1; ; page++ {
+		tflog.Debug(ctx, "Listing the APISIX objects", map[string]any{"collection": collection, "page": page})
+
+		body, err := getPage(fmt.Sprintf("page=%d&page_size=%d", page, listCachePageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		var response struct {
+			Total json.Number     `json:"total"`
+			List  json.RawMessage `json:"list"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+
+		// APISIX encodes an empty list as an empty object
+		var items []json.RawMessage
+		if string(response.List) != "{}" {
+			if err := json.Unmarshal(response.List, &items); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, rawItem := range items {
+			var item listItem
+			if err := json.Unmarshal(rawItem, &item); err != nil {
+				return nil, err
+			}
+			objects[item.Key[strings.LastIndex(item.Key, "/")+1:]] = rawItem
+		}
+
+		// APISIX 2.x doesn't paginate, and returns all the objects at once
+		total, _ := strconv.Atoi(response.Total.String())
+		if len(items) < listCachePageSize || len(objects) >= total {
+			return objects, nil
+		}
+	}
+}
+
+// listCacheResponse builds the Admin API response of a read served from the list cache.
+func listCacheResponse(r *http.Request, statusCode int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}
+}
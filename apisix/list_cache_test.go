@@ -0,0 +1,197 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+// newListCacheClient returns a client of a fake server holding the routes,
+// reading them through the list cache.
+func newListCacheClient(t *testing.T, routes int, ttl time.Duration) (*apisixClient, *fakeAdminServer) {
+	t.Helper()
+
+	fake := newFakeAdminServer(t)
+	fake.withVersions = true
+	for id := 1; id <= routes; id++ {
+		fake.setObject(fmt.Sprintf("routes/%d", id), fmt.Sprintf(`{"id":"%d","uri":"/%d","update_time":1700000000}`, id, id))
+	}
+
+	client := fake.client()
+	client.HTTPClient = &http.Client{Transport: objectVersionTransport{
+		Nested:   listCacheTransport{Nested: fake.Server.Client().Transport, cache: newListCache(ttl)},
+		versions: client.versions,
+	}}
+
+	return client, fake
+}
+
+func TestListCacheReads(t *testing.T) {
+	client, fake := newListCacheClient(t, 1200, time.Minute)
+
+	// Refresh many routes in parallel, like Terraform does
+	var wg sync.WaitGroup
+	for id := 1; id <= 1200; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+
+			route, err := client.GetRoute(fmt.Sprint(id))
+			if err != nil {
+				t.Errorf("unexpected error reading the route %d: %s", id, err)
+				return
+			}
+			if *route.URI != fmt.Sprintf("/%d", id) {
+				t.Errorf("expected the listed route %d, got %s", id, *route.URI)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if count := fake.count("GET /apisix/admin/routes"); count != 3 {
+		t.Errorf("expected the routes to be listed once in 3 pages, got %d requests", count)
+	}
+	if count := fake.count("GET /apisix/admin/routes/1"); count != 0 {
+		t.Errorf("expected the routes to be read from the list, got %d requests", count)
+	}
+
+	// The versions of the listed objects are recorded as for the single reads
+	if version, ok := client.versions.get("routes/42"); !ok || version.ModifiedIndex != 42 || version.UpdateTime != 1700000000 {
+		t.Errorf("expected the version of the listed route, got %+v", version)
+	}
+
+	// The objects missing from the list aren't found
+	if _, err := client.GetRoute("1201"); !isNotFoundError(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestListCacheInvalidation(t *testing.T) {
+	client, fake := newListCacheClient(t, 0, time.Minute)
+
+	// The empty collections are listed as an empty object
+	if _, err := client.GetRoute("1"); !isNotFoundError(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	uri := "/1"
+	if _, err := client.UpdateRoute("1", api_client.Route{URI: &uri}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The write invalidates the routes, listed again on the next read
+	route, err := client.GetRoute("1")
+	if err != nil {
+		t.Fatalf("expected the new route to be listed, got %s", err)
+	}
+	if *route.URI != "/1" {
+		t.Errorf("unexpected route %s", *route.URI)
+	}
+	if count := fake.count("GET /apisix/admin/routes"); count != 2 {
+		t.Errorf("expected the routes to be listed again after the write, got %d requests", count)
+	}
+}
+
+func TestListCacheTTL(t *testing.T) {
+	client, fake := newListCacheClient(t, 1, 10*time.Millisecond)
+
+	for range 2 {
+		if _, err := client.GetRoute("1"); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if count := fake.count("GET /apisix/admin/routes"); count != 2 {
+		t.Errorf("expected the expired list to be listed again, got %d requests", count)
+	}
+}
+
+func TestListCacheFreshRead(t *testing.T) {
+	client, fake := newListCacheClient(t, 1, time.Minute)
+
+	if _, err := client.GetRoute("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Detecting the concurrent changes bypasses the list
+	body, err := client.adminDo(withFreshRead(context.Background()), http.MethodGet, "routes/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"uri":"/1"`) || fake.count("GET /apisix/admin/routes/1") != 1 {
+		t.Errorf("expected the route to be read from APISIX, got %s", body)
+	}
+}
+
+// testPrivateState is the private state of a resource, keyed like the framework one.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestListCacheFreshReads(t *testing.T) {
+	client, fake := newListCacheClient(t, 1, time.Minute)
+	ctx := context.Background()
+
+	// The routes just imported have no recorded version, and are read from APISIX
+	var diags diag.Diagnostics
+	route, err := client.readClient(ctx, testPrivateState{}, &diags).GetRoute("1")
+	if err != nil || diags.HasError() {
+		t.Fatal(err, diags)
+	}
+	if *route.URI != "/1" || fake.count("GET /apisix/admin/routes/1") != 1 || fake.count("GET /apisix/admin/routes") != 0 {
+		t.Errorf("expected the imported route to be read from APISIX, got %v", fake.requests())
+	}
+
+	// The refreshes are served from the list
+	route, err = client.readClient(ctx, testPrivateState{objectVersionPrivateStateKey: []byte(`{"modified_index":101}`)}, &diags).GetRoute("1")
+	if err != nil || diags.HasError() {
+		t.Fatal(err, diags)
+	}
+	if *route.URI != "/1" || fake.count("GET /apisix/admin/routes/1") != 1 || fake.count("GET /apisix/admin/routes") != 1 {
+		t.Errorf("expected the refreshed route to be read from the list, got %v", fake.requests())
+	}
+
+	// The existence checks before the creates are read from APISIX
+	route, err = client.withFreshReads(ctx).GetRoute("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *route.URI != "/1" || fake.count("GET /apisix/admin/routes/1") != 2 {
+		t.Errorf("expected the existing route to be read from APISIX, got %v", fake.requests())
+	}
+}
+
+func TestObjectVersionFromResponseKey(t *testing.T) {
+	client, fake := newListCacheClient(t, 0, time.Minute)
+	fake.onWrite = func(_ string, object map[string]interface{}) {
+		object["create_time"] = 1700000000
+		object["update_time"] = 1700000001
+	}
+
+	// The consumers are written without their username in the URL
+	username := "jack"
+	if _, err := client.UpdateConsumer(api_client.Consumer{Username: &username}); err != nil {
+		t.Fatal(err)
+	}
+
+	createTime, updateTime := client.objectTimestamps("consumers/jack")
+	if createTime != types.Int64Value(1700000000) || updateTime != types.Int64Value(1700000001) {
+		t.Errorf("expected the timestamps of the consumer, got %s and %s", createTime, updateTime)
+	}
+}
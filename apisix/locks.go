@@ -0,0 +1,90 @@
+package apisix
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// objectLocks serialises the writes to the same APISIX object, keyed by the
+// object path relative to `/apisix/admin`, e.g. `plugin_metadata/syslog`.
+// Terraform applies the resources in parallel, and two resources writing the
+// same object would otherwise overwrite the changes of each other.
+type objectLocks struct {
+	mu    sync.Mutex
+	locks map[string]*objectLock
+}
+
+// objectLock is held by sending to held. The lock is dropped from the registry
+// once no resource holds it nor waits for it.
+type objectLock struct {
+	held    chan struct{}
+	waiters int
+}
+
+func newObjectLocks() *objectLocks {
+	return &objectLocks{locks: map[string]*objectLock{}}
+}
+
+// lock waits until the object is unlocked or the context is done, and returns
+// the function unlocking it.
+func (l *objectLocks) lock(ctx context.Context, objectPath string) (func(), error) {
+	l.mu.Lock()
+	lock, ok := l.locks[objectPath]
+	if !ok {
+		lock = &objectLock{held: make(chan struct{}, 1)}
+		l.locks[objectPath] = lock
+	}
+	lock.waiters++
+	l.mu.Unlock()
+
+	select {
+	case lock.held <- struct{}{}:
+	case <-ctx.Done():
+		l.release(objectPath, lock)
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-lock.held
+			l.release(objectPath, lock)
+		})
+	}, nil
+}
+
+func (l *objectLocks) release(objectPath string, lock *objectLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock.waiters--
+	if lock.waiters == 0 {
+		delete(l.locks, objectPath)
+	}
+}
+
+// lockObject locks the object for the read-modify-write of the caller, waiting
+// for the other resources writing it to finish first. It returns the function
+// unlocking the object, which does nothing when the object couldn't be locked
+// before the timeout of the operation, reported in diags.
+func (c *apisixClient) lockObject(ctx context.Context, objectType string, objectPath string, diags *diag.Diagnostics) func() {
+	if c.locks == nil {
+		return func() {}
+	}
+
+	unlock, err := c.locks.lock(ctx, objectPath)
+	if err != nil {
+		diags.AddError(
+			"APISIX "+objectType+" Locked",
+			fmt.Sprintf("The %s at %s is being written by another resource, and could not be locked: %s",
+				strings.ToLower(objectType), objectPath, err),
+		)
+		return func() {}
+	}
+
+	return unlock
+}
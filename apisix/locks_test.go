@@ -0,0 +1,173 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// incrementCounter increments the counter of the object with a read-modify-write,
+// locking the object like the resources do.
+func incrementCounter(ctx context.Context, client *apisixClient, objectPath string) error {
+	var diags diag.Diagnostics
+	unlock := client.lockObject(ctx, "Plugin Metadata", objectPath, &diags)
+	defer unlock()
+	if diags.HasError() {
+		return context.DeadlineExceeded
+	}
+
+	var object struct {
+		Count int `json:"count"`
+	}
+	if err := client.adminRequest(ctx, http.MethodGet, objectPath, nil, &object); err != nil {
+		return err
+	}
+
+	object.Count++
+
+	return client.adminRequest(ctx, http.MethodPut, objectPath, object, nil)
+}
+
+func TestLockObjectParallelWrites(t *testing.T) {
+	// The reads are slow, so that the unserialised read-modify-writes lose updates
+	server := newFakeAdminServer(t)
+	server.defaultObject = json.RawMessage(`{"count":0}`)
+	server.readDelay = 5 * time.Millisecond
+	client := server.client()
+
+	// Like terraform apply -parallelism=10, with two objects written by many resources
+	objectPaths := []string{"plugin_metadata/syslog", "routes/1", "upstreams/1", "consumers/jack", "global_rules/1"}
+	writes := 10
+
+	var wg sync.WaitGroup
+	for _, objectPath := range objectPaths {
+		for range writes {
+			wg.Add(1)
+			go func(objectPath string) {
+				defer wg.Done()
+
+				// The copies of the client bound to an operation timeout share the locks
+				ctx, timeoutClient, cancel := client.withTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+
+				if err := incrementCounter(ctx, timeoutClient, objectPath); err != nil {
+					t.Errorf("unexpected error writing %s: %s", objectPath, err)
+				}
+			}(objectPath)
+		}
+	}
+	wg.Wait()
+
+	for _, objectPath := range objectPaths {
+		var object struct {
+			Count int `json:"count"`
+		}
+		if err := client.adminRequest(context.Background(), http.MethodGet, objectPath, nil, &object); err != nil {
+			t.Fatal(err)
+		}
+		if object.Count != writes {
+			t.Errorf("expected the %d writes to %s to be kept, got %d", writes, objectPath, object.Count)
+		}
+	}
+
+	if len(client.locks.locks) != 0 {
+		t.Errorf("expected the released locks to be dropped, got %d", len(client.locks.locks))
+	}
+}
+
+func TestLockObjectPluginMetadataWriters(t *testing.T) {
+	server := newFakeAdminServer(t)
+	server.readDelay = 10 * time.Millisecond
+	client := server.client()
+
+	// Like terraform apply -parallelism=10, with many resources writing the metadata of the same plugin
+	writers := 10
+	results := make([]*resource.CreateResponse, writers)
+
+	var wg sync.WaitGroup
+	for writer := range writers {
+		wg.Add(1)
+		go func(writer int) {
+			defer wg.Done()
+
+			pluginMetadata := &pluginMetadataResource{client: client}
+			req := resource.CreateRequest{Plan: testPlan(t, model.PluginMetadataSchema, map[string]tftypes.Value{
+				"id":       tftypes.NewValue(tftypes.String, "syslog"),
+				"metadata": tftypes.NewValue(tftypes.String, fmt.Sprintf(`{"log_format":{"writer":"%d"}}`, writer)),
+			})}
+			resp := &resource.CreateResponse{State: emptyState(model.PluginMetadataSchema)}
+			pluginMetadata.Create(context.Background(), req, resp)
+			results[writer] = resp
+		}(writer)
+	}
+	wg.Wait()
+
+	// The first writer creates the metadata, the others see it instead of silently overwriting it
+	created := -1
+	for writer, resp := range results {
+		if !resp.Diagnostics.HasError() {
+			if created >= 0 {
+				t.Errorf("expected a single writer to create the plugin metadata, got %d and %d", created, writer)
+			}
+			created = writer
+			continue
+		}
+		if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "syslog") {
+			t.Errorf("expected the writer %d to report the existing plugin metadata, got %v", writer, resp.Diagnostics)
+		}
+	}
+	if created < 0 {
+		t.Fatal("expected a writer to create the plugin metadata")
+	}
+
+	stored, _ := server.object("plugin_metadata/syslog")
+	if expected := fmt.Sprintf(`"writer":"%d"`, created); !strings.Contains(string(stored), expected) {
+		t.Errorf("expected the plugin metadata of the writer %d, got %s", created, stored)
+	}
+	if len(client.locks.locks) != 0 {
+		t.Errorf("expected the released locks to be dropped, got %d", len(client.locks.locks))
+	}
+}
+
+func TestLockObjectTimeout(t *testing.T) {
+	client := &apisixClient{locks: newObjectLocks()}
+
+	var diags diag.Diagnostics
+	unlock := client.lockObject(context.Background(), "Plugin Metadata", "plugin_metadata/syslog", &diags)
+
+	// Another resource writing the same object gives up on its timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var waitingDiags diag.Diagnostics
+	client.lockObject(ctx, "Plugin Metadata", "plugin_metadata/syslog", &waitingDiags)()
+
+	if waitingDiags.ErrorsCount() != 1 ||
+		waitingDiags.Errors()[0].Summary() != "APISIX Plugin Metadata Locked" ||
+		!strings.Contains(waitingDiags.Errors()[0].Detail(), "The plugin metadata at plugin_metadata/syslog is being written by another resource") {
+		t.Errorf("expected the lock error, got %v", waitingDiags)
+	}
+
+	// The other objects aren't locked
+	var otherDiags diag.Diagnostics
+	client.lockObject(context.Background(), "Route", "routes/1", &otherDiags)()
+	if otherDiags.HasError() {
+		t.Errorf("unexpected error: %v", otherDiags)
+	}
+
+	unlock()
+	unlock()
+	if diags.HasError() || len(client.locks.locks) != 0 {
+		t.Errorf("expected the lock to be released once, got %v and %d locks", diags, len(client.locks.locks))
+	}
+}
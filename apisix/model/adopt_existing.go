@@ -0,0 +1,11 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+var AdoptExistingSchemaAttribute = schema.BoolAttribute{
+	MarkdownDescription: "Takes over the object on create when it already exists in APISIX, instead of failing or silently overwriting it. " +
+		"The fields that differ from the configuration are reported in a warning and overwritten. Defaults to `false`.",
+	Optional: true,
+}
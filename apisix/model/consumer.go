@@ -5,6 +5,7 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -14,14 +15,27 @@ import (
 
 // ConsumerResourceModel maps the resource schema data.
 type ConsumerResourceModel struct {
-	Username    types.String `tfsdk:"username"`
-	Description types.String `tfsdk:"desc"`
-	Labels      types.Map    `tfsdk:"labels"`
-	Plugins     types.String `tfsdk:"plugins"`
-	GroupId     types.String `tfsdk:"group_id"`
+	Username                types.String   `tfsdk:"username"`
+	Description             types.String   `tfsdk:"desc"`
+	Labels                  types.Map      `tfsdk:"labels"`
+	Plugins                 types.String   `tfsdk:"plugins"`
+	Plugin                  types.Dynamic  `tfsdk:"plugin"`
+	PluginsTyped            types.Object   `tfsdk:"plugins_typed"`
+	SensitivePlugins        types.String   `tfsdk:"sensitive_plugins"`
+	GroupId                 types.String   `tfsdk:"group_id"`
+	CreateTime              types.Int64    `tfsdk:"create_time"`
+	UpdateTime              types.Int64    `tfsdk:"update_time"`
+	AdoptExisting           types.Bool     `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool     `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
+// ConsumerStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the consumer.
+var ConsumerStateUpgrades = []StateUpgradeStep{}
+
 var ConsumerSchema = schema.Schema{
+	Version:     int64(len(ConsumerStateUpgrades)),
 	Description: "Manages APISIX Consumers.",
 	Attributes: map[string]schema.Attribute{
 		"username": schema.StringAttribute{
@@ -44,10 +58,20 @@ var ConsumerSchema = schema.Schema{
 			Description: "Plugins that are executed during the request/response cycle.",
 			Optional:    true,
 		},
+		"sensitive_plugins": SensitivePluginsSchemaAttribute,
+		"plugin":            PluginSchemaAttribute,
+		"plugins_typed":     ConsumerPluginsTypedSchemaAttribute,
 		"group_id": schema.StringAttribute{
 			Description: "Group of the Consumer.",
 			Optional:    true,
 		},
+		"create_time":               CreateTimeSchemaAttribute,
+		"update_time":               UpdateTimeSchemaAttribute,
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
@@ -58,7 +82,7 @@ func ConsumerFromTerraformToApi(ctx context.Context, terraformDataModel *Consume
 
 	_ = terraformDataModel.Labels.ElementsAs(ctx, &apiDataModel.Labels, true)
 
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	apiDataModel.Plugins = PluginsWithSensitiveToJson(ctx, PluginsTypedValue(ctx, PluginsValue(ctx, terraformDataModel.Plugins, terraformDataModel.Plugin), terraformDataModel.PluginsTyped), terraformDataModel.SensitivePlugins)
 
 	tflog.Debug(ctx, "Result of ConsumerFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
@@ -75,6 +99,7 @@ func ConsumerFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Co
 	terraformDataModel.Labels, _ = types.MapValueFrom(ctx, types.StringType, apiDataModel.Labels)
 
 	terraformDataModel.Plugins = PluginsFromJsonToString(ctx, apiDataModel.Plugins)
+	terraformDataModel.PluginsTyped = types.ObjectNull(ConsumerPluginsTypedSchemaAttribute.GetType().(types.ObjectType).AttrTypes)
 
 	tflog.Debug(ctx, "Result of ConsumerFromApiToTerraform", map[string]any{
 		"Values": terraformDataModel,
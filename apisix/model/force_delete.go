@@ -0,0 +1,11 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+var ForceDeleteSchemaAttribute = schema.BoolAttribute{
+	MarkdownDescription: "Deletes the object even when other objects still use it, with the APISIX Admin API `force=true` parameter. " +
+		"The objects using it keep a dangling reference. Defaults to `false`.",
+	Optional: true,
+}
@@ -5,20 +5,37 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // GlobalRuleResourceModel maps the resource schema data.
 type GlobalRuleResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	Plugins types.String `tfsdk:"plugins"`
+	ID                      types.String   `tfsdk:"id"`
+	Plugins                 types.String   `tfsdk:"plugins"`
+	Plugin                  types.Dynamic  `tfsdk:"plugin"`
+	SensitivePlugins        types.String   `tfsdk:"sensitive_plugins"`
+	CreateTime              types.Int64    `tfsdk:"create_time"`
+	UpdateTime              types.Int64    `tfsdk:"update_time"`
+	AdoptExisting           types.Bool     `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool     `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+	UpdateStrategy          types.String   `tfsdk:"update_strategy"`
 }
 
+// GlobalRuleStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the global rule.
+var GlobalRuleStateUpgrades = []StateUpgradeStep{}
+
 var GlobalRuleSchema = schema.Schema{
+	Version:     int64(len(GlobalRuleStateUpgrades)),
 	Description: "Sets APISIX Plugins which run globally.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
@@ -29,15 +46,28 @@ var GlobalRuleSchema = schema.Schema{
 			},
 		},
 		"plugins": schema.StringAttribute{
-			Description: "Plugins that are executed during the request/response cycle.",
-			Required:    true,
+			Description: "Plugins that are executed during the request/response cycle. Exactly one of `plugins` and `plugin` must be set.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("plugin")),
+			},
 		},
+		"sensitive_plugins":         SensitivePluginsSchemaAttribute,
+		"plugin":                    PluginSchemaAttribute,
+		"create_time":               CreateTimeSchemaAttribute,
+		"update_time":               UpdateTimeSchemaAttribute,
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+		"update_strategy":           UpdateStrategySchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
 func GlobalRuleFromTerraformToApi(ctx context.Context, terraformDataModel *GlobalRuleResourceModel) (apiDataModel api_client.GlobalRule) {
 	apiDataModel.ID = terraformDataModel.ID.ValueStringPointer()
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	apiDataModel.Plugins = PluginsWithSensitiveToJson(ctx, PluginsValue(ctx, terraformDataModel.Plugins, terraformDataModel.Plugin), terraformDataModel.SensitivePlugins)
 
 	tflog.Debug(ctx, "Result of the GlobalRuleFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
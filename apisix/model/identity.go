@@ -0,0 +1,67 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ObjectIdentityModel is the identity of the objects identified by their ID.
+type ObjectIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+var ObjectIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"id": identityschema.StringAttribute{
+			Description:       "Identifier of the object in APISIX.",
+			RequiredForImport: true,
+		},
+	},
+}
+
+// ConsumerIdentityModel is the identity of the consumers.
+type ConsumerIdentityModel struct {
+	Username types.String `tfsdk:"username"`
+}
+
+var ConsumerIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"username": identityschema.StringAttribute{
+			Description:       "Username of the Consumer.",
+			RequiredForImport: true,
+		},
+	},
+}
+
+// SecretIdentityModel is the identity of the secrets, unique per secret manager.
+type SecretIdentityModel struct {
+	Manager types.String `tfsdk:"manager"`
+	ID      types.String `tfsdk:"id"`
+}
+
+var SecretIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"manager": identityschema.StringAttribute{
+			Description:       "Secret manager of the Secret, `vault`, `aws` or `gcp`.",
+			RequiredForImport: true,
+		},
+		"id": identityschema.StringAttribute{
+			Description:       "Identifier of the Secret.",
+			RequiredForImport: true,
+		},
+	},
+}
+
+// PluginMetadataIdentityModel is the identity of the plugin metadata.
+type PluginMetadataIdentityModel struct {
+	PluginName types.String `tfsdk:"plugin_name"`
+}
+
+var PluginMetadataIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"plugin_name": identityschema.StringAttribute{
+			Description:       "Name of the plugin the metadata belongs to.",
+			RequiredForImport: true,
+		},
+	},
+}
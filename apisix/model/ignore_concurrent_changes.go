@@ -0,0 +1,11 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+var IgnoreConcurrentChangesSchemaAttribute = schema.BoolAttribute{
+	MarkdownDescription: "Skips the check that the object wasn't modified outside Terraform since the last refresh before updating or deleting it. " +
+		"Without it, a change made by another tool in between fails the apply instead of being silently overwritten. Defaults to `false`.",
+	Optional: true,
+}
@@ -5,22 +5,40 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // PluginConfigResourceModel maps the resource schema data.
 type PluginConfigResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Description types.String `tfsdk:"desc"`
-	Labels      types.Map    `tfsdk:"labels"`
-	Plugins     types.String `tfsdk:"plugins"`
+	ID                      types.String   `tfsdk:"id"`
+	Description             types.String   `tfsdk:"desc"`
+	Labels                  types.Map      `tfsdk:"labels"`
+	Plugins                 types.String   `tfsdk:"plugins"`
+	Plugin                  types.Dynamic  `tfsdk:"plugin"`
+	SensitivePlugins        types.String   `tfsdk:"sensitive_plugins"`
+	CreateTime              types.Int64    `tfsdk:"create_time"`
+	UpdateTime              types.Int64    `tfsdk:"update_time"`
+	AdoptExisting           types.Bool     `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool     `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+	ForceDelete             types.Bool     `tfsdk:"force_delete"`
+	UpdateStrategy          types.String   `tfsdk:"update_strategy"`
 }
 
+// PluginConfigStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the plugin config.
+var PluginConfigStateUpgrades = []StateUpgradeStep{}
+
 var PluginConfigSchema = schema.Schema{
+	Version:     int64(len(PluginConfigStateUpgrades)),
 	Description: "Manages APISIX Group of Plugins which can be reused across Routes.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
@@ -40,9 +58,23 @@ var PluginConfigSchema = schema.Schema{
 			Optional:    true,
 		},
 		"plugins": schema.StringAttribute{
-			Description: "Plugins that are executed during the request/response cycle.",
-			Required:    true,
+			Description: "Plugins that are executed during the request/response cycle. Exactly one of `plugins` and `plugin` must be set.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("plugin")),
+			},
 		},
+		"sensitive_plugins":         SensitivePluginsSchemaAttribute,
+		"plugin":                    PluginSchemaAttribute,
+		"create_time":               CreateTimeSchemaAttribute,
+		"update_time":               UpdateTimeSchemaAttribute,
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+		"force_delete":              ForceDeleteSchemaAttribute,
+		"update_strategy":           UpdateStrategySchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
@@ -50,7 +82,7 @@ func PluginConfigFromTerraformToApi(ctx context.Context, terraformDataModel *Plu
 	apiDataModel.ID = terraformDataModel.ID.ValueStringPointer()
 	apiDataModel.Description = terraformDataModel.Description.ValueStringPointer()
 	terraformDataModel.Labels.ElementsAs(ctx, &apiDataModel.Labels, true)
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	apiDataModel.Plugins = PluginsWithSensitiveToJson(ctx, PluginsValue(ctx, terraformDataModel.Plugins, terraformDataModel.Plugin), terraformDataModel.SensitivePlugins)
 
 	tflog.Debug(ctx, "Result of the PluginConfigFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
@@ -5,20 +5,33 @@ import (
 
 	api_client "github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // PluginMetadataResourceModel maps the resource schema data.
 type PluginMetadataResourceModel struct {
-	Id       types.String `tfsdk:"id"`
-	Metadata types.String `tfsdk:"metadata"`
+	Id                      types.String   `tfsdk:"id"`
+	Metadata                types.String   `tfsdk:"metadata"`
+	MetadataObject          types.Dynamic  `tfsdk:"metadata_object"`
+	AdoptExisting           types.Bool     `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool     `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
+// PluginMetadataStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the plugin metadata.
+var PluginMetadataStateUpgrades = []StateUpgradeStep{}
+
 var PluginMetadataSchema = schema.Schema{
+	Version:     int64(len(PluginMetadataStateUpgrades)),
 	Description: "Manages APISIX Plugin Metadata resource.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
@@ -29,15 +42,31 @@ var PluginMetadataSchema = schema.Schema{
 			},
 		},
 		"metadata": schema.StringAttribute{
-			Description: "Metadata associated with the plugin.",
-			Required:    true,
+			Description: "Metadata associated with the plugin, as a JSON string. Exactly one of `metadata` and `metadata_object` must be set.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("metadata_object")),
+			},
+		},
+		"metadata_object": schema.DynamicAttribute{
+			MarkdownDescription: "Metadata associated with the plugin, as an object like `metadata_object = { log_format = { host = \"$host\" } }`. " +
+				"Unlike the `metadata` JSON string, the plans show the changes of each metadata field.",
+			Optional: true,
+			Validators: []validator.Dynamic{
+				DynamicObjectValidator{},
+			},
 		},
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
 func PluginMetadataFromTerraformToApi(ctx context.Context, terraformDataModel *PluginMetadataResourceModel) (apiDataModel api_client.PluginMetadata) {
 	apiDataModel.Id = terraformDataModel.Id.ValueStringPointer()
-	apiDataModel.Metadata = PluginsStringToJson(ctx, terraformDataModel.Metadata)
+	apiDataModel.Metadata = PluginsStringToJson(ctx, PluginsValue(ctx, terraformDataModel.Metadata, terraformDataModel.MetadataObject))
 
 	tflog.Debug(ctx, "Result of the PluginMetadataFromTerraformToApi", map[string]any{
 		"id":       apiDataModel.Id,
@@ -57,3 +86,14 @@ func PluginMetadataFromApiToTerraform(ctx context.Context, apiDataModel *api_cli
 
 	return terraformDataModel
 }
+
+// KeepMetadataObject keeps the metadata of the plan or the prior state set as
+// an object, APISIX only returns it as JSON.
+func (m *PluginMetadataResourceModel) KeepMetadataObject(prior PluginMetadataResourceModel) {
+	if prior.MetadataObject.IsNull() {
+		return
+	}
+
+	m.Metadata = types.StringNull()
+	m.MetadataObject = prior.MetadataObject
+}
@@ -0,0 +1,277 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedApiBreakerSchemaAttribute is the typed configuration of the api-breaker plugin.
+var PluginTypedApiBreakerSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `api-breaker` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"break_response_body": schema.StringAttribute{
+			Optional: true,
+		},
+		"break_response_code": schema.Int64Attribute{
+			Required: true,
+			Validators: []validator.Int64{
+				int64validator.Between(200, 599),
+			},
+		},
+		"break_response_headers": schema.ListNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"key": schema.StringAttribute{
+						Required: true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"value": schema.StringAttribute{
+						Required: true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+				},
+			},
+		},
+		"healthy": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"http_statuses": schema.ListAttribute{
+					MarkdownDescription: "Defaults to `[200]`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             listdefault.StaticValue(types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(200)})),
+					ElementType:         types.Int64Type,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						listvalidator.UniqueValues(),
+						listvalidator.ValueInt64sAre(int64validator.Between(200, 499)),
+					},
+				},
+				"successes": schema.Int64Attribute{
+					MarkdownDescription: "Defaults to `3`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             int64default.StaticInt64(3),
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+			},
+		},
+		"max_breaker_sec": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `300`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(300),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(3),
+			},
+		},
+		"unhealthy": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"failures": schema.Int64Attribute{
+					MarkdownDescription: "Defaults to `3`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             int64default.StaticInt64(3),
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+				"http_statuses": schema.ListAttribute{
+					MarkdownDescription: "Defaults to `[500]`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             listdefault.StaticValue(types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(500)})),
+					ElementType:         types.Int64Type,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						listvalidator.UniqueValues(),
+						listvalidator.ValueInt64sAre(int64validator.Between(500, 599)),
+					},
+				},
+			},
+		},
+	},
+}
+
+// PluginTypedApiBreakerType is the model of the configuration of the `api-breaker` plugin.
+type PluginTypedApiBreakerType struct {
+	BreakResponseBody    types.String                                    `tfsdk:"break_response_body"`
+	BreakResponseCode    types.Int64                                     `tfsdk:"break_response_code"`
+	BreakResponseHeaders []PluginTypedApiBreakerBreakResponseHeadersType `tfsdk:"break_response_headers"`
+	Healthy              *PluginTypedApiBreakerHealthyType               `tfsdk:"healthy"`
+	MaxBreakerSec        types.Int64                                     `tfsdk:"max_breaker_sec"`
+	Unhealthy            *PluginTypedApiBreakerUnhealthyType             `tfsdk:"unhealthy"`
+}
+
+// PluginTypedApiBreakerFromTerraformToApi converts the configuration of the `api-breaker` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedApiBreakerFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedApiBreakerType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "break_response_body", terraformDataModel.BreakResponseBody)
+	setTypedField(ctx, result, "break_response_code", terraformDataModel.BreakResponseCode)
+	if terraformDataModel.BreakResponseHeaders != nil {
+		items := make([]interface{}, 0, len(terraformDataModel.BreakResponseHeaders))
+		for i := range terraformDataModel.BreakResponseHeaders {
+			items = append(items, PluginTypedApiBreakerBreakResponseHeadersFromTerraformToApi(ctx, &terraformDataModel.BreakResponseHeaders[i]))
+		}
+		result["break_response_headers"] = items
+	}
+	if nested := PluginTypedApiBreakerHealthyFromTerraformToApi(ctx, terraformDataModel.Healthy); nested != nil {
+		result["healthy"] = nested
+	}
+	setTypedField(ctx, result, "max_breaker_sec", terraformDataModel.MaxBreakerSec)
+	if nested := PluginTypedApiBreakerUnhealthyFromTerraformToApi(ctx, terraformDataModel.Unhealthy); nested != nil {
+		result["unhealthy"] = nested
+	}
+
+	return result
+}
+
+// PluginTypedApiBreakerFromApiToTerraform converts the APISIX JSON of the configuration of the `api-breaker` plugin into its model.
+func PluginTypedApiBreakerFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedApiBreakerType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedApiBreakerType{
+		BreakResponseBody: typedStringField(ctx, apiDataModel, "break_response_body"),
+		BreakResponseCode: typedInt64Field(ctx, apiDataModel, "break_response_code"),
+		Healthy:           PluginTypedApiBreakerHealthyFromApiToTerraform(ctx, typedObjectField(ctx, apiDataModel, "healthy")),
+		MaxBreakerSec:     typedInt64Field(ctx, apiDataModel, "max_breaker_sec"),
+		Unhealthy:         PluginTypedApiBreakerUnhealthyFromApiToTerraform(ctx, typedObjectField(ctx, apiDataModel, "unhealthy")),
+	}
+	if items := typedObjectsField(ctx, apiDataModel, "break_response_headers"); items != nil {
+		result.BreakResponseHeaders = make([]PluginTypedApiBreakerBreakResponseHeadersType, 0, len(items))
+		for _, item := range items {
+			result.BreakResponseHeaders = append(result.BreakResponseHeaders, *PluginTypedApiBreakerBreakResponseHeadersFromApiToTerraform(ctx, item))
+		}
+	}
+
+	return &result
+}
+
+// PluginTypedApiBreakerBreakResponseHeadersType is the model of the `api-breaker.break_response_headers` field.
+type PluginTypedApiBreakerBreakResponseHeadersType struct {
+	Key   types.String `tfsdk:"key"`
+	Value types.String `tfsdk:"value"`
+}
+
+// PluginTypedApiBreakerBreakResponseHeadersFromTerraformToApi converts the `api-breaker.break_response_headers` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedApiBreakerBreakResponseHeadersFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedApiBreakerBreakResponseHeadersType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "key", terraformDataModel.Key)
+	setTypedField(ctx, result, "value", terraformDataModel.Value)
+
+	return result
+}
+
+// PluginTypedApiBreakerBreakResponseHeadersFromApiToTerraform converts the APISIX JSON of the `api-breaker.break_response_headers` field into its model.
+func PluginTypedApiBreakerBreakResponseHeadersFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedApiBreakerBreakResponseHeadersType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedApiBreakerBreakResponseHeadersType{
+		Key:   typedStringField(ctx, apiDataModel, "key"),
+		Value: typedStringField(ctx, apiDataModel, "value"),
+	}
+
+	return &result
+}
+
+// PluginTypedApiBreakerHealthyType is the model of the `api-breaker.healthy` field.
+type PluginTypedApiBreakerHealthyType struct {
+	HttpStatuses types.List  `tfsdk:"http_statuses"`
+	Successes    types.Int64 `tfsdk:"successes"`
+}
+
+// PluginTypedApiBreakerHealthyFromTerraformToApi converts the `api-breaker.healthy` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedApiBreakerHealthyFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedApiBreakerHealthyType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "http_statuses", terraformDataModel.HttpStatuses)
+	setTypedField(ctx, result, "successes", terraformDataModel.Successes)
+
+	return result
+}
+
+// PluginTypedApiBreakerHealthyFromApiToTerraform converts the APISIX JSON of the `api-breaker.healthy` field into its model.
+func PluginTypedApiBreakerHealthyFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedApiBreakerHealthyType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedApiBreakerHealthyType{
+		HttpStatuses: typedListField(ctx, apiDataModel, "http_statuses", types.Int64Type),
+		Successes:    typedInt64Field(ctx, apiDataModel, "successes"),
+	}
+
+	return &result
+}
+
+// PluginTypedApiBreakerUnhealthyType is the model of the `api-breaker.unhealthy` field.
+type PluginTypedApiBreakerUnhealthyType struct {
+	Failures     types.Int64 `tfsdk:"failures"`
+	HttpStatuses types.List  `tfsdk:"http_statuses"`
+}
+
+// PluginTypedApiBreakerUnhealthyFromTerraformToApi converts the `api-breaker.unhealthy` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedApiBreakerUnhealthyFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedApiBreakerUnhealthyType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "failures", terraformDataModel.Failures)
+	setTypedField(ctx, result, "http_statuses", terraformDataModel.HttpStatuses)
+
+	return result
+}
+
+// PluginTypedApiBreakerUnhealthyFromApiToTerraform converts the APISIX JSON of the `api-breaker.unhealthy` field into its model.
+func PluginTypedApiBreakerUnhealthyFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedApiBreakerUnhealthyType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedApiBreakerUnhealthyType{
+		Failures:     typedInt64Field(ctx, apiDataModel, "failures"),
+		HttpStatuses: typedListField(ctx, apiDataModel, "http_statuses", types.Int64Type),
+	}
+
+	return &result
+}
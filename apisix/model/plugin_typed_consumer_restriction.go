@@ -0,0 +1,168 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedConsumerRestrictionSchemaAttribute is the typed configuration of the consumer-restriction plugin.
+var PluginTypedConsumerRestrictionSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `consumer-restriction` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"allowed_by_methods": schema.ListNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"methods": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.ValueStringsAre(stringvalidator.OneOf("GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "CONNECT", "TRACE", "PURGE")),
+						},
+					},
+					"user": schema.StringAttribute{
+						Optional: true,
+					},
+				},
+			},
+		},
+		"blacklist": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+		"rejected_code": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `403`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(403),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(200),
+			},
+		},
+		"rejected_msg": schema.StringAttribute{
+			Optional: true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `consumer_name`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("consumer_name"),
+			Validators: []validator.String{
+				stringvalidator.OneOf("consumer_name", "service_id", "route_id", "consumer_group_id"),
+			},
+		},
+		"whitelist": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+	},
+}
+
+// PluginTypedConsumerRestrictionType is the model of the configuration of the `consumer-restriction` plugin.
+type PluginTypedConsumerRestrictionType struct {
+	AllowedByMethods []PluginTypedConsumerRestrictionAllowedByMethodsType `tfsdk:"allowed_by_methods"`
+	Blacklist        types.List                                           `tfsdk:"blacklist"`
+	RejectedCode     types.Int64                                          `tfsdk:"rejected_code"`
+	RejectedMsg      types.String                                         `tfsdk:"rejected_msg"`
+	Type             types.String                                         `tfsdk:"type"`
+	Whitelist        types.List                                           `tfsdk:"whitelist"`
+}
+
+// PluginTypedConsumerRestrictionFromTerraformToApi converts the configuration of the `consumer-restriction` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedConsumerRestrictionFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedConsumerRestrictionType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	if terraformDataModel.AllowedByMethods != nil {
+		items := make([]interface{}, 0, len(terraformDataModel.AllowedByMethods))
+		for i := range terraformDataModel.AllowedByMethods {
+			items = append(items, PluginTypedConsumerRestrictionAllowedByMethodsFromTerraformToApi(ctx, &terraformDataModel.AllowedByMethods[i]))
+		}
+		result["allowed_by_methods"] = items
+	}
+	setTypedField(ctx, result, "blacklist", terraformDataModel.Blacklist)
+	setTypedField(ctx, result, "rejected_code", terraformDataModel.RejectedCode)
+	setTypedField(ctx, result, "rejected_msg", terraformDataModel.RejectedMsg)
+	setTypedField(ctx, result, "type", terraformDataModel.Type)
+	setTypedField(ctx, result, "whitelist", terraformDataModel.Whitelist)
+
+	return result
+}
+
+// PluginTypedConsumerRestrictionFromApiToTerraform converts the APISIX JSON of the configuration of the `consumer-restriction` plugin into its model.
+func PluginTypedConsumerRestrictionFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedConsumerRestrictionType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedConsumerRestrictionType{
+		Blacklist:    typedListField(ctx, apiDataModel, "blacklist", types.StringType),
+		RejectedCode: typedInt64Field(ctx, apiDataModel, "rejected_code"),
+		RejectedMsg:  typedStringField(ctx, apiDataModel, "rejected_msg"),
+		Type:         typedStringField(ctx, apiDataModel, "type"),
+		Whitelist:    typedListField(ctx, apiDataModel, "whitelist", types.StringType),
+	}
+	if items := typedObjectsField(ctx, apiDataModel, "allowed_by_methods"); items != nil {
+		result.AllowedByMethods = make([]PluginTypedConsumerRestrictionAllowedByMethodsType, 0, len(items))
+		for _, item := range items {
+			result.AllowedByMethods = append(result.AllowedByMethods, *PluginTypedConsumerRestrictionAllowedByMethodsFromApiToTerraform(ctx, item))
+		}
+	}
+
+	return &result
+}
+
+// PluginTypedConsumerRestrictionAllowedByMethodsType is the model of the `consumer-restriction.allowed_by_methods` field.
+type PluginTypedConsumerRestrictionAllowedByMethodsType struct {
+	Methods types.List   `tfsdk:"methods"`
+	User    types.String `tfsdk:"user"`
+}
+
+// PluginTypedConsumerRestrictionAllowedByMethodsFromTerraformToApi converts the `consumer-restriction.allowed_by_methods` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedConsumerRestrictionAllowedByMethodsFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedConsumerRestrictionAllowedByMethodsType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "methods", terraformDataModel.Methods)
+	setTypedField(ctx, result, "user", terraformDataModel.User)
+
+	return result
+}
+
+// PluginTypedConsumerRestrictionAllowedByMethodsFromApiToTerraform converts the APISIX JSON of the `consumer-restriction.allowed_by_methods` field into its model.
+func PluginTypedConsumerRestrictionAllowedByMethodsFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedConsumerRestrictionAllowedByMethodsType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedConsumerRestrictionAllowedByMethodsType{
+		Methods: typedListField(ctx, apiDataModel, "methods", types.StringType),
+		User:    typedStringField(ctx, apiDataModel, "user"),
+	}
+
+	return &result
+}
@@ -0,0 +1,157 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedCorsSchemaAttribute is the typed configuration of the cors plugin.
+var PluginTypedCorsSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `cors` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"allow_credential": schema.BoolAttribute{
+			MarkdownDescription: "Allow client append credential. according to CORS specification, if you set this option to 'true', you can not use '*' for other options. Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"allow_headers": schema.StringAttribute{
+			MarkdownDescription: "You can use '*' to allow all header when no credentials, '**' to allow forcefully(it will bring some security risks, be carefully), multiple header use ',' to split. default: *. Defaults to `*`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("*"),
+		},
+		"allow_methods": schema.StringAttribute{
+			MarkdownDescription: "You can use '*' to allow all methods when no credentials, '**' to allow forcefully(it will bring some security risks, be carefully), multiple method use ',' to split. default: *. Defaults to `*`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("*"),
+		},
+		"allow_origins": schema.StringAttribute{
+			MarkdownDescription: "You can use '*' to allow all origins when no credentials, '**' to allow forcefully(it will bring some security risks, be carefully), multiple origin use ',' to split. default: *. Defaults to `*`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("*"),
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^(\*|\*\*|null|\w+://[^,]+(,\w+://[^,]+)*)$`), `must match the pattern ^(\*|\*\*|null|\w+://[^,]+(,\w+://[^,]+)*)$`),
+			},
+		},
+		"allow_origins_by_metadata": schema.ListAttribute{
+			MarkdownDescription: "Set allowed origins by referencing origins in plugin metadata.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.UniqueValues(),
+				listvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 4096)),
+			},
+		},
+		"allow_origins_by_regex": schema.ListAttribute{
+			MarkdownDescription: "You can use regex to allow specific origins when no credentials, for example use [.*\\.test.com$] to allow a.test.com and b.test.com.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.UniqueValues(),
+				listvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 4096)),
+			},
+		},
+		"expose_headers": schema.StringAttribute{
+			MarkdownDescription: "Multiple header use ',' to split. If not specified, no custom headers are exposed.",
+			Optional:            true,
+		},
+		"max_age": schema.Int64Attribute{
+			MarkdownDescription: "Maximum number of seconds the results can be cached. -1 means no cached, the max value is depend on browser, more details plz check MDN. default: 5. Defaults to `5`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(5),
+		},
+		"timing_allow_origins": schema.StringAttribute{
+			MarkdownDescription: "You can use '*' to allow all origins which can view timing information when no credentials, '**' to allow forcefully (it will bring some security risks, be careful), multiple origin use ',' to split. default: nil.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^(\*|\*\*|null|\w+://[^,]+(,\w+://[^,]+)*)$`), `must match the pattern ^(\*|\*\*|null|\w+://[^,]+(,\w+://[^,]+)*)$`),
+			},
+		},
+		"timing_allow_origins_by_regex": schema.ListAttribute{
+			MarkdownDescription: "You can use regex to allow specific origins which can view timing information, for example use [.*\\.test.com] to allow a.test.com and b.test.com.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.UniqueValues(),
+				listvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 4096)),
+			},
+		},
+	},
+}
+
+// PluginTypedCorsType is the model of the configuration of the `cors` plugin.
+type PluginTypedCorsType struct {
+	AllowCredential           types.Bool   `tfsdk:"allow_credential"`
+	AllowHeaders              types.String `tfsdk:"allow_headers"`
+	AllowMethods              types.String `tfsdk:"allow_methods"`
+	AllowOrigins              types.String `tfsdk:"allow_origins"`
+	AllowOriginsByMetadata    types.List   `tfsdk:"allow_origins_by_metadata"`
+	AllowOriginsByRegex       types.List   `tfsdk:"allow_origins_by_regex"`
+	ExposeHeaders             types.String `tfsdk:"expose_headers"`
+	MaxAge                    types.Int64  `tfsdk:"max_age"`
+	TimingAllowOrigins        types.String `tfsdk:"timing_allow_origins"`
+	TimingAllowOriginsByRegex types.List   `tfsdk:"timing_allow_origins_by_regex"`
+}
+
+// PluginTypedCorsFromTerraformToApi converts the configuration of the `cors` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedCorsFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedCorsType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "allow_credential", terraformDataModel.AllowCredential)
+	setTypedField(ctx, result, "allow_headers", terraformDataModel.AllowHeaders)
+	setTypedField(ctx, result, "allow_methods", terraformDataModel.AllowMethods)
+	setTypedField(ctx, result, "allow_origins", terraformDataModel.AllowOrigins)
+	setTypedField(ctx, result, "allow_origins_by_metadata", terraformDataModel.AllowOriginsByMetadata)
+	setTypedField(ctx, result, "allow_origins_by_regex", terraformDataModel.AllowOriginsByRegex)
+	setTypedField(ctx, result, "expose_headers", terraformDataModel.ExposeHeaders)
+	setTypedField(ctx, result, "max_age", terraformDataModel.MaxAge)
+	setTypedField(ctx, result, "timing_allow_origins", terraformDataModel.TimingAllowOrigins)
+	setTypedField(ctx, result, "timing_allow_origins_by_regex", terraformDataModel.TimingAllowOriginsByRegex)
+
+	return result
+}
+
+// PluginTypedCorsFromApiToTerraform converts the APISIX JSON of the configuration of the `cors` plugin into its model.
+func PluginTypedCorsFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedCorsType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedCorsType{
+		AllowCredential:           typedBoolField(ctx, apiDataModel, "allow_credential"),
+		AllowHeaders:              typedStringField(ctx, apiDataModel, "allow_headers"),
+		AllowMethods:              typedStringField(ctx, apiDataModel, "allow_methods"),
+		AllowOrigins:              typedStringField(ctx, apiDataModel, "allow_origins"),
+		AllowOriginsByMetadata:    typedListField(ctx, apiDataModel, "allow_origins_by_metadata", types.StringType),
+		AllowOriginsByRegex:       typedListField(ctx, apiDataModel, "allow_origins_by_regex", types.StringType),
+		ExposeHeaders:             typedStringField(ctx, apiDataModel, "expose_headers"),
+		MaxAge:                    typedInt64Field(ctx, apiDataModel, "max_age"),
+		TimingAllowOrigins:        typedStringField(ctx, apiDataModel, "timing_allow_origins"),
+		TimingAllowOriginsByRegex: typedListField(ctx, apiDataModel, "timing_allow_origins_by_regex", types.StringType),
+	}
+
+	return &result
+}
@@ -0,0 +1,192 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedFaultInjectionSchemaAttribute is the typed configuration of the fault-injection plugin.
+var PluginTypedFaultInjectionSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `fault-injection` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"abort": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"body": schema.StringAttribute{
+					Optional: true,
+				},
+				"headers": schema.MapAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators: []validator.Map{
+						mapvalidator.SizeAtLeast(1),
+					},
+				},
+				"http_status": schema.Int64Attribute{
+					Required: true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(200),
+					},
+				},
+				"percentage": schema.Int64Attribute{
+					Optional: true,
+					Validators: []validator.Int64{
+						int64validator.Between(0, 100),
+					},
+				},
+				"vars": schema.StringAttribute{
+					MarkdownDescription: "As a JSON string, like `jsonencode(...)`.",
+					Optional:            true,
+				},
+			},
+		},
+		"delay": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"duration": schema.Float64Attribute{
+					Required: true,
+					Validators: []validator.Float64{
+						float64validator.AtLeast(0),
+					},
+				},
+				"percentage": schema.Int64Attribute{
+					Optional: true,
+					Validators: []validator.Int64{
+						int64validator.Between(0, 100),
+					},
+				},
+				"vars": schema.StringAttribute{
+					MarkdownDescription: "As a JSON string, like `jsonencode(...)`.",
+					Optional:            true,
+				},
+			},
+		},
+	},
+}
+
+// PluginTypedFaultInjectionType is the model of the configuration of the `fault-injection` plugin.
+type PluginTypedFaultInjectionType struct {
+	Abort *PluginTypedFaultInjectionAbortType `tfsdk:"abort"`
+	Delay *PluginTypedFaultInjectionDelayType `tfsdk:"delay"`
+}
+
+// PluginTypedFaultInjectionFromTerraformToApi converts the configuration of the `fault-injection` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedFaultInjectionFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedFaultInjectionType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	if nested := PluginTypedFaultInjectionAbortFromTerraformToApi(ctx, terraformDataModel.Abort); nested != nil {
+		result["abort"] = nested
+	}
+	if nested := PluginTypedFaultInjectionDelayFromTerraformToApi(ctx, terraformDataModel.Delay); nested != nil {
+		result["delay"] = nested
+	}
+
+	return result
+}
+
+// PluginTypedFaultInjectionFromApiToTerraform converts the APISIX JSON of the configuration of the `fault-injection` plugin into its model.
+func PluginTypedFaultInjectionFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedFaultInjectionType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedFaultInjectionType{
+		Abort: PluginTypedFaultInjectionAbortFromApiToTerraform(ctx, typedObjectField(ctx, apiDataModel, "abort")),
+		Delay: PluginTypedFaultInjectionDelayFromApiToTerraform(ctx, typedObjectField(ctx, apiDataModel, "delay")),
+	}
+
+	return &result
+}
+
+// PluginTypedFaultInjectionAbortType is the model of the `fault-injection.abort` field.
+type PluginTypedFaultInjectionAbortType struct {
+	Body       types.String `tfsdk:"body"`
+	Headers    types.Map    `tfsdk:"headers"`
+	HttpStatus types.Int64  `tfsdk:"http_status"`
+	Percentage types.Int64  `tfsdk:"percentage"`
+	Vars       types.String `tfsdk:"vars"`
+}
+
+// PluginTypedFaultInjectionAbortFromTerraformToApi converts the `fault-injection.abort` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedFaultInjectionAbortFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedFaultInjectionAbortType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "body", terraformDataModel.Body)
+	setTypedField(ctx, result, "headers", terraformDataModel.Headers)
+	setTypedField(ctx, result, "http_status", terraformDataModel.HttpStatus)
+	setTypedField(ctx, result, "percentage", terraformDataModel.Percentage)
+	setTypedJSONField(ctx, result, "vars", terraformDataModel.Vars)
+
+	return result
+}
+
+// PluginTypedFaultInjectionAbortFromApiToTerraform converts the APISIX JSON of the `fault-injection.abort` field into its model.
+func PluginTypedFaultInjectionAbortFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedFaultInjectionAbortType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedFaultInjectionAbortType{
+		Body:       typedStringField(ctx, apiDataModel, "body"),
+		Headers:    typedMapField(ctx, apiDataModel, "headers", types.StringType),
+		HttpStatus: typedInt64Field(ctx, apiDataModel, "http_status"),
+		Percentage: typedInt64Field(ctx, apiDataModel, "percentage"),
+		Vars:       typedJSONField(ctx, apiDataModel, "vars"),
+	}
+
+	return &result
+}
+
+// PluginTypedFaultInjectionDelayType is the model of the `fault-injection.delay` field.
+type PluginTypedFaultInjectionDelayType struct {
+	Duration   types.Float64 `tfsdk:"duration"`
+	Percentage types.Int64   `tfsdk:"percentage"`
+	Vars       types.String  `tfsdk:"vars"`
+}
+
+// PluginTypedFaultInjectionDelayFromTerraformToApi converts the `fault-injection.delay` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedFaultInjectionDelayFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedFaultInjectionDelayType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "duration", terraformDataModel.Duration)
+	setTypedField(ctx, result, "percentage", terraformDataModel.Percentage)
+	setTypedJSONField(ctx, result, "vars", terraformDataModel.Vars)
+
+	return result
+}
+
+// PluginTypedFaultInjectionDelayFromApiToTerraform converts the APISIX JSON of the `fault-injection.delay` field into its model.
+func PluginTypedFaultInjectionDelayFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedFaultInjectionDelayType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedFaultInjectionDelayType{
+		Duration:   typedFloat64Field(ctx, apiDataModel, "duration"),
+		Percentage: typedInt64Field(ctx, apiDataModel, "percentage"),
+		Vars:       typedJSONField(ctx, apiDataModel, "vars"),
+	}
+
+	return &result
+}
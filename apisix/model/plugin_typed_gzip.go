@@ -0,0 +1,173 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedGzipSchemaAttribute is the typed configuration of the gzip plugin.
+var PluginTypedGzipSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `gzip` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"buffers": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"number": schema.Int64Attribute{
+					MarkdownDescription: "Defaults to `32`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             int64default.StaticInt64(32),
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+				"size": schema.Int64Attribute{
+					MarkdownDescription: "Defaults to `4096`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             int64default.StaticInt64(4096),
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+			},
+		},
+		"comp_level": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `1`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(1),
+			Validators: []validator.Int64{
+				int64validator.Between(1, 9),
+			},
+		},
+		"http_version": schema.Float64Attribute{
+			MarkdownDescription: "Defaults to `1.1`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             float64default.StaticFloat64(1.1),
+			Validators: []validator.Float64{
+				float64validator.OneOf(1.1, 1.0),
+			},
+		},
+		"min_length": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `20`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(20),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"types": schema.ListAttribute{
+			MarkdownDescription: "Defaults to `[\"text/html\"]`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("text/html")})),
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+			},
+		},
+		"vary": schema.BoolAttribute{
+			Optional: true,
+		},
+	},
+}
+
+// PluginTypedGzipType is the model of the configuration of the `gzip` plugin.
+type PluginTypedGzipType struct {
+	Buffers     *PluginTypedGzipBuffersType `tfsdk:"buffers"`
+	CompLevel   types.Int64                 `tfsdk:"comp_level"`
+	HttpVersion types.Float64               `tfsdk:"http_version"`
+	MinLength   types.Int64                 `tfsdk:"min_length"`
+	Types       types.List                  `tfsdk:"types"`
+	Vary        types.Bool                  `tfsdk:"vary"`
+}
+
+// PluginTypedGzipFromTerraformToApi converts the configuration of the `gzip` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedGzipFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedGzipType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	if nested := PluginTypedGzipBuffersFromTerraformToApi(ctx, terraformDataModel.Buffers); nested != nil {
+		result["buffers"] = nested
+	}
+	setTypedField(ctx, result, "comp_level", terraformDataModel.CompLevel)
+	setTypedField(ctx, result, "http_version", terraformDataModel.HttpVersion)
+	setTypedField(ctx, result, "min_length", terraformDataModel.MinLength)
+	setTypedField(ctx, result, "types", terraformDataModel.Types)
+	setTypedField(ctx, result, "vary", terraformDataModel.Vary)
+
+	return result
+}
+
+// PluginTypedGzipFromApiToTerraform converts the APISIX JSON of the configuration of the `gzip` plugin into its model.
+func PluginTypedGzipFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedGzipType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedGzipType{
+		Buffers:     PluginTypedGzipBuffersFromApiToTerraform(ctx, typedObjectField(ctx, apiDataModel, "buffers")),
+		CompLevel:   typedInt64Field(ctx, apiDataModel, "comp_level"),
+		HttpVersion: typedFloat64Field(ctx, apiDataModel, "http_version"),
+		MinLength:   typedInt64Field(ctx, apiDataModel, "min_length"),
+		Types:       typedListField(ctx, apiDataModel, "types", types.StringType),
+		Vary:        typedBoolField(ctx, apiDataModel, "vary"),
+	}
+
+	return &result
+}
+
+// PluginTypedGzipBuffersType is the model of the `gzip.buffers` field.
+type PluginTypedGzipBuffersType struct {
+	Number types.Int64 `tfsdk:"number"`
+	Size   types.Int64 `tfsdk:"size"`
+}
+
+// PluginTypedGzipBuffersFromTerraformToApi converts the `gzip.buffers` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedGzipBuffersFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedGzipBuffersType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "number", terraformDataModel.Number)
+	setTypedField(ctx, result, "size", terraformDataModel.Size)
+
+	return result
+}
+
+// PluginTypedGzipBuffersFromApiToTerraform converts the APISIX JSON of the `gzip.buffers` field into its model.
+func PluginTypedGzipBuffersFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedGzipBuffersType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedGzipBuffersType{
+		Number: typedInt64Field(ctx, apiDataModel, "number"),
+		Size:   typedInt64Field(ctx, apiDataModel, "size"),
+	}
+
+	return &result
+}
@@ -0,0 +1,182 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedHmacAuthSchemaAttribute is the typed configuration of the hmac-auth plugin.
+var PluginTypedHmacAuthSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `hmac-auth` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"allowed_algorithms": schema.ListAttribute{
+			MarkdownDescription: "Defaults to `[\"hmac-sha1\",\"hmac-sha256\",\"hmac-sha512\"]`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("hmac-sha1"), types.StringValue("hmac-sha256"), types.StringValue("hmac-sha512")})),
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ValueStringsAre(stringvalidator.OneOf("hmac-sha1", "hmac-sha256", "hmac-sha512")),
+			},
+		},
+		"anonymous_consumer": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"clock_skew": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `300`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(300),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"hide_credentials": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"realm": schema.StringAttribute{
+			MarkdownDescription: "Realm attribute in the WWW-Authenticate header of the 401 response. Defaults to `hmac`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("hmac"),
+		},
+		"signed_headers": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, 50)),
+			},
+		},
+		"validate_request_body": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+	},
+}
+
+// PluginTypedHmacAuthConsumerSchemaAttribute is the typed configuration of the hmac-auth plugin on the consumers.
+var PluginTypedHmacAuthConsumerSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The consumer configuration of the `hmac-auth` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"key_id": schema.StringAttribute{
+			Required: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"secret_key": schema.StringAttribute{
+			Required:  true,
+			Sensitive: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+	},
+}
+
+// PluginTypedHmacAuthType is the model of the configuration of the `hmac-auth` plugin.
+type PluginTypedHmacAuthType struct {
+	AllowedAlgorithms   types.List   `tfsdk:"allowed_algorithms"`
+	AnonymousConsumer   types.String `tfsdk:"anonymous_consumer"`
+	ClockSkew           types.Int64  `tfsdk:"clock_skew"`
+	HideCredentials     types.Bool   `tfsdk:"hide_credentials"`
+	Realm               types.String `tfsdk:"realm"`
+	SignedHeaders       types.List   `tfsdk:"signed_headers"`
+	ValidateRequestBody types.Bool   `tfsdk:"validate_request_body"`
+}
+
+// PluginTypedHmacAuthFromTerraformToApi converts the configuration of the `hmac-auth` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedHmacAuthFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedHmacAuthType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "allowed_algorithms", terraformDataModel.AllowedAlgorithms)
+	setTypedField(ctx, result, "anonymous_consumer", terraformDataModel.AnonymousConsumer)
+	setTypedField(ctx, result, "clock_skew", terraformDataModel.ClockSkew)
+	setTypedField(ctx, result, "hide_credentials", terraformDataModel.HideCredentials)
+	setTypedField(ctx, result, "realm", terraformDataModel.Realm)
+	setTypedField(ctx, result, "signed_headers", terraformDataModel.SignedHeaders)
+	setTypedField(ctx, result, "validate_request_body", terraformDataModel.ValidateRequestBody)
+
+	return result
+}
+
+// PluginTypedHmacAuthFromApiToTerraform converts the APISIX JSON of the configuration of the `hmac-auth` plugin into its model.
+func PluginTypedHmacAuthFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedHmacAuthType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedHmacAuthType{
+		AllowedAlgorithms:   typedListField(ctx, apiDataModel, "allowed_algorithms", types.StringType),
+		AnonymousConsumer:   typedStringField(ctx, apiDataModel, "anonymous_consumer"),
+		ClockSkew:           typedInt64Field(ctx, apiDataModel, "clock_skew"),
+		HideCredentials:     typedBoolField(ctx, apiDataModel, "hide_credentials"),
+		Realm:               typedStringField(ctx, apiDataModel, "realm"),
+		SignedHeaders:       typedListField(ctx, apiDataModel, "signed_headers", types.StringType),
+		ValidateRequestBody: typedBoolField(ctx, apiDataModel, "validate_request_body"),
+	}
+
+	return &result
+}
+
+// PluginTypedHmacAuthConsumerType is the model of the consumer configuration of the `hmac-auth` plugin.
+type PluginTypedHmacAuthConsumerType struct {
+	KeyId     types.String `tfsdk:"key_id"`
+	SecretKey types.String `tfsdk:"secret_key"`
+}
+
+// PluginTypedHmacAuthConsumerFromTerraformToApi converts the consumer configuration of the `hmac-auth` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedHmacAuthConsumerFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedHmacAuthConsumerType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "key_id", terraformDataModel.KeyId)
+	setTypedField(ctx, result, "secret_key", terraformDataModel.SecretKey)
+
+	return result
+}
+
+// PluginTypedHmacAuthConsumerFromApiToTerraform converts the APISIX JSON of the consumer configuration of the `hmac-auth` plugin into its model.
+func PluginTypedHmacAuthConsumerFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedHmacAuthConsumerType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedHmacAuthConsumerType{
+		KeyId:     typedStringField(ctx, apiDataModel, "key_id"),
+		SecretKey: typedStringField(ctx, apiDataModel, "secret_key"),
+	}
+
+	return &result
+}
@@ -0,0 +1,210 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedHttpLoggerSchemaAttribute is the typed configuration of the http-logger plugin.
+var PluginTypedHttpLoggerSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `http-logger` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"auth_header": schema.StringAttribute{
+			Optional:  true,
+			Sensitive: true,
+		},
+		"batch_max_size": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `1000`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(1000),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"buffer_duration": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `60`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(60),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"concat_method": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `json`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("json"),
+			Validators: []validator.String{
+				stringvalidator.OneOf("json", "new_line"),
+			},
+		},
+		"inactive_timeout": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `5`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(5),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"include_req_body": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"include_req_body_expr": schema.StringAttribute{
+			MarkdownDescription: "As a JSON string, like `jsonencode(...)`.",
+			Optional:            true,
+		},
+		"include_resp_body": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"include_resp_body_expr": schema.StringAttribute{
+			MarkdownDescription: "As a JSON string, like `jsonencode(...)`.",
+			Optional:            true,
+		},
+		"log_format": schema.StringAttribute{
+			MarkdownDescription: "As a JSON string, like `jsonencode(...)`.",
+			Optional:            true,
+		},
+		"max_retry_count": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `0`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(0),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `http logger`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("http logger"),
+		},
+		"retry_delay": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `1`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(1),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+		"ssl_verify": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"timeout": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `3`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(3),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"uri": schema.StringAttribute{
+			Required: true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^[^\/]+:\/\/([\da-zA-Z.-]+|\[[\da-fA-F:]+\])(:\d+)?`), `must match the pattern ^[^\/]+:\/\/([\da-zA-Z.-]+|\[[\da-fA-F:]+\])(:\d+)?`),
+			},
+		},
+	},
+}
+
+// PluginTypedHttpLoggerType is the model of the configuration of the `http-logger` plugin.
+type PluginTypedHttpLoggerType struct {
+	AuthHeader          types.String `tfsdk:"auth_header"`
+	BatchMaxSize        types.Int64  `tfsdk:"batch_max_size"`
+	BufferDuration      types.Int64  `tfsdk:"buffer_duration"`
+	ConcatMethod        types.String `tfsdk:"concat_method"`
+	InactiveTimeout     types.Int64  `tfsdk:"inactive_timeout"`
+	IncludeReqBody      types.Bool   `tfsdk:"include_req_body"`
+	IncludeReqBodyExpr  types.String `tfsdk:"include_req_body_expr"`
+	IncludeRespBody     types.Bool   `tfsdk:"include_resp_body"`
+	IncludeRespBodyExpr types.String `tfsdk:"include_resp_body_expr"`
+	LogFormat           types.String `tfsdk:"log_format"`
+	MaxRetryCount       types.Int64  `tfsdk:"max_retry_count"`
+	Name                types.String `tfsdk:"name"`
+	RetryDelay          types.Int64  `tfsdk:"retry_delay"`
+	SslVerify           types.Bool   `tfsdk:"ssl_verify"`
+	Timeout             types.Int64  `tfsdk:"timeout"`
+	Uri                 types.String `tfsdk:"uri"`
+}
+
+// PluginTypedHttpLoggerFromTerraformToApi converts the configuration of the `http-logger` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedHttpLoggerFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedHttpLoggerType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "auth_header", terraformDataModel.AuthHeader)
+	setTypedField(ctx, result, "batch_max_size", terraformDataModel.BatchMaxSize)
+	setTypedField(ctx, result, "buffer_duration", terraformDataModel.BufferDuration)
+	setTypedField(ctx, result, "concat_method", terraformDataModel.ConcatMethod)
+	setTypedField(ctx, result, "inactive_timeout", terraformDataModel.InactiveTimeout)
+	setTypedField(ctx, result, "include_req_body", terraformDataModel.IncludeReqBody)
+	setTypedJSONField(ctx, result, "include_req_body_expr", terraformDataModel.IncludeReqBodyExpr)
+	setTypedField(ctx, result, "include_resp_body", terraformDataModel.IncludeRespBody)
+	setTypedJSONField(ctx, result, "include_resp_body_expr", terraformDataModel.IncludeRespBodyExpr)
+	setTypedJSONField(ctx, result, "log_format", terraformDataModel.LogFormat)
+	setTypedField(ctx, result, "max_retry_count", terraformDataModel.MaxRetryCount)
+	setTypedField(ctx, result, "name", terraformDataModel.Name)
+	setTypedField(ctx, result, "retry_delay", terraformDataModel.RetryDelay)
+	setTypedField(ctx, result, "ssl_verify", terraformDataModel.SslVerify)
+	setTypedField(ctx, result, "timeout", terraformDataModel.Timeout)
+	setTypedField(ctx, result, "uri", terraformDataModel.Uri)
+
+	return result
+}
+
+// PluginTypedHttpLoggerFromApiToTerraform converts the APISIX JSON of the configuration of the `http-logger` plugin into its model.
+func PluginTypedHttpLoggerFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedHttpLoggerType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedHttpLoggerType{
+		AuthHeader:          typedStringField(ctx, apiDataModel, "auth_header"),
+		BatchMaxSize:        typedInt64Field(ctx, apiDataModel, "batch_max_size"),
+		BufferDuration:      typedInt64Field(ctx, apiDataModel, "buffer_duration"),
+		ConcatMethod:        typedStringField(ctx, apiDataModel, "concat_method"),
+		InactiveTimeout:     typedInt64Field(ctx, apiDataModel, "inactive_timeout"),
+		IncludeReqBody:      typedBoolField(ctx, apiDataModel, "include_req_body"),
+		IncludeReqBodyExpr:  typedJSONField(ctx, apiDataModel, "include_req_body_expr"),
+		IncludeRespBody:     typedBoolField(ctx, apiDataModel, "include_resp_body"),
+		IncludeRespBodyExpr: typedJSONField(ctx, apiDataModel, "include_resp_body_expr"),
+		LogFormat:           typedJSONField(ctx, apiDataModel, "log_format"),
+		MaxRetryCount:       typedInt64Field(ctx, apiDataModel, "max_retry_count"),
+		Name:                typedStringField(ctx, apiDataModel, "name"),
+		RetryDelay:          typedInt64Field(ctx, apiDataModel, "retry_delay"),
+		SslVerify:           typedBoolField(ctx, apiDataModel, "ssl_verify"),
+		Timeout:             typedInt64Field(ctx, apiDataModel, "timeout"),
+		Uri:                 typedStringField(ctx, apiDataModel, "uri"),
+	}
+
+	return &result
+}
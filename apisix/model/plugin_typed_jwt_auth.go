@@ -0,0 +1,244 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedJwtAuthSchemaAttribute is the typed configuration of the jwt-auth plugin.
+var PluginTypedJwtAuthSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `jwt-auth` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"anonymous_consumer": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"claims_to_verify": schema.ListAttribute{
+			MarkdownDescription: "Defaults to `[\"exp\",\"nbf\"]`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("exp"), types.StringValue("nbf")})),
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.UniqueValues(),
+				listvalidator.ValueStringsAre(stringvalidator.OneOf("exp", "nbf")),
+			},
+		},
+		"cookie": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `jwt`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("jwt"),
+		},
+		"header": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `authorization`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("authorization"),
+		},
+		"hide_credentials": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"key_claim_name": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `key`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("key"),
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"query": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `jwt`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("jwt"),
+		},
+		"realm": schema.StringAttribute{
+			MarkdownDescription: "Realm attribute in the WWW-Authenticate header of the 401 response. Defaults to `jwt`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("jwt"),
+		},
+		"store_in_ctx": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+	},
+}
+
+// PluginTypedJwtAuthConsumerSchemaAttribute is the typed configuration of the jwt-auth plugin on the consumers.
+var PluginTypedJwtAuthConsumerSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The consumer configuration of the `jwt-auth` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"algorithm": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `HS256`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("HS256"),
+			Validators: []validator.String{
+				stringvalidator.OneOf("HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512", "EdDSA"),
+			},
+		},
+		"base64_secret": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"exp": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `86400`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(86400),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"key": schema.StringAttribute{
+			Required: true,
+		},
+		"lifetime_grace_period": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `0`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(0),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+		"public_key": schema.StringAttribute{
+			Optional: true,
+		},
+		"secret": schema.StringAttribute{
+			Optional:  true,
+			Sensitive: true,
+		},
+	},
+}
+
+// PluginTypedJwtAuthType is the model of the configuration of the `jwt-auth` plugin.
+type PluginTypedJwtAuthType struct {
+	AnonymousConsumer types.String `tfsdk:"anonymous_consumer"`
+	ClaimsToVerify    types.List   `tfsdk:"claims_to_verify"`
+	Cookie            types.String `tfsdk:"cookie"`
+	Header            types.String `tfsdk:"header"`
+	HideCredentials   types.Bool   `tfsdk:"hide_credentials"`
+	KeyClaimName      types.String `tfsdk:"key_claim_name"`
+	Query             types.String `tfsdk:"query"`
+	Realm             types.String `tfsdk:"realm"`
+	StoreInCtx        types.Bool   `tfsdk:"store_in_ctx"`
+}
+
+// PluginTypedJwtAuthFromTerraformToApi converts the configuration of the `jwt-auth` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedJwtAuthFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedJwtAuthType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "anonymous_consumer", terraformDataModel.AnonymousConsumer)
+	setTypedField(ctx, result, "claims_to_verify", terraformDataModel.ClaimsToVerify)
+	setTypedField(ctx, result, "cookie", terraformDataModel.Cookie)
+	setTypedField(ctx, result, "header", terraformDataModel.Header)
+	setTypedField(ctx, result, "hide_credentials", terraformDataModel.HideCredentials)
+	setTypedField(ctx, result, "key_claim_name", terraformDataModel.KeyClaimName)
+	setTypedField(ctx, result, "query", terraformDataModel.Query)
+	setTypedField(ctx, result, "realm", terraformDataModel.Realm)
+	setTypedField(ctx, result, "store_in_ctx", terraformDataModel.StoreInCtx)
+
+	return result
+}
+
+// PluginTypedJwtAuthFromApiToTerraform converts the APISIX JSON of the configuration of the `jwt-auth` plugin into its model.
+func PluginTypedJwtAuthFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedJwtAuthType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedJwtAuthType{
+		AnonymousConsumer: typedStringField(ctx, apiDataModel, "anonymous_consumer"),
+		ClaimsToVerify:    typedListField(ctx, apiDataModel, "claims_to_verify", types.StringType),
+		Cookie:            typedStringField(ctx, apiDataModel, "cookie"),
+		Header:            typedStringField(ctx, apiDataModel, "header"),
+		HideCredentials:   typedBoolField(ctx, apiDataModel, "hide_credentials"),
+		KeyClaimName:      typedStringField(ctx, apiDataModel, "key_claim_name"),
+		Query:             typedStringField(ctx, apiDataModel, "query"),
+		Realm:             typedStringField(ctx, apiDataModel, "realm"),
+		StoreInCtx:        typedBoolField(ctx, apiDataModel, "store_in_ctx"),
+	}
+
+	return &result
+}
+
+// PluginTypedJwtAuthConsumerType is the model of the consumer configuration of the `jwt-auth` plugin.
+type PluginTypedJwtAuthConsumerType struct {
+	Algorithm           types.String `tfsdk:"algorithm"`
+	Base64Secret        types.Bool   `tfsdk:"base64_secret"`
+	Exp                 types.Int64  `tfsdk:"exp"`
+	Key                 types.String `tfsdk:"key"`
+	LifetimeGracePeriod types.Int64  `tfsdk:"lifetime_grace_period"`
+	PublicKey           types.String `tfsdk:"public_key"`
+	Secret              types.String `tfsdk:"secret"`
+}
+
+// PluginTypedJwtAuthConsumerFromTerraformToApi converts the consumer configuration of the `jwt-auth` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedJwtAuthConsumerFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedJwtAuthConsumerType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "algorithm", terraformDataModel.Algorithm)
+	setTypedField(ctx, result, "base64_secret", terraformDataModel.Base64Secret)
+	setTypedField(ctx, result, "exp", terraformDataModel.Exp)
+	setTypedField(ctx, result, "key", terraformDataModel.Key)
+	setTypedField(ctx, result, "lifetime_grace_period", terraformDataModel.LifetimeGracePeriod)
+	setTypedField(ctx, result, "public_key", terraformDataModel.PublicKey)
+	setTypedField(ctx, result, "secret", terraformDataModel.Secret)
+
+	return result
+}
+
+// PluginTypedJwtAuthConsumerFromApiToTerraform converts the APISIX JSON of the consumer configuration of the `jwt-auth` plugin into its model.
+func PluginTypedJwtAuthConsumerFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedJwtAuthConsumerType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedJwtAuthConsumerType{
+		Algorithm:           typedStringField(ctx, apiDataModel, "algorithm"),
+		Base64Secret:        typedBoolField(ctx, apiDataModel, "base64_secret"),
+		Exp:                 typedInt64Field(ctx, apiDataModel, "exp"),
+		Key:                 typedStringField(ctx, apiDataModel, "key"),
+		LifetimeGracePeriod: typedInt64Field(ctx, apiDataModel, "lifetime_grace_period"),
+		PublicKey:           typedStringField(ctx, apiDataModel, "public_key"),
+		Secret:              typedStringField(ctx, apiDataModel, "secret"),
+	}
+
+	return &result
+}
@@ -0,0 +1,133 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedLimitConnSchemaAttribute is the typed configuration of the limit-conn plugin.
+var PluginTypedLimitConnSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `limit-conn` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"allow_degradation": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"burst": schema.Int64Attribute{
+			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+		"conn": schema.Int64Attribute{
+			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"default_conn_delay": schema.Float64Attribute{
+			Required: true,
+		},
+		"key": schema.StringAttribute{
+			Required: true,
+		},
+		"key_type": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `var`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("var"),
+			Validators: []validator.String{
+				stringvalidator.OneOf("var", "var_combination"),
+			},
+		},
+		"only_use_default_delay": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"rejected_code": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `503`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(503),
+			Validators: []validator.Int64{
+				int64validator.Between(200, 599),
+			},
+		},
+		"rejected_msg": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+	},
+}
+
+// PluginTypedLimitConnType is the model of the configuration of the `limit-conn` plugin.
+type PluginTypedLimitConnType struct {
+	AllowDegradation    types.Bool    `tfsdk:"allow_degradation"`
+	Burst               types.Int64   `tfsdk:"burst"`
+	Conn                types.Int64   `tfsdk:"conn"`
+	DefaultConnDelay    types.Float64 `tfsdk:"default_conn_delay"`
+	Key                 types.String  `tfsdk:"key"`
+	KeyType             types.String  `tfsdk:"key_type"`
+	OnlyUseDefaultDelay types.Bool    `tfsdk:"only_use_default_delay"`
+	RejectedCode        types.Int64   `tfsdk:"rejected_code"`
+	RejectedMsg         types.String  `tfsdk:"rejected_msg"`
+}
+
+// PluginTypedLimitConnFromTerraformToApi converts the configuration of the `limit-conn` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedLimitConnFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedLimitConnType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "allow_degradation", terraformDataModel.AllowDegradation)
+	setTypedField(ctx, result, "burst", terraformDataModel.Burst)
+	setTypedField(ctx, result, "conn", terraformDataModel.Conn)
+	setTypedField(ctx, result, "default_conn_delay", terraformDataModel.DefaultConnDelay)
+	setTypedField(ctx, result, "key", terraformDataModel.Key)
+	setTypedField(ctx, result, "key_type", terraformDataModel.KeyType)
+	setTypedField(ctx, result, "only_use_default_delay", terraformDataModel.OnlyUseDefaultDelay)
+	setTypedField(ctx, result, "rejected_code", terraformDataModel.RejectedCode)
+	setTypedField(ctx, result, "rejected_msg", terraformDataModel.RejectedMsg)
+
+	return result
+}
+
+// PluginTypedLimitConnFromApiToTerraform converts the APISIX JSON of the configuration of the `limit-conn` plugin into its model.
+func PluginTypedLimitConnFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedLimitConnType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedLimitConnType{
+		AllowDegradation:    typedBoolField(ctx, apiDataModel, "allow_degradation"),
+		Burst:               typedInt64Field(ctx, apiDataModel, "burst"),
+		Conn:                typedInt64Field(ctx, apiDataModel, "conn"),
+		DefaultConnDelay:    typedFloat64Field(ctx, apiDataModel, "default_conn_delay"),
+		Key:                 typedStringField(ctx, apiDataModel, "key"),
+		KeyType:             typedStringField(ctx, apiDataModel, "key_type"),
+		OnlyUseDefaultDelay: typedBoolField(ctx, apiDataModel, "only_use_default_delay"),
+		RejectedCode:        typedInt64Field(ctx, apiDataModel, "rejected_code"),
+		RejectedMsg:         typedStringField(ctx, apiDataModel, "rejected_msg"),
+	}
+
+	return &result
+}
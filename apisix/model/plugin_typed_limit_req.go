@@ -0,0 +1,231 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedLimitReqSchemaAttribute is the typed configuration of the limit-req plugin.
+var PluginTypedLimitReqSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `limit-req` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"allow_degradation": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"burst": schema.Float64Attribute{
+			Required: true,
+			Validators: []validator.Float64{
+				float64validator.AtLeast(0),
+			},
+		},
+		"key": schema.StringAttribute{
+			Required: true,
+		},
+		"key_type": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `var`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("var"),
+			Validators: []validator.String{
+				stringvalidator.OneOf("var", "var_combination"),
+			},
+		},
+		"nodelay": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"policy": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `local`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("local"),
+			Validators: []validator.String{
+				stringvalidator.OneOf("local", "redis", "redis-cluster"),
+			},
+		},
+		"rate": schema.Float64Attribute{
+			Required: true,
+		},
+		"redis_cluster_name": schema.StringAttribute{
+			Optional: true,
+		},
+		"redis_cluster_nodes": schema.ListAttribute{
+			Optional:    true,
+			ElementType: types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ValueStringsAre(stringvalidator.LengthBetween(2, 100)),
+			},
+		},
+		"redis_cluster_ssl": schema.BoolAttribute{
+			Optional: true,
+		},
+		"redis_cluster_ssl_verify": schema.BoolAttribute{
+			Optional: true,
+		},
+		"redis_database": schema.Int64Attribute{
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+		"redis_host": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(2),
+			},
+		},
+		"redis_password": schema.StringAttribute{
+			Optional:  true,
+			Sensitive: true,
+		},
+		"redis_port": schema.Int64Attribute{
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"redis_ssl": schema.BoolAttribute{
+			Optional: true,
+		},
+		"redis_ssl_verify": schema.BoolAttribute{
+			Optional: true,
+		},
+		"redis_timeout": schema.Int64Attribute{
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"redis_username": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"rejected_code": schema.Int64Attribute{
+			MarkdownDescription: "Defaults to `503`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(503),
+			Validators: []validator.Int64{
+				int64validator.Between(200, 599),
+			},
+		},
+		"rejected_msg": schema.StringAttribute{
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+	},
+}
+
+// PluginTypedLimitReqType is the model of the configuration of the `limit-req` plugin.
+type PluginTypedLimitReqType struct {
+	AllowDegradation      types.Bool    `tfsdk:"allow_degradation"`
+	Burst                 types.Float64 `tfsdk:"burst"`
+	Key                   types.String  `tfsdk:"key"`
+	KeyType               types.String  `tfsdk:"key_type"`
+	Nodelay               types.Bool    `tfsdk:"nodelay"`
+	Policy                types.String  `tfsdk:"policy"`
+	Rate                  types.Float64 `tfsdk:"rate"`
+	RedisClusterName      types.String  `tfsdk:"redis_cluster_name"`
+	RedisClusterNodes     types.List    `tfsdk:"redis_cluster_nodes"`
+	RedisClusterSsl       types.Bool    `tfsdk:"redis_cluster_ssl"`
+	RedisClusterSslVerify types.Bool    `tfsdk:"redis_cluster_ssl_verify"`
+	RedisDatabase         types.Int64   `tfsdk:"redis_database"`
+	RedisHost             types.String  `tfsdk:"redis_host"`
+	RedisPassword         types.String  `tfsdk:"redis_password"`
+	RedisPort             types.Int64   `tfsdk:"redis_port"`
+	RedisSsl              types.Bool    `tfsdk:"redis_ssl"`
+	RedisSslVerify        types.Bool    `tfsdk:"redis_ssl_verify"`
+	RedisTimeout          types.Int64   `tfsdk:"redis_timeout"`
+	RedisUsername         types.String  `tfsdk:"redis_username"`
+	RejectedCode          types.Int64   `tfsdk:"rejected_code"`
+	RejectedMsg           types.String  `tfsdk:"rejected_msg"`
+}
+
+// PluginTypedLimitReqFromTerraformToApi converts the configuration of the `limit-req` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedLimitReqFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedLimitReqType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "allow_degradation", terraformDataModel.AllowDegradation)
+	setTypedField(ctx, result, "burst", terraformDataModel.Burst)
+	setTypedField(ctx, result, "key", terraformDataModel.Key)
+	setTypedField(ctx, result, "key_type", terraformDataModel.KeyType)
+	setTypedField(ctx, result, "nodelay", terraformDataModel.Nodelay)
+	setTypedField(ctx, result, "policy", terraformDataModel.Policy)
+	setTypedField(ctx, result, "rate", terraformDataModel.Rate)
+	setTypedField(ctx, result, "redis_cluster_name", terraformDataModel.RedisClusterName)
+	setTypedField(ctx, result, "redis_cluster_nodes", terraformDataModel.RedisClusterNodes)
+	setTypedField(ctx, result, "redis_cluster_ssl", terraformDataModel.RedisClusterSsl)
+	setTypedField(ctx, result, "redis_cluster_ssl_verify", terraformDataModel.RedisClusterSslVerify)
+	setTypedField(ctx, result, "redis_database", terraformDataModel.RedisDatabase)
+	setTypedField(ctx, result, "redis_host", terraformDataModel.RedisHost)
+	setTypedField(ctx, result, "redis_password", terraformDataModel.RedisPassword)
+	setTypedField(ctx, result, "redis_port", terraformDataModel.RedisPort)
+	setTypedField(ctx, result, "redis_ssl", terraformDataModel.RedisSsl)
+	setTypedField(ctx, result, "redis_ssl_verify", terraformDataModel.RedisSslVerify)
+	setTypedField(ctx, result, "redis_timeout", terraformDataModel.RedisTimeout)
+	setTypedField(ctx, result, "redis_username", terraformDataModel.RedisUsername)
+	setTypedField(ctx, result, "rejected_code", terraformDataModel.RejectedCode)
+	setTypedField(ctx, result, "rejected_msg", terraformDataModel.RejectedMsg)
+
+	return result
+}
+
+// PluginTypedLimitReqFromApiToTerraform converts the APISIX JSON of the configuration of the `limit-req` plugin into its model.
+func PluginTypedLimitReqFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedLimitReqType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedLimitReqType{
+		AllowDegradation:      typedBoolField(ctx, apiDataModel, "allow_degradation"),
+		Burst:                 typedFloat64Field(ctx, apiDataModel, "burst"),
+		Key:                   typedStringField(ctx, apiDataModel, "key"),
+		KeyType:               typedStringField(ctx, apiDataModel, "key_type"),
+		Nodelay:               typedBoolField(ctx, apiDataModel, "nodelay"),
+		Policy:                typedStringField(ctx, apiDataModel, "policy"),
+		Rate:                  typedFloat64Field(ctx, apiDataModel, "rate"),
+		RedisClusterName:      typedStringField(ctx, apiDataModel, "redis_cluster_name"),
+		RedisClusterNodes:     typedListField(ctx, apiDataModel, "redis_cluster_nodes", types.StringType),
+		RedisClusterSsl:       typedBoolField(ctx, apiDataModel, "redis_cluster_ssl"),
+		RedisClusterSslVerify: typedBoolField(ctx, apiDataModel, "redis_cluster_ssl_verify"),
+		RedisDatabase:         typedInt64Field(ctx, apiDataModel, "redis_database"),
+		RedisHost:             typedStringField(ctx, apiDataModel, "redis_host"),
+		RedisPassword:         typedStringField(ctx, apiDataModel, "redis_password"),
+		RedisPort:             typedInt64Field(ctx, apiDataModel, "redis_port"),
+		RedisSsl:              typedBoolField(ctx, apiDataModel, "redis_ssl"),
+		RedisSslVerify:        typedBoolField(ctx, apiDataModel, "redis_ssl_verify"),
+		RedisTimeout:          typedInt64Field(ctx, apiDataModel, "redis_timeout"),
+		RedisUsername:         typedStringField(ctx, apiDataModel, "redis_username"),
+		RejectedCode:          typedInt64Field(ctx, apiDataModel, "rejected_code"),
+		RejectedMsg:           typedStringField(ctx, apiDataModel, "rejected_msg"),
+	}
+
+	return &result
+}
@@ -0,0 +1,173 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedProxyRewriteSchemaAttribute is the typed configuration of the proxy-rewrite plugin.
+var PluginTypedProxyRewriteSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `proxy-rewrite` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"headers": schema.SingleNestedAttribute{
+			MarkdownDescription: "New headers for request.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"add": schema.MapAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators: []validator.Map{
+						mapvalidator.SizeAtLeast(1),
+					},
+				},
+				"remove": schema.ListAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						listvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile("^[^:]+$"), "must match the pattern ^[^:]+$")),
+					},
+				},
+				"set": schema.MapAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators: []validator.Map{
+						mapvalidator.SizeAtLeast(1),
+					},
+				},
+			},
+		},
+		"host": schema.StringAttribute{
+			MarkdownDescription: "New host for upstream.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9a-zA-Z-.]+(:\d{1,5})?$`), `must match the pattern ^[0-9a-zA-Z-.]+(:\d{1,5})?$`),
+			},
+		},
+		"method": schema.StringAttribute{
+			MarkdownDescription: "Proxy route method.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("GET", "POST", "PUT", "HEAD", "DELETE", "OPTIONS", "MKCOL", "COPY", "MOVE", "PROPFIND", "LOCK", "UNLOCK", "PATCH", "TRACE"),
+			},
+		},
+		"regex_uri": schema.ListAttribute{
+			MarkdownDescription: "New uri that substitute from client uri for upstream, lower priority than uri property.",
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(2),
+			},
+		},
+		"uri": schema.StringAttribute{
+			MarkdownDescription: "New uri for upstream.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 4096),
+				stringvalidator.RegexMatches(regexp.MustCompile(`^\/.*`), `must match the pattern ^\/.*`),
+			},
+		},
+		"use_real_request_uri_unsafe": schema.BoolAttribute{
+			MarkdownDescription: "Use real_request_uri instead, THIS IS VERY UNSAFE. Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+	},
+}
+
+// PluginTypedProxyRewriteType is the model of the configuration of the `proxy-rewrite` plugin.
+type PluginTypedProxyRewriteType struct {
+	Headers                 *PluginTypedProxyRewriteHeadersType `tfsdk:"headers"`
+	Host                    types.String                        `tfsdk:"host"`
+	Method                  types.String                        `tfsdk:"method"`
+	RegexUri                types.List                          `tfsdk:"regex_uri"`
+	Uri                     types.String                        `tfsdk:"uri"`
+	UseRealRequestUriUnsafe types.Bool                          `tfsdk:"use_real_request_uri_unsafe"`
+}
+
+// PluginTypedProxyRewriteFromTerraformToApi converts the configuration of the `proxy-rewrite` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedProxyRewriteFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedProxyRewriteType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	if nested := PluginTypedProxyRewriteHeadersFromTerraformToApi(ctx, terraformDataModel.Headers); nested != nil {
+		result["headers"] = nested
+	}
+	setTypedField(ctx, result, "host", terraformDataModel.Host)
+	setTypedField(ctx, result, "method", terraformDataModel.Method)
+	setTypedField(ctx, result, "regex_uri", terraformDataModel.RegexUri)
+	setTypedField(ctx, result, "uri", terraformDataModel.Uri)
+	setTypedField(ctx, result, "use_real_request_uri_unsafe", terraformDataModel.UseRealRequestUriUnsafe)
+
+	return result
+}
+
+// PluginTypedProxyRewriteFromApiToTerraform converts the APISIX JSON of the configuration of the `proxy-rewrite` plugin into its model.
+func PluginTypedProxyRewriteFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedProxyRewriteType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedProxyRewriteType{
+		Headers:                 PluginTypedProxyRewriteHeadersFromApiToTerraform(ctx, typedObjectField(ctx, apiDataModel, "headers")),
+		Host:                    typedStringField(ctx, apiDataModel, "host"),
+		Method:                  typedStringField(ctx, apiDataModel, "method"),
+		RegexUri:                typedListField(ctx, apiDataModel, "regex_uri", types.StringType),
+		Uri:                     typedStringField(ctx, apiDataModel, "uri"),
+		UseRealRequestUriUnsafe: typedBoolField(ctx, apiDataModel, "use_real_request_uri_unsafe"),
+	}
+
+	return &result
+}
+
+// PluginTypedProxyRewriteHeadersType is the model of the `proxy-rewrite.headers` field.
+type PluginTypedProxyRewriteHeadersType struct {
+	Add    types.Map  `tfsdk:"add"`
+	Remove types.List `tfsdk:"remove"`
+	Set    types.Map  `tfsdk:"set"`
+}
+
+// PluginTypedProxyRewriteHeadersFromTerraformToApi converts the `proxy-rewrite.headers` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedProxyRewriteHeadersFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedProxyRewriteHeadersType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "add", terraformDataModel.Add)
+	setTypedField(ctx, result, "remove", terraformDataModel.Remove)
+	setTypedField(ctx, result, "set", terraformDataModel.Set)
+
+	return result
+}
+
+// PluginTypedProxyRewriteHeadersFromApiToTerraform converts the APISIX JSON of the `proxy-rewrite.headers` field into its model.
+func PluginTypedProxyRewriteHeadersFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedProxyRewriteHeadersType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedProxyRewriteHeadersType{
+		Add:    typedMapField(ctx, apiDataModel, "add", types.StringType),
+		Remove: typedListField(ctx, apiDataModel, "remove", types.StringType),
+		Set:    typedMapField(ctx, apiDataModel, "set", types.StringType),
+	}
+
+	return &result
+}
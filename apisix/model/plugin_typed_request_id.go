@@ -0,0 +1,144 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedRequestIdSchemaAttribute is the typed configuration of the request-id plugin.
+var PluginTypedRequestIdSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `request-id` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"algorithm": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `uuid`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("uuid"),
+			Validators: []validator.String{
+				stringvalidator.OneOf("uuid", "nanoid", "range_id"),
+			},
+		},
+		"header_name": schema.StringAttribute{
+			MarkdownDescription: "Defaults to `X-Request-Id`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("X-Request-Id"),
+		},
+		"include_in_response": schema.BoolAttribute{
+			MarkdownDescription: "Defaults to `true`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+		},
+		"range_id": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"char_set": schema.StringAttribute{
+					MarkdownDescription: "Defaults to `abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"),
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(6),
+					},
+				},
+				"length": schema.Int64Attribute{
+					MarkdownDescription: "Defaults to `16`.",
+					Optional:            true,
+					Computed:            true,
+					Default:             int64default.StaticInt64(16),
+					Validators: []validator.Int64{
+						int64validator.AtLeast(6),
+					},
+				},
+			},
+		},
+	},
+}
+
+// PluginTypedRequestIdType is the model of the configuration of the `request-id` plugin.
+type PluginTypedRequestIdType struct {
+	Algorithm         types.String                     `tfsdk:"algorithm"`
+	HeaderName        types.String                     `tfsdk:"header_name"`
+	IncludeInResponse types.Bool                       `tfsdk:"include_in_response"`
+	RangeId           *PluginTypedRequestIdRangeIdType `tfsdk:"range_id"`
+}
+
+// PluginTypedRequestIdFromTerraformToApi converts the configuration of the `request-id` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedRequestIdFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedRequestIdType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "algorithm", terraformDataModel.Algorithm)
+	setTypedField(ctx, result, "header_name", terraformDataModel.HeaderName)
+	setTypedField(ctx, result, "include_in_response", terraformDataModel.IncludeInResponse)
+	if nested := PluginTypedRequestIdRangeIdFromTerraformToApi(ctx, terraformDataModel.RangeId); nested != nil {
+		result["range_id"] = nested
+	}
+
+	return result
+}
+
+// PluginTypedRequestIdFromApiToTerraform converts the APISIX JSON of the configuration of the `request-id` plugin into its model.
+func PluginTypedRequestIdFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedRequestIdType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedRequestIdType{
+		Algorithm:         typedStringField(ctx, apiDataModel, "algorithm"),
+		HeaderName:        typedStringField(ctx, apiDataModel, "header_name"),
+		IncludeInResponse: typedBoolField(ctx, apiDataModel, "include_in_response"),
+		RangeId:           PluginTypedRequestIdRangeIdFromApiToTerraform(ctx, typedObjectField(ctx, apiDataModel, "range_id")),
+	}
+
+	return &result
+}
+
+// PluginTypedRequestIdRangeIdType is the model of the `request-id.range_id` field.
+type PluginTypedRequestIdRangeIdType struct {
+	CharSet types.String `tfsdk:"char_set"`
+	Length  types.Int64  `tfsdk:"length"`
+}
+
+// PluginTypedRequestIdRangeIdFromTerraformToApi converts the `request-id.range_id` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedRequestIdRangeIdFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedRequestIdRangeIdType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "char_set", terraformDataModel.CharSet)
+	setTypedField(ctx, result, "length", terraformDataModel.Length)
+
+	return result
+}
+
+// PluginTypedRequestIdRangeIdFromApiToTerraform converts the APISIX JSON of the `request-id.range_id` field into its model.
+func PluginTypedRequestIdRangeIdFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedRequestIdRangeIdType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedRequestIdRangeIdType{
+		CharSet: typedStringField(ctx, apiDataModel, "char_set"),
+		Length:  typedInt64Field(ctx, apiDataModel, "length"),
+	}
+
+	return &result
+}
@@ -0,0 +1,248 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PluginTypedResponseRewriteSchemaAttribute is the typed configuration of the response-rewrite plugin.
+var PluginTypedResponseRewriteSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The configuration of the `response-rewrite` plugin.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"body": schema.StringAttribute{
+			MarkdownDescription: "New body for response.",
+			Optional:            true,
+		},
+		"body_base64": schema.BoolAttribute{
+			MarkdownDescription: "Whether new body for response need base64 decode before return. Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"filters": schema.ListNestedAttribute{
+			MarkdownDescription: "A group of filters that modify response body by replacing one specified string by another.",
+			Optional:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"options": schema.StringAttribute{
+						MarkdownDescription: "Regex options. Defaults to `jo`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("jo"),
+					},
+					"regex": schema.StringAttribute{
+						MarkdownDescription: "Match pattern on response body.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"replace": schema.StringAttribute{
+						MarkdownDescription: "Regex substitution content.",
+						Required:            true,
+					},
+					"scope": schema.StringAttribute{
+						MarkdownDescription: "Regex substitution range. Defaults to `once`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("once"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("once", "global"),
+						},
+					},
+				},
+			},
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+		"headers": schema.SingleNestedAttribute{
+			MarkdownDescription: "New headers for response.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"add": schema.MapAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators: []validator.Map{
+						mapvalidator.SizeAtLeast(1),
+					},
+				},
+				"remove": schema.ListAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						listvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile("^[^:]+$"), "must match the pattern ^[^:]+$")),
+					},
+				},
+				"set": schema.MapAttribute{
+					Optional:    true,
+					ElementType: types.StringType,
+					Validators: []validator.Map{
+						mapvalidator.SizeAtLeast(1),
+					},
+				},
+			},
+		},
+		"status_code": schema.Int64Attribute{
+			MarkdownDescription: "New status code for response.",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(200, 598),
+			},
+		},
+		"vars": schema.StringAttribute{
+			MarkdownDescription: "As a JSON string, like `jsonencode(...)`.",
+			Optional:            true,
+		},
+	},
+}
+
+// PluginTypedResponseRewriteType is the model of the configuration of the `response-rewrite` plugin.
+type PluginTypedResponseRewriteType struct {
+	Body       types.String                            `tfsdk:"body"`
+	BodyBase64 types.Bool                              `tfsdk:"body_base64"`
+	Filters    []PluginTypedResponseRewriteFiltersType `tfsdk:"filters"`
+	Headers    *PluginTypedResponseRewriteHeadersType  `tfsdk:"headers"`
+	StatusCode types.Int64                             `tfsdk:"status_code"`
+	Vars       types.String                            `tfsdk:"vars"`
+}
+
+// PluginTypedResponseRewriteFromTerraformToApi converts the configuration of the `response-rewrite` plugin into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedResponseRewriteFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedResponseRewriteType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "body", terraformDataModel.Body)
+	setTypedField(ctx, result, "body_base64", terraformDataModel.BodyBase64)
+	if terraformDataModel.Filters != nil {
+		items := make([]interface{}, 0, len(terraformDataModel.Filters))
+		for i := range terraformDataModel.Filters {
+			items = append(items, PluginTypedResponseRewriteFiltersFromTerraformToApi(ctx, &terraformDataModel.Filters[i]))
+		}
+		result["filters"] = items
+	}
+	if nested := PluginTypedResponseRewriteHeadersFromTerraformToApi(ctx, terraformDataModel.Headers); nested != nil {
+		result["headers"] = nested
+	}
+	setTypedField(ctx, result, "status_code", terraformDataModel.StatusCode)
+	setTypedJSONField(ctx, result, "vars", terraformDataModel.Vars)
+
+	return result
+}
+
+// PluginTypedResponseRewriteFromApiToTerraform converts the APISIX JSON of the configuration of the `response-rewrite` plugin into its model.
+func PluginTypedResponseRewriteFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedResponseRewriteType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedResponseRewriteType{
+		Body:       typedStringField(ctx, apiDataModel, "body"),
+		BodyBase64: typedBoolField(ctx, apiDataModel, "body_base64"),
+		Headers:    PluginTypedResponseRewriteHeadersFromApiToTerraform(ctx, typedObjectField(ctx, apiDataModel, "headers")),
+		StatusCode: typedInt64Field(ctx, apiDataModel, "status_code"),
+		Vars:       typedJSONField(ctx, apiDataModel, "vars"),
+	}
+	if items := typedObjectsField(ctx, apiDataModel, "filters"); items != nil {
+		result.Filters = make([]PluginTypedResponseRewriteFiltersType, 0, len(items))
+		for _, item := range items {
+			result.Filters = append(result.Filters, *PluginTypedResponseRewriteFiltersFromApiToTerraform(ctx, item))
+		}
+	}
+
+	return &result
+}
+
+// PluginTypedResponseRewriteFiltersType is the model of the `response-rewrite.filters` field.
+type PluginTypedResponseRewriteFiltersType struct {
+	Options types.String `tfsdk:"options"`
+	Regex   types.String `tfsdk:"regex"`
+	Replace types.String `tfsdk:"replace"`
+	Scope   types.String `tfsdk:"scope"`
+}
+
+// PluginTypedResponseRewriteFiltersFromTerraformToApi converts the `response-rewrite.filters` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedResponseRewriteFiltersFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedResponseRewriteFiltersType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "options", terraformDataModel.Options)
+	setTypedField(ctx, result, "regex", terraformDataModel.Regex)
+	setTypedField(ctx, result, "replace", terraformDataModel.Replace)
+	setTypedField(ctx, result, "scope", terraformDataModel.Scope)
+
+	return result
+}
+
+// PluginTypedResponseRewriteFiltersFromApiToTerraform converts the APISIX JSON of the `response-rewrite.filters` field into its model.
+func PluginTypedResponseRewriteFiltersFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedResponseRewriteFiltersType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedResponseRewriteFiltersType{
+		Options: typedStringField(ctx, apiDataModel, "options"),
+		Regex:   typedStringField(ctx, apiDataModel, "regex"),
+		Replace: typedStringField(ctx, apiDataModel, "replace"),
+		Scope:   typedStringField(ctx, apiDataModel, "scope"),
+	}
+
+	return &result
+}
+
+// PluginTypedResponseRewriteHeadersType is the model of the `response-rewrite.headers` field.
+type PluginTypedResponseRewriteHeadersType struct {
+	Add    types.Map  `tfsdk:"add"`
+	Remove types.List `tfsdk:"remove"`
+	Set    types.Map  `tfsdk:"set"`
+}
+
+// PluginTypedResponseRewriteHeadersFromTerraformToApi converts the `response-rewrite.headers` field into its APISIX JSON,
+// leaving out the fields which aren't set.
+func PluginTypedResponseRewriteHeadersFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedResponseRewriteHeadersType) (apiDataModel map[string]interface{}) {
+	if terraformDataModel == nil {
+		return
+	}
+
+	result := map[string]interface{}{}
+	setTypedField(ctx, result, "add", terraformDataModel.Add)
+	setTypedField(ctx, result, "remove", terraformDataModel.Remove)
+	setTypedField(ctx, result, "set", terraformDataModel.Set)
+
+	return result
+}
+
+// PluginTypedResponseRewriteHeadersFromApiToTerraform converts the APISIX JSON of the `response-rewrite.headers` field into its model.
+func PluginTypedResponseRewriteHeadersFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedResponseRewriteHeadersType) {
+	if apiDataModel == nil {
+		return
+	}
+
+	result := PluginTypedResponseRewriteHeadersType{
+		Add:    typedMapField(ctx, apiDataModel, "add", types.StringType),
+		Remove: typedListField(ctx, apiDataModel, "remove", types.StringType),
+		Set:    typedMapField(ctx, apiDataModel, "set", types.StringType),
+	}
+
+	return &result
+}
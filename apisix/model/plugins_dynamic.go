@@ -0,0 +1,238 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/dynamicvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var PluginSchemaAttribute = schema.DynamicAttribute{
+	MarkdownDescription: "Plugins that are executed during the request/response cycle, as an object keyed by the plugin name, " +
+		"like `plugin = { limit-count = { count = 2, time_window = 60 } }`. Unlike the `plugins` JSON string, " +
+		"the plans show the changes of each plugin field. Conflicts with `plugins`.",
+	Optional: true,
+	Validators: []validator.Dynamic{
+		dynamicvalidator.ConflictsWith(path.MatchRoot("plugins")),
+		DynamicObjectValidator{},
+	},
+}
+
+// DynamicObjectValidator validates that a dynamic attribute holds an object
+// or a map, like the plugins keyed by their name.
+type DynamicObjectValidator struct{}
+
+func (v DynamicObjectValidator) Description(_ context.Context) string {
+	return "value must be an object"
+}
+
+func (v DynamicObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v DynamicObjectValidator) ValidateDynamic(_ context.Context, req validator.DynamicRequest, resp *validator.DynamicResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.IsUnderlyingValueUnknown() {
+		return
+	}
+
+	switch req.ConfigValue.UnderlyingValue().(type) {
+	case basetypes.ObjectValue, basetypes.MapValue:
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("The %s attribute must be an object, like { limit-count = { count = 2 } }, got %s.", req.Path, req.ConfigValue.UnderlyingValue().Type(context.Background())),
+	)
+}
+
+// PluginsValue returns the plugins configured either as a JSON string or as
+// a dynamic value, as a JSON string.
+func PluginsValue(ctx context.Context, plugins types.String, plugin types.Dynamic) types.String {
+	if plugin.IsNull() {
+		return plugins
+	}
+	if plugin.IsUnknown() || plugin.IsUnderlyingValueUnknown() {
+		return types.StringUnknown()
+	}
+
+	value, err := DynamicToJson(plugin)
+	if err != nil {
+		tflog.Error(ctx, "Failed to convert the plugins to JSON", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return types.StringNull()
+	}
+
+	pluginsBytes, err := json.Marshal(value)
+	if err != nil {
+		tflog.Error(ctx, "Failed to marshal the plugins to JSON", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(pluginsBytes))
+}
+
+// DynamicToJson converts a dynamic value into its JSON form, with the objects,
+// maps as JSON objects and the lists, sets and tuples as JSON arrays.
+func DynamicToJson(value types.Dynamic) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("the value is unknown")
+	}
+
+	return attrValueToJson(value.UnderlyingValue())
+}
+
+func attrValueToJson(value attr.Value) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("the value is unknown")
+	}
+
+	switch value := value.(type) {
+	case basetypes.DynamicValue:
+		return DynamicToJson(value)
+	case basetypes.StringValue:
+		return value.ValueString(), nil
+	case basetypes.BoolValue:
+		return value.ValueBool(), nil
+	case basetypes.NumberValue:
+		return bigFloatToJson(value.ValueBigFloat()), nil
+	case basetypes.Int64Value:
+		return value.ValueInt64(), nil
+	case basetypes.Float64Value:
+		return value.ValueFloat64(), nil
+	case basetypes.ObjectValue:
+		return attrValuesToJsonObject(value.Attributes())
+	case basetypes.MapValue:
+		return attrValuesToJsonObject(value.Elements())
+	case basetypes.ListValue:
+		return attrValuesToJsonArray(value.Elements())
+	case basetypes.SetValue:
+		return attrValuesToJsonArray(value.Elements())
+	case basetypes.TupleValue:
+		return attrValuesToJsonArray(value.Elements())
+	}
+
+	return nil, fmt.Errorf("unsupported value type %s", value.Type(context.Background()))
+}
+
+func attrValuesToJsonObject(values map[string]attr.Value) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		jsonValue, err := attrValueToJson(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		result[key] = jsonValue
+	}
+
+	return result, nil
+}
+
+func attrValuesToJsonArray(values []attr.Value) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(values))
+	for i, value := range values {
+		jsonValue, err := attrValueToJson(value)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		result = append(result, jsonValue)
+	}
+
+	return result, nil
+}
+
+// bigFloatToJson keeps the exact value of a Terraform number in JSON.
+func bigFloatToJson(value *big.Float) json.Number {
+	if value == nil {
+		return json.Number("0")
+	}
+
+	return json.Number(value.Text('g', -1))
+}
+
+// JsonToDynamic converts a JSON value into a dynamic value, with the JSON
+// objects as objects and the arrays as tuples, like the HCL expressions.
+func JsonToDynamic(value interface{}) (types.Dynamic, error) {
+	if value == nil {
+		return types.DynamicNull(), nil
+	}
+
+	attrValue, err := jsonToAttrValue(value)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+
+	return types.DynamicValue(attrValue), nil
+}
+
+func jsonToAttrValue(value interface{}) (attr.Value, error) {
+	switch value := value.(type) {
+	case nil:
+		return types.DynamicNull(), nil
+	case string:
+		return types.StringValue(value), nil
+	case bool:
+		return types.BoolValue(value), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(value)), nil
+	case json.Number:
+		number, _, err := big.ParseFloat(value.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(number), nil
+	case map[string]interface{}:
+		attributeTypes := make(map[string]attr.Type, len(value))
+		attributes := make(map[string]attr.Value, len(value))
+		for key, item := range value {
+			attribute, err := jsonToAttrValue(item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			attributeTypes[key] = attribute.Type(context.Background())
+			attributes[key] = attribute
+		}
+		object, diags := types.ObjectValue(attributeTypes, attributes)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diags.Errors()[0].Detail())
+		}
+		return object, nil
+	case []interface{}:
+		elementTypes := make([]attr.Type, 0, len(value))
+		elements := make([]attr.Value, 0, len(value))
+		for i, item := range value {
+			element, err := jsonToAttrValue(item)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			elementTypes = append(elementTypes, element.Type(context.Background()))
+			elements = append(elements, element)
+		}
+		tuple, diags := types.TupleValue(elementTypes, elements)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%s", diags.Errors()[0].Detail())
+		}
+		return tuple, nil
+	}
+
+	return nil, fmt.Errorf("unsupported JSON value %T", value)
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPluginsValue(t *testing.T) {
+	// plugin = { limit-count = { count = 2, time_window = 0.5, allow_degradation = true, ... } }
+	limitCount := types.ObjectValueMust(
+		map[string]attr.Type{
+			"count":             types.NumberType,
+			"time_window":       types.NumberType,
+			"allow_degradation": types.BoolType,
+			"key":               types.StringType,
+			"rules":             types.TupleType{ElemTypes: []attr.Type{types.StringType, types.NumberType}},
+			"policy":            types.StringType,
+		},
+		map[string]attr.Value{
+			"count":             types.NumberValue(big.NewFloat(2)),
+			"time_window":       types.NumberValue(big.NewFloat(0.5)),
+			"allow_degradation": types.BoolValue(true),
+			"key":               types.StringValue("remote_addr"),
+			"rules":             types.TupleValueMust([]attr.Type{types.StringType, types.NumberType}, []attr.Value{types.StringValue("a"), types.NumberValue(big.NewFloat(1e21))}),
+			"policy":            types.StringNull(),
+		},
+	)
+	cors := types.MapValueMust(types.StringType, map[string]attr.Value{"allow_origins": types.StringValue("*")})
+	plugin := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"limit-count": limitCount.Type(context.Background()), "cors": cors.Type(context.Background())},
+		map[string]attr.Value{"limit-count": limitCount, "cors": cors},
+	))
+
+	plugins := PluginsValue(context.Background(), types.StringNull(), plugin)
+	expected := `{"cors":{"allow_origins":"*"},"limit-count":{"allow_degradation":true,"count":2,"key":"remote_addr","policy":null,"rules":["a",1e+21],"time_window":0.5}}`
+	if plugins.ValueString() != expected {
+		t.Errorf("expected %s, got %s", expected, plugins)
+	}
+
+	// The plugins JSON string is used when the object isn't set
+	plugins = PluginsValue(context.Background(), types.StringValue(`{"cors":{}}`), types.DynamicNull())
+	if plugins.ValueString() != `{"cors":{}}` {
+		t.Errorf("expected the plugins JSON string, got %s", plugins)
+	}
+
+	// The unknown plugins are sent once known
+	plugins = PluginsValue(context.Background(), types.StringNull(), types.DynamicUnknown())
+	if !plugins.IsUnknown() {
+		t.Errorf("expected unknown plugins, got %s", plugins)
+	}
+}
+
+func TestDynamicObjectValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value         types.Dynamic
+		expectedError bool
+	}{
+		"object": {
+			value: types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{})),
+		},
+		"map": {
+			value: types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+		},
+		"null": {
+			value: types.DynamicNull(),
+		},
+		"string": {
+			value:         types.DynamicValue(types.StringValue(`{"cors":{}}`)),
+			expectedError: true,
+		},
+		"list": {
+			value:         types.DynamicValue(types.ListValueMust(types.StringType, []attr.Value{})),
+			expectedError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := validator.DynamicResponse{}
+			DynamicObjectValidator{}.ValidateDynamic(context.Background(), validator.DynamicRequest{
+				Path:        path.Root("plugin"),
+				ConfigValue: testCase.value,
+			}, &resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectedError {
+				t.Errorf("expected error %t, got %v", testCase.expectedError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package model
+
+//go:generate go run ../../tools/pluginschemagen -schemas ../plugin_schemas/3.15 -output .
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// PluginsTypedValidator validates that the typed plugins aren't configured in
+// the `plugins` or `plugin` attributes too.
+type PluginsTypedValidator struct{}
+
+func (v PluginsTypedValidator) Description(_ context.Context) string {
+	return "the typed plugins must not be configured in plugins or plugin"
+}
+
+func (v PluginsTypedValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v PluginsTypedValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var plugins types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plugins"), &plugins)...)
+	var plugin types.Dynamic
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("plugin"), &plugin)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configured := map[string]string{}
+	if !plugins.IsNull() && !plugins.IsUnknown() {
+		var pluginsJSON map[string]interface{}
+		if err := json.Unmarshal([]byte(plugins.ValueString()), &pluginsJSON); err == nil {
+			for name := range pluginsJSON {
+				configured[name] = "plugins"
+			}
+		}
+	}
+	if !plugin.IsNull() && !plugin.IsUnknown() && !plugin.IsUnderlyingValueUnknown() {
+		if pluginJSON, ok := dynamicObjectJson(plugin); ok {
+			for name := range pluginJSON {
+				configured[name] = "plugin"
+			}
+		}
+	}
+
+	for _, attributeName := range sortedAttributeNames(req.ConfigValue.Attributes()) {
+		if req.ConfigValue.Attributes()[attributeName].IsNull() {
+			continue
+		}
+
+		name := pluginsTypedNames[attributeName]
+		if configuredIn, ok := configured[name]; ok {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtName(attributeName),
+				"Duplicate Plugin Configuration",
+				fmt.Sprintf("The %s plugin is configured both in %s and in %s, it must only be configured in one of them.", name, req.Path, configuredIn),
+			)
+		}
+	}
+}
+
+func dynamicObjectJson(value types.Dynamic) (map[string]interface{}, bool) {
+	converted, err := DynamicToJson(value)
+	if err != nil {
+		return nil, false
+	}
+	object, ok := converted.(map[string]interface{})
+
+	return object, ok
+}
+
+func sortedAttributeNames(attributes map[string]attr.Value) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// PluginsTypedValue returns the plugins JSON string with the typed plugins
+// added, so that the typed plugins are merged like the other plugins.
+func PluginsTypedValue(ctx context.Context, plugins types.String, pluginsTyped types.Object) types.String {
+	if pluginsTyped.IsNull() || pluginsTyped.IsUnknown() || plugins.IsUnknown() {
+		return plugins
+	}
+
+	var typed map[string]interface{}
+	if pluginsTyped.Type(ctx).Equal(ConsumerPluginsTypedSchemaAttribute.GetType()) {
+		typed = ConsumerPluginsTypedFromTerraformToApi(ctx, pluginsTyped)
+	} else {
+		typed = PluginsTypedFromTerraformToApi(ctx, pluginsTyped)
+	}
+	if len(typed) == 0 {
+		return plugins
+	}
+
+	merged := map[string]interface{}{}
+	if !plugins.IsNull() {
+		decoder := json.NewDecoder(strings.NewReader(plugins.ValueString()))
+		decoder.UseNumber()
+		if err := decoder.Decode(&merged); err != nil {
+			tflog.Error(ctx, "Failed to unmarshal the plugins JSON", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return plugins
+		}
+	}
+	for name, config := range typed {
+		merged[name] = config
+	}
+
+	pluginsBytes, err := json.Marshal(merged)
+	if err != nil {
+		tflog.Error(ctx, "Failed to marshal the plugins to JSON", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return plugins
+	}
+
+	return types.StringValue(string(pluginsBytes))
+}
+
+// pluginTypedAs reads the typed plugin of the attribute into its model,
+// reporting whether it's configured. The plugins holding unknown values are
+// left out until they are known.
+func pluginTypedAs(ctx context.Context, pluginsTyped types.Object, attributeName string, target interface{}) bool {
+	value, ok := pluginsTyped.Attributes()[attributeName].(types.Object)
+	if !ok || value.IsNull() {
+		return false
+	}
+
+	terraformValue, err := value.ToTerraformValue(ctx)
+	if err == nil && !terraformValue.IsFullyKnown() {
+		err = fmt.Errorf("the value is unknown")
+	}
+	if err == nil {
+		if diags := value.As(ctx, target, basetypes.ObjectAsOptions{}); diags.HasError() {
+			err = fmt.Errorf("%s", diags.Errors()[0].Detail())
+		}
+	}
+	if err != nil {
+		tflog.Error(ctx, "Failed to convert the typed plugin", map[string]interface{}{
+			"plugin": pluginsTypedNames[attributeName],
+			"error":  err.Error(),
+		})
+		return false
+	}
+
+	return true
+}
+
+// pluginsTypedObject returns the typed plugins of the model.
+func pluginsTypedObject(ctx context.Context, attributeTypes map[string]attr.Type, terraformDataModel interface{}) types.Object {
+	pluginsTyped, diags := types.ObjectValueFrom(ctx, attributeTypes, terraformDataModel)
+	if diags.HasError() {
+		tflog.Error(ctx, "Failed to convert the plugins into the typed plugins", map[string]interface{}{
+			"error": diags.Errors()[0].Detail(),
+		})
+		return types.ObjectNull(attributeTypes)
+	}
+
+	return pluginsTyped
+}
+
+// setTypedField sets the field of the APISIX JSON of a typed plugin to the
+// value of its attribute, unless it's null.
+func setTypedField(ctx context.Context, object map[string]interface{}, name string, value attr.Value) {
+	if value.IsNull() {
+		return
+	}
+
+	converted, err := attrValueToJson(value)
+	if err != nil {
+		logTypedFieldError(ctx, name, err)
+		return
+	}
+	object[name] = converted
+}
+
+// setTypedJSONField sets the field of the APISIX JSON of a typed plugin to the
+// JSON string of its attribute, unless it's null.
+func setTypedJSONField(ctx context.Context, object map[string]interface{}, name string, value types.String) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(value.ValueString()))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		logTypedFieldError(ctx, name, err)
+		return
+	}
+	object[name] = decoded
+}
+
+func typedStringField(ctx context.Context, object map[string]interface{}, name string) types.String {
+	if value, ok := typedScalarField(ctx, object, name, types.StringType); ok {
+		return value.(types.String)
+	}
+
+	return types.StringNull()
+}
+
+func typedInt64Field(ctx context.Context, object map[string]interface{}, name string) types.Int64 {
+	if value, ok := typedScalarField(ctx, object, name, types.Int64Type); ok {
+		return value.(types.Int64)
+	}
+
+	return types.Int64Null()
+}
+
+func typedFloat64Field(ctx context.Context, object map[string]interface{}, name string) types.Float64 {
+	if value, ok := typedScalarField(ctx, object, name, types.Float64Type); ok {
+		return value.(types.Float64)
+	}
+
+	return types.Float64Null()
+}
+
+func typedBoolField(ctx context.Context, object map[string]interface{}, name string) types.Bool {
+	if value, ok := typedScalarField(ctx, object, name, types.BoolType); ok {
+		return value.(types.Bool)
+	}
+
+	return types.BoolNull()
+}
+
+func typedScalarField(ctx context.Context, object map[string]interface{}, name string, valueType attr.Type) (attr.Value, bool) {
+	if object[name] == nil {
+		return nil, false
+	}
+
+	value, ok := typedScalarValue(valueType, object[name])
+	if !ok {
+		logTypedFieldError(ctx, name, fmt.Errorf("unexpected value %v for %s", object[name], valueType))
+	}
+
+	return value, ok
+}
+
+func typedListField(ctx context.Context, object map[string]interface{}, name string, elementType attr.Type) types.List {
+	if object[name] == nil {
+		return types.ListNull(elementType)
+	}
+
+	list, ok := object[name].([]interface{})
+	if !ok {
+		logTypedFieldError(ctx, name, fmt.Errorf("expected an array, got %T", object[name]))
+		return types.ListNull(elementType)
+	}
+	elements := make([]attr.Value, 0, len(list))
+	for _, element := range list {
+		value, ok := typedScalarValue(elementType, element)
+		if !ok {
+			logTypedFieldError(ctx, name, fmt.Errorf("unexpected value %v for %s", element, elementType))
+			return types.ListNull(elementType)
+		}
+		elements = append(elements, value)
+	}
+
+	return types.ListValueMust(elementType, elements)
+}
+
+func typedMapField(ctx context.Context, object map[string]interface{}, name string, elementType attr.Type) types.Map {
+	if object[name] == nil {
+		return types.MapNull(elementType)
+	}
+
+	entries, ok := object[name].(map[string]interface{})
+	if !ok {
+		logTypedFieldError(ctx, name, fmt.Errorf("expected an object, got %T", object[name]))
+		return types.MapNull(elementType)
+	}
+	elements := make(map[string]attr.Value, len(entries))
+	for key, element := range entries {
+		value, ok := typedScalarValue(elementType, element)
+		if !ok {
+			logTypedFieldError(ctx, name+"."+key, fmt.Errorf("unexpected value %v for %s", element, elementType))
+			return types.MapNull(elementType)
+		}
+		elements[key] = value
+	}
+
+	return types.MapValueMust(elementType, elements)
+}
+
+// typedJSONField returns the field of the APISIX JSON of a typed plugin as a
+// JSON string, for the fields the typed attributes can't express.
+func typedJSONField(ctx context.Context, object map[string]interface{}, name string) types.String {
+	if object[name] == nil {
+		return types.StringNull()
+	}
+
+	valueBytes, err := json.Marshal(object[name])
+	if err != nil {
+		logTypedFieldError(ctx, name, err)
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(valueBytes))
+}
+
+// typedObjectField returns the object of the field, nil when it isn't set.
+func typedObjectField(ctx context.Context, object map[string]interface{}, name string) map[string]interface{} {
+	if object[name] == nil {
+		return nil
+	}
+
+	nested, ok := object[name].(map[string]interface{})
+	if !ok {
+		logTypedFieldError(ctx, name, fmt.Errorf("expected an object, got %T", object[name]))
+	}
+
+	return nested
+}
+
+// typedObjectsField returns the objects of the array field, nil when it isn't
+// set and empty when the array is.
+func typedObjectsField(ctx context.Context, object map[string]interface{}, name string) []map[string]interface{} {
+	if object[name] == nil {
+		return nil
+	}
+
+	list, ok := object[name].([]interface{})
+	if !ok {
+		logTypedFieldError(ctx, name, fmt.Errorf("expected an array, got %T", object[name]))
+		return nil
+	}
+	objects := make([]map[string]interface{}, 0, len(list))
+	for _, element := range list {
+		nested, ok := element.(map[string]interface{})
+		if !ok {
+			logTypedFieldError(ctx, name, fmt.Errorf("expected an array of objects, got %T", element))
+			return nil
+		}
+		objects = append(objects, nested)
+	}
+
+	return objects
+}
+
+// typedScalarValue converts a JSON value into a value of the scalar type,
+// reporting whether it's of that type.
+func typedScalarValue(valueType attr.Type, value interface{}) (attr.Value, bool) {
+	switch valueType {
+	case types.StringType:
+		switch value := value.(type) {
+		case string:
+			return types.StringValue(value), true
+		case json.Number, float64:
+			return types.StringValue(fmt.Sprint(value)), true
+		}
+	case types.Int64Type:
+		switch value := value.(type) {
+		case json.Number:
+			if integer, err := value.Int64(); err == nil {
+				return types.Int64Value(integer), true
+			}
+		case float64:
+			return types.Int64Value(int64(value)), true
+		}
+	case types.Float64Type:
+		switch value := value.(type) {
+		case json.Number:
+			if float, err := value.Float64(); err == nil {
+				return types.Float64Value(float), true
+			}
+		case float64:
+			return types.Float64Value(value), true
+		}
+	case types.BoolType:
+		if value, ok := value.(bool); ok {
+			return types.BoolValue(value), true
+		}
+	}
+
+	return nil, false
+}
+
+func logTypedFieldError(ctx context.Context, name string, err error) {
+	tflog.Error(ctx, "Failed to convert the field of the typed plugin", map[string]interface{}{
+		"field": name,
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,468 @@
+// Code generated by tools/pluginschemagen from the APISIX 3.15 plugin schemas. DO NOT EDIT.
+
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// pluginsTypedNames maps the attributes of the typed plugins to the plugin names.
+var pluginsTypedNames = map[string]string{
+	"api_breaker":             "api-breaker",
+	"basic_auth":              "basic-auth",
+	"consumer_restriction":    "consumer-restriction",
+	"cors":                    "cors",
+	"fault_injection":         "fault-injection",
+	"gzip":                    "gzip",
+	"hmac_auth":               "hmac-auth",
+	"http_logger":             "http-logger",
+	"ip_restriction":          "ip-restriction",
+	"jwt_auth":                "jwt-auth",
+	"key_auth":                "key-auth",
+	"limit_conn":              "limit-conn",
+	"limit_count":             "limit-count",
+	"limit_req":               "limit-req",
+	"prometheus":              "prometheus",
+	"proxy_mirror":            "proxy-mirror",
+	"proxy_rewrite":           "proxy-rewrite",
+	"real_ip":                 "real-ip",
+	"redirect":                "redirect",
+	"referer_restriction":     "referer-restriction",
+	"request_id":              "request-id",
+	"response_rewrite":        "response-rewrite",
+	"serverless_pre_function": "serverless-pre-function",
+	"ua_restriction":          "ua-restriction",
+	"uri_blocker":             "uri-blocker",
+}
+
+// PluginsTypedSchemaAttribute is the attribute of the typed plugins.
+var PluginsTypedSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The plugins with a typed configuration, generated from the APISIX 3.15 plugin schemas, so that the editors complete and validate their fields. The attributes are named after the plugins, with underscores, like `limit_count` for `limit-count`. They are merged into the plugins of `plugins` or `plugin`, which configure the other plugins, and a plugin can't be configured in both.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"api_breaker":             PluginTypedApiBreakerSchemaAttribute,
+		"basic_auth":              PluginTypedBasicAuthSchemaAttribute,
+		"consumer_restriction":    PluginTypedConsumerRestrictionSchemaAttribute,
+		"cors":                    PluginTypedCorsSchemaAttribute,
+		"fault_injection":         PluginTypedFaultInjectionSchemaAttribute,
+		"gzip":                    PluginTypedGzipSchemaAttribute,
+		"hmac_auth":               PluginTypedHmacAuthSchemaAttribute,
+		"http_logger":             PluginTypedHttpLoggerSchemaAttribute,
+		"ip_restriction":          PluginTypedIpRestrictionSchemaAttribute,
+		"jwt_auth":                PluginTypedJwtAuthSchemaAttribute,
+		"key_auth":                PluginTypedKeyAuthSchemaAttribute,
+		"limit_conn":              PluginTypedLimitConnSchemaAttribute,
+		"limit_count":             PluginTypedLimitCountSchemaAttribute,
+		"limit_req":               PluginTypedLimitReqSchemaAttribute,
+		"prometheus":              PluginTypedPrometheusSchemaAttribute,
+		"proxy_mirror":            PluginTypedProxyMirrorSchemaAttribute,
+		"proxy_rewrite":           PluginTypedProxyRewriteSchemaAttribute,
+		"real_ip":                 PluginTypedRealIpSchemaAttribute,
+		"redirect":                PluginTypedRedirectSchemaAttribute,
+		"referer_restriction":     PluginTypedRefererRestrictionSchemaAttribute,
+		"request_id":              PluginTypedRequestIdSchemaAttribute,
+		"response_rewrite":        PluginTypedResponseRewriteSchemaAttribute,
+		"serverless_pre_function": PluginTypedServerlessPreFunctionSchemaAttribute,
+		"ua_restriction":          PluginTypedUaRestrictionSchemaAttribute,
+		"uri_blocker":             PluginTypedUriBlockerSchemaAttribute,
+	},
+	Validators: []validator.Object{
+		PluginsTypedValidator{},
+	},
+}
+
+// ConsumerPluginsTypedSchemaAttribute is the attribute of the typed plugins.
+var ConsumerPluginsTypedSchemaAttribute = schema.SingleNestedAttribute{
+	MarkdownDescription: "The plugins with a typed configuration, generated from the APISIX 3.15 plugin schemas, so that the editors complete and validate their fields. The attributes are named after the plugins, with underscores, like `limit_count` for `limit-count`. They are merged into the plugins of `plugins` or `plugin`, which configure the other plugins, and a plugin can't be configured in both. The authentication plugins have their consumer configuration, like the `key` of `key-auth`.",
+	Optional:            true,
+	Attributes: map[string]schema.Attribute{
+		"api_breaker":             PluginTypedApiBreakerSchemaAttribute,
+		"basic_auth":              PluginTypedBasicAuthConsumerSchemaAttribute,
+		"consumer_restriction":    PluginTypedConsumerRestrictionSchemaAttribute,
+		"cors":                    PluginTypedCorsSchemaAttribute,
+		"fault_injection":         PluginTypedFaultInjectionSchemaAttribute,
+		"gzip":                    PluginTypedGzipSchemaAttribute,
+		"hmac_auth":               PluginTypedHmacAuthConsumerSchemaAttribute,
+		"http_logger":             PluginTypedHttpLoggerSchemaAttribute,
+		"ip_restriction":          PluginTypedIpRestrictionSchemaAttribute,
+		"jwt_auth":                PluginTypedJwtAuthConsumerSchemaAttribute,
+		"key_auth":                PluginTypedKeyAuthConsumerSchemaAttribute,
+		"limit_conn":              PluginTypedLimitConnSchemaAttribute,
+		"limit_count":             PluginTypedLimitCountSchemaAttribute,
+		"limit_req":               PluginTypedLimitReqSchemaAttribute,
+		"prometheus":              PluginTypedPrometheusSchemaAttribute,
+		"proxy_mirror":            PluginTypedProxyMirrorSchemaAttribute,
+		"proxy_rewrite":           PluginTypedProxyRewriteSchemaAttribute,
+		"real_ip":                 PluginTypedRealIpSchemaAttribute,
+		"redirect":                PluginTypedRedirectSchemaAttribute,
+		"referer_restriction":     PluginTypedRefererRestrictionSchemaAttribute,
+		"request_id":              PluginTypedRequestIdSchemaAttribute,
+		"response_rewrite":        PluginTypedResponseRewriteSchemaAttribute,
+		"serverless_pre_function": PluginTypedServerlessPreFunctionSchemaAttribute,
+		"ua_restriction":          PluginTypedUaRestrictionSchemaAttribute,
+		"uri_blocker":             PluginTypedUriBlockerSchemaAttribute,
+	},
+	Validators: []validator.Object{
+		PluginsTypedValidator{},
+	},
+}
+
+// PluginsTypedType is the model of the typed plugins of the routes and services.
+type PluginsTypedType struct {
+	ApiBreaker            *PluginTypedApiBreakerType            `tfsdk:"api_breaker"`
+	BasicAuth             *PluginTypedBasicAuthType             `tfsdk:"basic_auth"`
+	ConsumerRestriction   *PluginTypedConsumerRestrictionType   `tfsdk:"consumer_restriction"`
+	Cors                  *PluginTypedCorsType                  `tfsdk:"cors"`
+	FaultInjection        *PluginTypedFaultInjectionType        `tfsdk:"fault_injection"`
+	Gzip                  *PluginTypedGzipType                  `tfsdk:"gzip"`
+	HmacAuth              *PluginTypedHmacAuthType              `tfsdk:"hmac_auth"`
+	HttpLogger            *PluginTypedHttpLoggerType            `tfsdk:"http_logger"`
+	IpRestriction         *PluginTypedIpRestrictionType         `tfsdk:"ip_restriction"`
+	JwtAuth               *PluginTypedJwtAuthType               `tfsdk:"jwt_auth"`
+	KeyAuth               *PluginTypedKeyAuthType               `tfsdk:"key_auth"`
+	LimitConn             *PluginTypedLimitConnType             `tfsdk:"limit_conn"`
+	LimitCount            *PluginTypedLimitCountType            `tfsdk:"limit_count"`
+	LimitReq              *PluginTypedLimitReqType              `tfsdk:"limit_req"`
+	Prometheus            *PluginTypedPrometheusType            `tfsdk:"prometheus"`
+	ProxyMirror           *PluginTypedProxyMirrorType           `tfsdk:"proxy_mirror"`
+	ProxyRewrite          *PluginTypedProxyRewriteType          `tfsdk:"proxy_rewrite"`
+	RealIp                *PluginTypedRealIpType                `tfsdk:"real_ip"`
+	Redirect              *PluginTypedRedirectType              `tfsdk:"redirect"`
+	RefererRestriction    *PluginTypedRefererRestrictionType    `tfsdk:"referer_restriction"`
+	RequestId             *PluginTypedRequestIdType             `tfsdk:"request_id"`
+	ResponseRewrite       *PluginTypedResponseRewriteType       `tfsdk:"response_rewrite"`
+	ServerlessPreFunction *PluginTypedServerlessPreFunctionType `tfsdk:"serverless_pre_function"`
+	UaRestriction         *PluginTypedUaRestrictionType         `tfsdk:"ua_restriction"`
+	UriBlocker            *PluginTypedUriBlockerType            `tfsdk:"uri_blocker"`
+}
+
+// PluginsTypedFromTerraformToApi converts the typed plugins of the routes and services into the plugins
+// configuration of APISIX, leaving out the plugins holding unknown values.
+func PluginsTypedFromTerraformToApi(ctx context.Context, pluginsTyped types.Object) map[string]interface{} {
+	if pluginsTyped.IsNull() || pluginsTyped.IsUnknown() {
+		return nil
+	}
+
+	plugins := map[string]interface{}{}
+	var apiBreaker PluginTypedApiBreakerType
+	if pluginTypedAs(ctx, pluginsTyped, "api_breaker", &apiBreaker) {
+		plugins["api-breaker"] = PluginTypedApiBreakerFromTerraformToApi(ctx, &apiBreaker)
+	}
+	var basicAuth PluginTypedBasicAuthType
+	if pluginTypedAs(ctx, pluginsTyped, "basic_auth", &basicAuth) {
+		plugins["basic-auth"] = PluginTypedBasicAuthFromTerraformToApi(ctx, &basicAuth)
+	}
+	var consumerRestriction PluginTypedConsumerRestrictionType
+	if pluginTypedAs(ctx, pluginsTyped, "consumer_restriction", &consumerRestriction) {
+		plugins["consumer-restriction"] = PluginTypedConsumerRestrictionFromTerraformToApi(ctx, &consumerRestriction)
+	}
+	var cors PluginTypedCorsType
+	if pluginTypedAs(ctx, pluginsTyped, "cors", &cors) {
+		plugins["cors"] = PluginTypedCorsFromTerraformToApi(ctx, &cors)
+	}
+	var faultInjection PluginTypedFaultInjectionType
+	if pluginTypedAs(ctx, pluginsTyped, "fault_injection", &faultInjection) {
+		plugins["fault-injection"] = PluginTypedFaultInjectionFromTerraformToApi(ctx, &faultInjection)
+	}
+	var gzip PluginTypedGzipType
+	if pluginTypedAs(ctx, pluginsTyped, "gzip", &gzip) {
+		plugins["gzip"] = PluginTypedGzipFromTerraformToApi(ctx, &gzip)
+	}
+	var hmacAuth PluginTypedHmacAuthType
+	if pluginTypedAs(ctx, pluginsTyped, "hmac_auth", &hmacAuth) {
+		plugins["hmac-auth"] = PluginTypedHmacAuthFromTerraformToApi(ctx, &hmacAuth)
+	}
+	var httpLogger PluginTypedHttpLoggerType
+	if pluginTypedAs(ctx, pluginsTyped, "http_logger", &httpLogger) {
+		plugins["http-logger"] = PluginTypedHttpLoggerFromTerraformToApi(ctx, &httpLogger)
+	}
+	var ipRestriction PluginTypedIpRestrictionType
+	if pluginTypedAs(ctx, pluginsTyped, "ip_restriction", &ipRestriction) {
+		plugins["ip-restriction"] = PluginTypedIpRestrictionFromTerraformToApi(ctx, &ipRestriction)
+	}
+	var jwtAuth PluginTypedJwtAuthType
+	if pluginTypedAs(ctx, pluginsTyped, "jwt_auth", &jwtAuth) {
+		plugins["jwt-auth"] = PluginTypedJwtAuthFromTerraformToApi(ctx, &jwtAuth)
+	}
+	var keyAuth PluginTypedKeyAuthType
+	if pluginTypedAs(ctx, pluginsTyped, "key_auth", &keyAuth) {
+		plugins["key-auth"] = PluginTypedKeyAuthFromTerraformToApi(ctx, &keyAuth)
+	}
+	var limitConn PluginTypedLimitConnType
+	if pluginTypedAs(ctx, pluginsTyped, "limit_conn", &limitConn) {
+		plugins["limit-conn"] = PluginTypedLimitConnFromTerraformToApi(ctx, &limitConn)
+	}
+	var limitCount PluginTypedLimitCountType
+	if pluginTypedAs(ctx, pluginsTyped, "limit_count", &limitCount) {
+		plugins["limit-count"] = PluginTypedLimitCountFromTerraformToApi(ctx, &limitCount)
+	}
+	var limitReq PluginTypedLimitReqType
+	if pluginTypedAs(ctx, pluginsTyped, "limit_req", &limitReq) {
+		plugins["limit-req"] = PluginTypedLimitReqFromTerraformToApi(ctx, &limitReq)
+	}
+	var prometheus PluginTypedPrometheusType
+	if pluginTypedAs(ctx, pluginsTyped, "prometheus", &prometheus) {
+		plugins["prometheus"] = PluginTypedPrometheusFromTerraformToApi(ctx, &prometheus)
+	}
+	var proxyMirror PluginTypedProxyMirrorType
+	if pluginTypedAs(ctx, pluginsTyped, "proxy_mirror", &proxyMirror) {
+		plugins["proxy-mirror"] = PluginTypedProxyMirrorFromTerraformToApi(ctx, &proxyMirror)
+	}
+	var proxyRewrite PluginTypedProxyRewriteType
+	if pluginTypedAs(ctx, pluginsTyped, "proxy_rewrite", &proxyRewrite) {
+		plugins["proxy-rewrite"] = PluginTypedProxyRewriteFromTerraformToApi(ctx, &proxyRewrite)
+	}
+	var realIp PluginTypedRealIpType
+	if pluginTypedAs(ctx, pluginsTyped, "real_ip", &realIp) {
+		plugins["real-ip"] = PluginTypedRealIpFromTerraformToApi(ctx, &realIp)
+	}
+	var redirect PluginTypedRedirectType
+	if pluginTypedAs(ctx, pluginsTyped, "redirect", &redirect) {
+		plugins["redirect"] = PluginTypedRedirectFromTerraformToApi(ctx, &redirect)
+	}
+	var refererRestriction PluginTypedRefererRestrictionType
+	if pluginTypedAs(ctx, pluginsTyped, "referer_restriction", &refererRestriction) {
+		plugins["referer-restriction"] = PluginTypedRefererRestrictionFromTerraformToApi(ctx, &refererRestriction)
+	}
+	var requestId PluginTypedRequestIdType
+	if pluginTypedAs(ctx, pluginsTyped, "request_id", &requestId) {
+		plugins["request-id"] = PluginTypedRequestIdFromTerraformToApi(ctx, &requestId)
+	}
+	var responseRewrite PluginTypedResponseRewriteType
+	if pluginTypedAs(ctx, pluginsTyped, "response_rewrite", &responseRewrite) {
+		plugins["response-rewrite"] = PluginTypedResponseRewriteFromTerraformToApi(ctx, &responseRewrite)
+	}
+	var serverlessPreFunction PluginTypedServerlessPreFunctionType
+	if pluginTypedAs(ctx, pluginsTyped, "serverless_pre_function", &serverlessPreFunction) {
+		plugins["serverless-pre-function"] = PluginTypedServerlessPreFunctionFromTerraformToApi(ctx, &serverlessPreFunction)
+	}
+	var uaRestriction PluginTypedUaRestrictionType
+	if pluginTypedAs(ctx, pluginsTyped, "ua_restriction", &uaRestriction) {
+		plugins["ua-restriction"] = PluginTypedUaRestrictionFromTerraformToApi(ctx, &uaRestriction)
+	}
+	var uriBlocker PluginTypedUriBlockerType
+	if pluginTypedAs(ctx, pluginsTyped, "uri_blocker", &uriBlocker) {
+		plugins["uri-blocker"] = PluginTypedUriBlockerFromTerraformToApi(ctx, &uriBlocker)
+	}
+
+	return plugins
+}
+
+// PluginsTypedFromApiToTerraform converts the plugins configuration of APISIX
+// into the typed plugins of the routes and services, leaving out the plugins which aren't typed.
+func PluginsTypedFromApiToTerraform(ctx context.Context, plugins map[string]interface{}) types.Object {
+	attributeTypes := PluginsTypedSchemaAttribute.GetType().(types.ObjectType).AttrTypes
+	if plugins == nil {
+		return types.ObjectNull(attributeTypes)
+	}
+
+	return pluginsTypedObject(ctx, attributeTypes, PluginsTypedType{
+		ApiBreaker:            PluginTypedApiBreakerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "api-breaker")),
+		BasicAuth:             PluginTypedBasicAuthFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "basic-auth")),
+		ConsumerRestriction:   PluginTypedConsumerRestrictionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "consumer-restriction")),
+		Cors:                  PluginTypedCorsFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "cors")),
+		FaultInjection:        PluginTypedFaultInjectionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "fault-injection")),
+		Gzip:                  PluginTypedGzipFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "gzip")),
+		HmacAuth:              PluginTypedHmacAuthFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "hmac-auth")),
+		HttpLogger:            PluginTypedHttpLoggerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "http-logger")),
+		IpRestriction:         PluginTypedIpRestrictionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "ip-restriction")),
+		JwtAuth:               PluginTypedJwtAuthFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "jwt-auth")),
+		KeyAuth:               PluginTypedKeyAuthFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "key-auth")),
+		LimitConn:             PluginTypedLimitConnFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "limit-conn")),
+		LimitCount:            PluginTypedLimitCountFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "limit-count")),
+		LimitReq:              PluginTypedLimitReqFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "limit-req")),
+		Prometheus:            PluginTypedPrometheusFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "prometheus")),
+		ProxyMirror:           PluginTypedProxyMirrorFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "proxy-mirror")),
+		ProxyRewrite:          PluginTypedProxyRewriteFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "proxy-rewrite")),
+		RealIp:                PluginTypedRealIpFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "real-ip")),
+		Redirect:              PluginTypedRedirectFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "redirect")),
+		RefererRestriction:    PluginTypedRefererRestrictionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "referer-restriction")),
+		RequestId:             PluginTypedRequestIdFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "request-id")),
+		ResponseRewrite:       PluginTypedResponseRewriteFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "response-rewrite")),
+		ServerlessPreFunction: PluginTypedServerlessPreFunctionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "serverless-pre-function")),
+		UaRestriction:         PluginTypedUaRestrictionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "ua-restriction")),
+		UriBlocker:            PluginTypedUriBlockerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "uri-blocker")),
+	})
+}
+
+// ConsumerPluginsTypedType is the model of the typed plugins of the consumers.
+type ConsumerPluginsTypedType struct {
+	ApiBreaker            *PluginTypedApiBreakerType            `tfsdk:"api_breaker"`
+	BasicAuth             *PluginTypedBasicAuthConsumerType     `tfsdk:"basic_auth"`
+	ConsumerRestriction   *PluginTypedConsumerRestrictionType   `tfsdk:"consumer_restriction"`
+	Cors                  *PluginTypedCorsType                  `tfsdk:"cors"`
+	FaultInjection        *PluginTypedFaultInjectionType        `tfsdk:"fault_injection"`
+	Gzip                  *PluginTypedGzipType                  `tfsdk:"gzip"`
+	HmacAuth              *PluginTypedHmacAuthConsumerType      `tfsdk:"hmac_auth"`
+	HttpLogger            *PluginTypedHttpLoggerType            `tfsdk:"http_logger"`
+	IpRestriction         *PluginTypedIpRestrictionType         `tfsdk:"ip_restriction"`
+	JwtAuth               *PluginTypedJwtAuthConsumerType       `tfsdk:"jwt_auth"`
+	KeyAuth               *PluginTypedKeyAuthConsumerType       `tfsdk:"key_auth"`
+	LimitConn             *PluginTypedLimitConnType             `tfsdk:"limit_conn"`
+	LimitCount            *PluginTypedLimitCountType            `tfsdk:"limit_count"`
+	LimitReq              *PluginTypedLimitReqType              `tfsdk:"limit_req"`
+	Prometheus            *PluginTypedPrometheusType            `tfsdk:"prometheus"`
+	ProxyMirror           *PluginTypedProxyMirrorType           `tfsdk:"proxy_mirror"`
+	ProxyRewrite          *PluginTypedProxyRewriteType          `tfsdk:"proxy_rewrite"`
+	RealIp                *PluginTypedRealIpType                `tfsdk:"real_ip"`
+	Redirect              *PluginTypedRedirectType              `tfsdk:"redirect"`
+	RefererRestriction    *PluginTypedRefererRestrictionType    `tfsdk:"referer_restriction"`
+	RequestId             *PluginTypedRequestIdType             `tfsdk:"request_id"`
+	ResponseRewrite       *PluginTypedResponseRewriteType       `tfsdk:"response_rewrite"`
+	ServerlessPreFunction *PluginTypedServerlessPreFunctionType `tfsdk:"serverless_pre_function"`
+	UaRestriction         *PluginTypedUaRestrictionType         `tfsdk:"ua_restriction"`
+	UriBlocker            *PluginTypedUriBlockerType            `tfsdk:"uri_blocker"`
+}
+
+// ConsumerPluginsTypedFromTerraformToApi converts the typed plugins of the consumers into the plugins
+// configuration of APISIX, leaving out the plugins holding unknown values.
+func ConsumerPluginsTypedFromTerraformToApi(ctx context.Context, pluginsTyped types.Object) map[string]interface{} {
+	if pluginsTyped.IsNull() || pluginsTyped.IsUnknown() {
+		return nil
+	}
+
+	plugins := map[string]interface{}{}
+	var apiBreaker PluginTypedApiBreakerType
+	if pluginTypedAs(ctx, pluginsTyped, "api_breaker", &apiBreaker) {
+		plugins["api-breaker"] = PluginTypedApiBreakerFromTerraformToApi(ctx, &apiBreaker)
+	}
+	var basicAuth PluginTypedBasicAuthConsumerType
+	if pluginTypedAs(ctx, pluginsTyped, "basic_auth", &basicAuth) {
+		plugins["basic-auth"] = PluginTypedBasicAuthConsumerFromTerraformToApi(ctx, &basicAuth)
+	}
+	var consumerRestriction PluginTypedConsumerRestrictionType
+	if pluginTypedAs(ctx, pluginsTyped, "consumer_restriction", &consumerRestriction) {
+		plugins["consumer-restriction"] = PluginTypedConsumerRestrictionFromTerraformToApi(ctx, &consumerRestriction)
+	}
+	var cors PluginTypedCorsType
+	if pluginTypedAs(ctx, pluginsTyped, "cors", &cors) {
+		plugins["cors"] = PluginTypedCorsFromTerraformToApi(ctx, &cors)
+	}
+	var faultInjection PluginTypedFaultInjectionType
+	if pluginTypedAs(ctx, pluginsTyped, "fault_injection", &faultInjection) {
+		plugins["fault-injection"] = PluginTypedFaultInjectionFromTerraformToApi(ctx, &faultInjection)
+	}
+	var gzip PluginTypedGzipType
+	if pluginTypedAs(ctx, pluginsTyped, "gzip", &gzip) {
+		plugins["gzip"] = PluginTypedGzipFromTerraformToApi(ctx, &gzip)
+	}
+	var hmacAuth PluginTypedHmacAuthConsumerType
+	if pluginTypedAs(ctx, pluginsTyped, "hmac_auth", &hmacAuth) {
+		plugins["hmac-auth"] = PluginTypedHmacAuthConsumerFromTerraformToApi(ctx, &hmacAuth)
+	}
+	var httpLogger PluginTypedHttpLoggerType
+	if pluginTypedAs(ctx, pluginsTyped, "http_logger", &httpLogger) {
+		plugins["http-logger"] = PluginTypedHttpLoggerFromTerraformToApi(ctx, &httpLogger)
+	}
+	var ipRestriction PluginTypedIpRestrictionType
+	if pluginTypedAs(ctx, pluginsTyped, "ip_restriction", &ipRestriction) {
+		plugins["ip-restriction"] = PluginTypedIpRestrictionFromTerraformToApi(ctx, &ipRestriction)
+	}
+	var jwtAuth PluginTypedJwtAuthConsumerType
+	if pluginTypedAs(ctx, pluginsTyped, "jwt_auth", &jwtAuth) {
+		plugins["jwt-auth"] = PluginTypedJwtAuthConsumerFromTerraformToApi(ctx, &jwtAuth)
+	}
+	var keyAuth PluginTypedKeyAuthConsumerType
+	if pluginTypedAs(ctx, pluginsTyped, "key_auth", &keyAuth) {
+		plugins["key-auth"] = PluginTypedKeyAuthConsumerFromTerraformToApi(ctx, &keyAuth)
+	}
+	var limitConn PluginTypedLimitConnType
+	if pluginTypedAs(ctx, pluginsTyped, "limit_conn", &limitConn) {
+		plugins["limit-conn"] = PluginTypedLimitConnFromTerraformToApi(ctx, &limitConn)
+	}
+	var limitCount PluginTypedLimitCountType
+	if pluginTypedAs(ctx, pluginsTyped, "limit_count", &limitCount) {
+		plugins["limit-count"] = PluginTypedLimitCountFromTerraformToApi(ctx, &limitCount)
+	}
+	var limitReq PluginTypedLimitReqType
+	if pluginTypedAs(ctx, pluginsTyped, "limit_req", &limitReq) {
+		plugins["limit-req"] = PluginTypedLimitReqFromTerraformToApi(ctx, &limitReq)
+	}
+	var prometheus PluginTypedPrometheusType
+	if pluginTypedAs(ctx, pluginsTyped, "prometheus", &prometheus) {
+		plugins["prometheus"] = PluginTypedPrometheusFromTerraformToApi(ctx, &prometheus)
+	}
+	var proxyMirror PluginTypedProxyMirrorType
+	if pluginTypedAs(ctx, pluginsTyped, "proxy_mirror", &proxyMirror) {
+		plugins["proxy-mirror"] = PluginTypedProxyMirrorFromTerraformToApi(ctx, &proxyMirror)
+	}
+	var proxyRewrite PluginTypedProxyRewriteType
+	if pluginTypedAs(ctx, pluginsTyped, "proxy_rewrite", &proxyRewrite) {
+		plugins["proxy-rewrite"] = PluginTypedProxyRewriteFromTerraformToApi(ctx, &proxyRewrite)
+	}
+	var realIp PluginTypedRealIpType
+	if pluginTypedAs(ctx, pluginsTyped, "real_ip", &realIp) {
+		plugins["real-ip"] = PluginTypedRealIpFromTerraformToApi(ctx, &realIp)
+	}
+	var redirect PluginTypedRedirectType
+	if pluginTypedAs(ctx, pluginsTyped, "redirect", &redirect) {
+		plugins["redirect"] = PluginTypedRedirectFromTerraformToApi(ctx, &redirect)
+	}
+	var refererRestriction PluginTypedRefererRestrictionType
+	if pluginTypedAs(ctx, pluginsTyped, "referer_restriction", &refererRestriction) {
+		plugins["referer-restriction"] = PluginTypedRefererRestrictionFromTerraformToApi(ctx, &refererRestriction)
+	}
+	var requestId PluginTypedRequestIdType
+	if pluginTypedAs(ctx, pluginsTyped, "request_id", &requestId) {
+		plugins["request-id"] = PluginTypedRequestIdFromTerraformToApi(ctx, &requestId)
+	}
+	var responseRewrite PluginTypedResponseRewriteType
+	if pluginTypedAs(ctx, pluginsTyped, "response_rewrite", &responseRewrite) {
+		plugins["response-rewrite"] = PluginTypedResponseRewriteFromTerraformToApi(ctx, &responseRewrite)
+	}
+	var serverlessPreFunction PluginTypedServerlessPreFunctionType
+	if pluginTypedAs(ctx, pluginsTyped, "serverless_pre_function", &serverlessPreFunction) {
+		plugins["serverless-pre-function"] = PluginTypedServerlessPreFunctionFromTerraformToApi(ctx, &serverlessPreFunction)
+	}
+	var uaRestriction PluginTypedUaRestrictionType
+	if pluginTypedAs(ctx, pluginsTyped, "ua_restriction", &uaRestriction) {
+		plugins["ua-restriction"] = PluginTypedUaRestrictionFromTerraformToApi(ctx, &uaRestriction)
+	}
+	var uriBlocker PluginTypedUriBlockerType
+	if pluginTypedAs(ctx, pluginsTyped, "uri_blocker", &uriBlocker) {
+		plugins["uri-blocker"] = PluginTypedUriBlockerFromTerraformToApi(ctx, &uriBlocker)
+	}
+
+	return plugins
+}
+
+// ConsumerPluginsTypedFromApiToTerraform converts the plugins configuration of APISIX
+// into the typed plugins of the consumers, leaving out the plugins which aren't typed.
+func ConsumerPluginsTypedFromApiToTerraform(ctx context.Context, plugins map[string]interface{}) types.Object {
+	attributeTypes := ConsumerPluginsTypedSchemaAttribute.GetType().(types.ObjectType).AttrTypes
+	if plugins == nil {
+		return types.ObjectNull(attributeTypes)
+	}
+
+	return pluginsTypedObject(ctx, attributeTypes, ConsumerPluginsTypedType{
+		ApiBreaker:            PluginTypedApiBreakerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "api-breaker")),
+		BasicAuth:             PluginTypedBasicAuthConsumerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "basic-auth")),
+		ConsumerRestriction:   PluginTypedConsumerRestrictionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "consumer-restriction")),
+		Cors:                  PluginTypedCorsFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "cors")),
+		FaultInjection:        PluginTypedFaultInjectionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "fault-injection")),
+		Gzip:                  PluginTypedGzipFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "gzip")),
+		HmacAuth:              PluginTypedHmacAuthConsumerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "hmac-auth")),
+		HttpLogger:            PluginTypedHttpLoggerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "http-logger")),
+		IpRestriction:         PluginTypedIpRestrictionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "ip-restriction")),
+		JwtAuth:               PluginTypedJwtAuthConsumerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "jwt-auth")),
+		KeyAuth:               PluginTypedKeyAuthConsumerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "key-auth")),
+		LimitConn:             PluginTypedLimitConnFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "limit-conn")),
+		LimitCount:            PluginTypedLimitCountFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "limit-count")),
+		LimitReq:              PluginTypedLimitReqFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "limit-req")),
+		Prometheus:            PluginTypedPrometheusFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "prometheus")),
+		ProxyMirror:           PluginTypedProxyMirrorFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "proxy-mirror")),
+		ProxyRewrite:          PluginTypedProxyRewriteFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "proxy-rewrite")),
+		RealIp:                PluginTypedRealIpFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "real-ip")),
+		Redirect:              PluginTypedRedirectFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "redirect")),
+		RefererRestriction:    PluginTypedRefererRestrictionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "referer-restriction")),
+		RequestId:             PluginTypedRequestIdFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "request-id")),
+		ResponseRewrite:       PluginTypedResponseRewriteFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "response-rewrite")),
+		ServerlessPreFunction: PluginTypedServerlessPreFunctionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "serverless-pre-function")),
+		UaRestriction:         PluginTypedUaRestrictionFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "ua-restriction")),
+		UriBlocker:            PluginTypedUriBlockerFromApiToTerraform(ctx, typedObjectField(ctx, plugins, "uri-blocker")),
+	})
+}
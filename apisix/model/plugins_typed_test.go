@@ -0,0 +1,144 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPluginsTypedRoundTrip(t *testing.T) {
+	pluginsJSON := `{
+		"limit-count": {"count": 2, "time_window": 60, "key_type": "var", "redis_cluster_nodes": ["127.0.0.1:7000"]},
+		"http-logger": {"uri": "http://logs", "log_format": {"host": "$host", "status": 200}},
+		"proxy-rewrite": {"headers": {"set": {"X-Api-Version": "v1"}, "remove": ["X-Debug"]}},
+		"gzip": {"http_version": 1.1},
+		"response-rewrite": {"filters": [{"regex": "v1", "replace": "v2"}]},
+		"consumer-restriction": {"whitelist": ["jack"], "allowed_by_methods": []},
+		"openid-connect": {"client_id": "client"}
+	}`
+	var plugins map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(pluginsJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&plugins); err != nil {
+		t.Fatal(err)
+	}
+
+	pluginsTyped := PluginsTypedFromApiToTerraform(context.Background(), plugins)
+	limitCount := pluginsTyped.Attributes()["limit_count"].(types.Object).Attributes()
+	if limitCount["count"].(types.Int64).ValueInt64() != 2 || !limitCount["policy"].IsNull() {
+		t.Errorf("unexpected limit_count %v", limitCount)
+	}
+	logFormat := pluginsTyped.Attributes()["http_logger"].(types.Object).Attributes()["log_format"]
+	if logFormat.(types.String).ValueString() != `{"host":"$host","status":200}` {
+		t.Errorf("expected the log_format as a JSON string, got %s", logFormat)
+	}
+	filters := pluginsTyped.Attributes()["response_rewrite"].(types.Object).Attributes()["filters"].(types.List)
+	if len(filters.Elements()) != 1 || filters.Elements()[0].(types.Object).Attributes()["replace"].(types.String).ValueString() != "v2" {
+		t.Errorf("unexpected response-rewrite filters %s", filters)
+	}
+	if !pluginsTyped.Attributes()["cors"].IsNull() {
+		t.Errorf("expected a null cors, got %s", pluginsTyped.Attributes()["cors"])
+	}
+
+	// The plugins without a typed schema are left out
+	delete(plugins, "openid-connect")
+	expected, _ := json.Marshal(plugins)
+	actual, _ := json.Marshal(PluginsTypedFromTerraformToApi(context.Background(), pluginsTyped))
+	if string(actual) != string(expected) {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	// The typed plugins are merged into the plugins
+	merged := PluginsTypedValue(context.Background(), types.StringValue(`{"openid-connect":{"client_id":"client"}}`), pluginsTyped)
+	if !strings.Contains(merged.ValueString(), `"openid-connect":{"client_id":"client"}`) || !strings.Contains(merged.ValueString(), `"gzip":{"http_version":1.1}`) {
+		t.Errorf("unexpected merged plugins %s", merged)
+	}
+
+	// The consumer schemas of the authentication plugins hold their credentials
+	consumerPluginsTyped := ConsumerPluginsTypedFromApiToTerraform(context.Background(), map[string]interface{}{
+		"key-auth": map[string]interface{}{"key": "secret"},
+	})
+	key := consumerPluginsTyped.Attributes()["key_auth"].(types.Object).Attributes()["key"]
+	if key.(types.String).ValueString() != "secret" {
+		t.Errorf("expected the key-auth key, got %s", key)
+	}
+	consumerPlugins := PluginsTypedValue(context.Background(), types.StringNull(), consumerPluginsTyped)
+	if consumerPlugins.ValueString() != `{"key-auth":{"key":"secret"}}` {
+		t.Errorf("unexpected consumer plugins %s", consumerPlugins)
+	}
+}
+
+func TestPluginsTypedUnknown(t *testing.T) {
+	pluginsTyped := PluginsTypedFromApiToTerraform(context.Background(), map[string]interface{}{
+		"cors":        map[string]interface{}{"allow_origins": "*"},
+		"limit-count": map[string]interface{}{"count": json.Number("2"), "time_window": json.Number("60")},
+	})
+	attributes := pluginsTyped.Attributes()
+	limitCount := attributes["limit_count"].(types.Object).Attributes()
+	limitCount["count"] = types.Int64Unknown()
+	attributes["limit_count"] = types.ObjectValueMust(attributes["limit_count"].Type(context.Background()).(types.ObjectType).AttrTypes, limitCount)
+	pluginsTyped = types.ObjectValueMust(pluginsTyped.AttributeTypes(context.Background()), attributes)
+
+	// The plugins holding unknown values are left out until they are known
+	plugins := PluginsTypedFromTerraformToApi(context.Background(), pluginsTyped)
+	if _, ok := plugins["limit-count"]; ok || plugins["cors"] == nil {
+		t.Errorf("expected only the known plugins, got %v", plugins)
+	}
+}
+
+func TestPluginsTypedValidator(t *testing.T) {
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"plugins":       schema.StringAttribute{Optional: true},
+			"plugin":        PluginSchemaAttribute,
+			"plugins_typed": PluginsTypedSchemaAttribute,
+		},
+	}
+	pluginsTyped := PluginsTypedFromApiToTerraform(context.Background(), map[string]interface{}{
+		"cors": map[string]interface{}{"allow_origins": "*"},
+	})
+
+	testCases := map[string]struct {
+		plugins       string
+		expectedError bool
+	}{
+		"other plugins": {plugins: `{"limit-count":{"count":1,"time_window":60}}`},
+		"same plugin":   {plugins: `{"cors":{}}`, expectedError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pluginsTypedValue, err := pluginsTyped.ToTerraformValue(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			config := tfsdk.Config{
+				Schema: testSchema,
+				Raw: tftypes.NewValue(testSchema.Type().TerraformType(context.Background()), map[string]tftypes.Value{
+					"plugins":       tftypes.NewValue(tftypes.String, testCase.plugins),
+					"plugin":        tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+					"plugins_typed": pluginsTypedValue,
+				}),
+			}
+
+			resp := &validator.ObjectResponse{}
+			PluginsTypedValidator{}.ValidateObject(context.Background(), validator.ObjectRequest{
+				Path:        path.Root("plugins_typed"),
+				Config:      config,
+				ConfigValue: pluginsTyped,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != testCase.expectedError {
+				t.Errorf("expected error %t, got %v", testCase.expectedError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -5,8 +5,9 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -20,39 +21,61 @@ import (
 
 // RouteResourceModel maps the resource schema data.
 type RouteResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"desc"`
-	URI             types.String `tfsdk:"uri"`
-	URIS            types.List   `tfsdk:"uris"`
-	Host            types.String `tfsdk:"host"`
-	Hosts           types.List   `tfsdk:"hosts"`
-	RemoteAddr      types.String `tfsdk:"remote_addr"`
-	RemoteAddrs     types.List   `tfsdk:"remote_addrs"`
-	Methods         types.List   `tfsdk:"methods"`
-	Priority        types.Int64  `tfsdk:"priority"`
-	Vars            types.String `tfsdk:"vars"`
-	FilterFunc      types.String `tfsdk:"filter_func"`
-	Plugins         types.String `tfsdk:"plugins"`
-	Script          types.String `tfsdk:"script"`
-	UpstreamId      types.String `tfsdk:"upstream_id"`
-	ServiceId       types.String `tfsdk:"service_id"`
-	PluginConfigId  types.String `tfsdk:"plugin_config_id"`
-	Labels          types.Map    `tfsdk:"labels"`
-	Timeout         *TimeoutType `tfsdk:"timeout"`
-	EnableWebsocket types.Bool   `tfsdk:"enable_websocket"`
-	Status          types.Int64  `tfsdk:"status"`
+	ID                      types.String   `tfsdk:"id"`
+	Name                    types.String   `tfsdk:"name"`
+	Description             types.String   `tfsdk:"desc"`
+	URI                     types.String   `tfsdk:"uri"`
+	URIS                    types.Set      `tfsdk:"uris"`
+	Host                    types.String   `tfsdk:"host"`
+	Hosts                   types.Set      `tfsdk:"hosts"`
+	RemoteAddr              types.String   `tfsdk:"remote_addr"`
+	RemoteAddrs             types.Set      `tfsdk:"remote_addrs"`
+	Methods                 types.Set      `tfsdk:"methods"`
+	Priority                types.Int64    `tfsdk:"priority"`
+	Vars                    types.String   `tfsdk:"vars"`
+	FilterFunc              types.String   `tfsdk:"filter_func"`
+	Plugins                 types.String   `tfsdk:"plugins"`
+	Plugin                  types.Dynamic  `tfsdk:"plugin"`
+	PluginsTyped            types.Object   `tfsdk:"plugins_typed"`
+	SensitivePlugins        types.String   `tfsdk:"sensitive_plugins"`
+	Script                  types.String   `tfsdk:"script"`
+	UpstreamId              types.String   `tfsdk:"upstream_id"`
+	ServiceId               types.String   `tfsdk:"service_id"`
+	PluginConfigId          types.String   `tfsdk:"plugin_config_id"`
+	Labels                  types.Map      `tfsdk:"labels"`
+	Timeout                 *TimeoutType   `tfsdk:"timeout"`
+	EnableWebsocket         types.Bool     `tfsdk:"enable_websocket"`
+	Status                  types.Int64    `tfsdk:"status"`
+	CreateTime              types.Int64    `tfsdk:"create_time"`
+	UpdateTime              types.Int64    `tfsdk:"update_time"`
+	AdoptExisting           types.Bool     `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool     `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+	UpdateStrategy          types.String   `tfsdk:"update_strategy"`
+}
+
+// RouteStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the route.
+var RouteStateUpgrades = []StateUpgradeStep{
+	// Version 0 to 1
+	ListsToSets("uris", "hosts", "remote_addrs", "methods"),
+	// Version 1 to 2
+	TimeoutIntegerToNumber("timeout"),
 }
 
 var RouteSchema = schema.Schema{
+	Version:     int64(len(RouteStateUpgrades)),
 	Description: "Manages APISIX routes.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the route.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the route. When not set, it is generated randomly on create, or derived from the `name` with the provider `derive_ids`.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators,
 		},
 		"name": schema.StringAttribute{
 			Description: "Identifier for the route.",
@@ -66,7 +89,7 @@ var RouteSchema = schema.Schema{
 			Description: "Matches the uri.",
 			Optional:    true,
 		},
-		"uris": schema.ListAttribute{
+		"uris": schema.SetAttribute{
 			MarkdownDescription: "Matches with any one of the multiple `uri`s specified in the form of a non-empty list.",
 			ElementType:         types.StringType,
 			Optional:            true,
@@ -75,7 +98,7 @@ var RouteSchema = schema.Schema{
 			Description: "Matches with domain names such as `foo.com` or PAN domain names like `*.foo.com`.",
 			Optional:    true,
 		},
-		"hosts": schema.ListAttribute{
+		"hosts": schema.SetAttribute{
 			MarkdownDescription: "Matches with any one of the multiple `host`s specified in the form of a non-empty list.",
 			ElementType:         types.StringType,
 			Optional:            true,
@@ -84,18 +107,17 @@ var RouteSchema = schema.Schema{
 			Description: "Matches with the specified IP address in standard IPv4 format (`192.168.1.101`), CIDR format (`192.168.1.0/24`), or in IPv6 format.",
 			Optional:    true,
 		},
-		"remote_addrs": schema.ListAttribute{
+		"remote_addrs": schema.SetAttribute{
 			MarkdownDescription: "Matches with any one of the multiple `remote_addrs` specified in the form of a non-empty list.",
 			ElementType:         types.StringType,
 			Optional:            true,
 		},
-		"methods": schema.ListAttribute{
+		"methods": schema.SetAttribute{
 			MarkdownDescription: "Matches with the specified HTTP methods. Matches all methods if empty or unspecified.",
 			ElementType:         types.StringType,
 			Optional:            true,
-			Validators: []validator.List{
-				listvalidator.UniqueValues(),
-				listvalidator.ValueStringsAre(
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(
 					stringvalidator.OneOf(HttpMethods...),
 				),
 			},
@@ -121,6 +143,9 @@ var RouteSchema = schema.Schema{
 			Description: "Plugins that are executed during the request/response cycle.",
 			Optional:    true,
 		},
+		"sensitive_plugins": SensitivePluginsSchemaAttribute,
+		"plugin":            PluginSchemaAttribute,
+		"plugins_typed":     PluginsTypedSchemaAttribute,
 		"plugin_config_id": schema.StringAttribute{
 			Description: "Plugin config bound to the Route.",
 			Optional:    true,
@@ -158,6 +183,14 @@ var RouteSchema = schema.Schema{
 				int64validator.OneOf([]int64{0, 1}...),
 			},
 		},
+		"create_time":               CreateTimeSchemaAttribute,
+		"update_time":               UpdateTimeSchemaAttribute,
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+		"update_strategy":           UpdateStrategySchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
@@ -182,7 +215,7 @@ func RouteFromTerraformToApi(ctx context.Context, terraformDataModel *RouteResou
 	apiDataModel.Vars = VarsStringToJson(ctx, terraformDataModel.Vars)
 
 	apiDataModel.FilterFunc = terraformDataModel.FilterFunc.ValueStringPointer()
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	apiDataModel.Plugins = PluginsWithSensitiveToJson(ctx, PluginsTypedValue(ctx, PluginsValue(ctx, terraformDataModel.Plugins, terraformDataModel.Plugin), terraformDataModel.PluginsTyped), terraformDataModel.SensitivePlugins)
 	apiDataModel.Script = terraformDataModel.Script.ValueStringPointer()
 	apiDataModel.UpstreamId = terraformDataModel.UpstreamId.ValueStringPointer()
 	apiDataModel.ServiceId = terraformDataModel.ServiceId.ValueStringPointer()
@@ -208,23 +241,24 @@ func RouteFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Route
 	terraformDataModel.Description = types.StringPointerValue(apiDataModel.Description)
 	terraformDataModel.URI = types.StringPointerValue(apiDataModel.URI)
 
-	terraformDataModel.URIS, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.URIS)
+	terraformDataModel.URIS, _ = types.SetValueFrom(ctx, types.StringType, apiDataModel.URIS)
 
 	terraformDataModel.Host = types.StringPointerValue(apiDataModel.Host)
 
-	terraformDataModel.Hosts, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.Hosts)
+	terraformDataModel.Hosts, _ = types.SetValueFrom(ctx, types.StringType, apiDataModel.Hosts)
 
 	terraformDataModel.RemoteAddr = types.StringPointerValue(apiDataModel.RemoteAddr)
 
-	terraformDataModel.RemoteAddrs, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.RemoteAddrs)
+	terraformDataModel.RemoteAddrs, _ = types.SetValueFrom(ctx, types.StringType, apiDataModel.RemoteAddrs)
 
-	terraformDataModel.Methods, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.Methods)
+	terraformDataModel.Methods, _ = types.SetValueFrom(ctx, types.StringType, apiDataModel.Methods)
 	terraformDataModel.Priority = types.Int64PointerValue(apiDataModel.Priority)
 
 	terraformDataModel.Vars = VarsFromJsonToString(ctx, apiDataModel.Vars)
 
 	terraformDataModel.FilterFunc = types.StringPointerValue(apiDataModel.FilterFunc)
 	terraformDataModel.Plugins = PluginsFromJsonToString(ctx, apiDataModel.Plugins)
+	terraformDataModel.PluginsTyped = types.ObjectNull(PluginsTypedSchemaAttribute.GetType().(types.ObjectType).AttrTypes)
 	terraformDataModel.Script = types.StringPointerValue(apiDataModel.Script)
 	terraformDataModel.UpstreamId = types.StringPointerValue(apiDataModel.UpstreamId)
 	terraformDataModel.ServiceId = types.StringPointerValue(apiDataModel.ServiceId)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -14,13 +15,22 @@ import (
 )
 
 type SecretResourceModel struct {
-	ID    types.String     `tfsdk:"id"`
-	Vault *SecretVaultType `tfsdk:"vault"`
-	AWS   *SecretAWSType   `tfsdk:"aws"`
-	GCP   *SecretGCPType   `tfsdk:"gcp"`
+	ID                      types.String     `tfsdk:"id"`
+	Vault                   *SecretVaultType `tfsdk:"vault"`
+	AWS                     *SecretAWSType   `tfsdk:"aws"`
+	GCP                     *SecretGCPType   `tfsdk:"gcp"`
+	AdoptExisting           types.Bool       `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool       `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value   `tfsdk:"timeouts"`
+	ForceDelete             types.Bool       `tfsdk:"force_delete"`
 }
 
+// SecretStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the secret.
+var SecretStateUpgrades = []StateUpgradeStep{}
+
 var SecretSchema = schema.Schema{
+	Version:     int64(len(SecretStateUpgrades)),
 	Description: "Manages APISIX Secret Management",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
@@ -30,9 +40,15 @@ var SecretSchema = schema.Schema{
 				stringplanmodifier.RequiresReplace(),
 			},
 		},
-		"vault": SecretVaultSchemaAttribute,
-		"aws":   SecretAWSSchemaAttribute,
-		"gcp":   SecretGCPSchemaAttribute,
+		"vault":                     SecretVaultSchemaAttribute,
+		"aws":                       SecretAWSSchemaAttribute,
+		"gcp":                       SecretGCPSchemaAttribute,
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+		"force_delete":              ForceDeleteSchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
@@ -91,3 +107,23 @@ func SecretFromApiToTerraform(ctx context.Context, apiDataModel api_client.Secre
 
 	return terraformDataModel
 }
+
+// SetWriteOnly sets the write-only secrets of the configuration, as the plan
+// doesn't hold them.
+func (m *SecretResourceModel) SetWriteOnly(config SecretResourceModel) {
+	m.Vault.setWriteOnly(config.Vault)
+	m.AWS.setWriteOnly(config.AWS)
+	if m.GCP != nil && config.GCP != nil {
+		m.GCP.AuthConfig.setWriteOnly(config.GCP.AuthConfig)
+	}
+}
+
+// KeepWriteOnly keeps the versions of the write-only secrets of the plan or
+// the prior state, and the secrets sent with them out of the state.
+func (m *SecretResourceModel) KeepWriteOnly(prior SecretResourceModel) {
+	m.Vault.keepWriteOnly(prior.Vault)
+	m.AWS.keepWriteOnly(prior.AWS)
+	if m.GCP != nil && prior.GCP != nil {
+		m.GCP.AuthConfig.keepWriteOnly(prior.GCP.AuthConfig)
+	}
+}
@@ -6,17 +6,24 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type SecretAWSType struct {
-	AccessKeyId     types.String `tfsdk:"access_key_id"`
-	SecretAccessKey types.String `tfsdk:"secret_access_key"`
-	SessionToken    types.String `tfsdk:"session_token"`
-	Region          types.String `tfsdk:"region"`
-	EndpointUrl     types.String `tfsdk:"endpoint_url"`
+	AccessKeyId              types.String `tfsdk:"access_key_id"`
+	SecretAccessKey          types.String `tfsdk:"secret_access_key"`
+	SecretAccessKeyWO        types.String `tfsdk:"secret_access_key_wo"`
+	SecretAccessKeyWOVersion types.Int64  `tfsdk:"secret_access_key_wo_version"`
+	SessionToken             types.String `tfsdk:"session_token"`
+	SessionTokenWO           types.String `tfsdk:"session_token_wo"`
+	SessionTokenWOVersion    types.Int64  `tfsdk:"session_token_wo_version"`
+	Region                   types.String `tfsdk:"region"`
+	EndpointUrl              types.String `tfsdk:"endpoint_url"`
 }
 
 var SecretAWSSchemaAttribute = schema.SingleNestedAttribute{
@@ -29,13 +36,22 @@ var SecretAWSSchemaAttribute = schema.SingleNestedAttribute{
 			Required:    true,
 		},
 		"secret_access_key": schema.StringAttribute{
-			Description: "AWS Secret Access Key.",
-			Required:    true,
+			MarkdownDescription: "AWS Secret Access Key. Exactly one of `secret_access_key` and `secret_access_key_wo` must be set.",
+			Optional:            true,
+			Sensitive:           true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("secret_access_key_wo")),
+			},
 		},
+		"secret_access_key_wo":         WriteOnlySchemaAttribute("secret_access_key"),
+		"secret_access_key_wo_version": WriteOnlyVersionSchemaAttribute("secret_access_key"),
 		"session_token": schema.StringAttribute{
 			Description: "Temporary access credential information.",
 			Optional:    true,
+			Sensitive:   true,
 		},
+		"session_token_wo":         WriteOnlySchemaAttribute("session_token"),
+		"session_token_wo_version": WriteOnlyVersionSchemaAttribute("session_token"),
 		"region": schema.StringAttribute{
 			Description: "AWS Region.",
 			Optional:    true,
@@ -56,8 +72,8 @@ func SecretAWSFromTerraformToApi(ctx context.Context, id *string, terraformDataM
 	result := api_client.AWSSecret{
 		BaseSecret:      api_client.BaseSecret{ID: fmt.Sprintf("%s/%s", api_client.AWS, *id)},
 		AccessKeyId:     terraformDataModel.AccessKeyId.ValueStringPointer(),
-		SecretAccessKey: terraformDataModel.SecretAccessKey.ValueStringPointer(),
-		SessionToken:    terraformDataModel.SessionToken.ValueStringPointer(),
+		SecretAccessKey: WriteOnlyValue(terraformDataModel.SecretAccessKey, terraformDataModel.SecretAccessKeyWO).ValueStringPointer(),
+		SessionToken:    WriteOnlyValue(terraformDataModel.SessionToken, terraformDataModel.SessionTokenWO).ValueStringPointer(),
 		Region:          terraformDataModel.Region.ValueStringPointer(),
 		EndpointUrl:     terraformDataModel.EndpointUrl.ValueStringPointer(),
 	}
@@ -81,3 +97,24 @@ func SecretAWSFromApiToTerraform(ctx context.Context, apiDataModel *api_client.A
 
 	return &result
 }
+
+// setWriteOnly sets the write-only credentials of the configuration.
+func (t *SecretAWSType) setWriteOnly(config *SecretAWSType) {
+	if t == nil || config == nil {
+		return
+	}
+
+	t.SecretAccessKeyWO = config.SecretAccessKeyWO
+	t.SessionTokenWO = config.SessionTokenWO
+}
+
+// keepWriteOnly keeps the credentials sent with the write-only attributes out
+// of the state.
+func (t *SecretAWSType) keepWriteOnly(prior *SecretAWSType) {
+	if t == nil || prior == nil {
+		return
+	}
+
+	keepWriteOnly(&t.SecretAccessKey, &t.SecretAccessKeyWOVersion, prior.SecretAccessKeyWOVersion)
+	keepWriteOnly(&t.SessionToken, &t.SessionTokenWOVersion, prior.SessionTokenWOVersion)
+}
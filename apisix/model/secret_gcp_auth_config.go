@@ -5,21 +5,26 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type SecretGCPAuthConfigType struct {
-	ClientEmail types.String `tfsdk:"client_email"`
-	PrivateKey  types.String `tfsdk:"private_key"`
-	ProjectId   types.String `tfsdk:"project_id"`
-	TokenUri    types.String `tfsdk:"token_uri"`
-	EntriesUri  types.String `tfsdk:"entries_uri"`
-	Scope       types.List   `tfsdk:"scope"`
+	ClientEmail         types.String `tfsdk:"client_email"`
+	PrivateKey          types.String `tfsdk:"private_key"`
+	PrivateKeyWO        types.String `tfsdk:"private_key_wo"`
+	PrivateKeyWOVersion types.Int64  `tfsdk:"private_key_wo_version"`
+	ProjectId           types.String `tfsdk:"project_id"`
+	TokenUri            types.String `tfsdk:"token_uri"`
+	EntriesUri          types.String `tfsdk:"entries_uri"`
+	Scope               types.List   `tfsdk:"scope"`
 }
 
 var SecretGCPAuthConfigSchemaAttribute = schema.SingleNestedAttribute{
@@ -32,10 +37,15 @@ var SecretGCPAuthConfigSchemaAttribute = schema.SingleNestedAttribute{
 			Required:    true,
 		},
 		"private_key": schema.StringAttribute{
-			Description: "Private key of the Google Cloud service account.",
-			Required:    true,
-			Sensitive:   true,
+			MarkdownDescription: "Private key of the Google Cloud service account. Exactly one of `private_key` and `private_key_wo` must be set.",
+			Optional:            true,
+			Sensitive:           true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("private_key_wo")),
+			},
 		},
+		"private_key_wo":         WriteOnlySchemaAttribute("private_key"),
+		"private_key_wo_version": WriteOnlyVersionSchemaAttribute("private_key"),
 		"project_id": schema.StringAttribute{
 			Description: "Project ID in the Google Cloud service account.",
 			Required:    true,
@@ -77,7 +87,7 @@ func SecretGCPAuthConfigFromTerraformToApi(ctx context.Context, terraformDataMod
 
 	result := api_client.AuthConfigType{
 		ClientEmail: terraformDataModel.ClientEmail.ValueStringPointer(),
-		PrivateKey:  terraformDataModel.PrivateKey.ValueStringPointer(),
+		PrivateKey:  WriteOnlyValue(terraformDataModel.PrivateKey, terraformDataModel.PrivateKeyWO).ValueStringPointer(),
 		ProjectId:   terraformDataModel.ProjectId.ValueStringPointer(),
 		TokenUri:    terraformDataModel.TokenUri.ValueStringPointer(),
 		EntriesUri:  terraformDataModel.EntriesUri.ValueStringPointer(),
@@ -106,3 +116,22 @@ func SecretGCPAuthConfigFromApiToTerraform(ctx context.Context, apiDataModel *ap
 
 	return &result
 }
+
+// setWriteOnly sets the write-only private key of the configuration.
+func (t *SecretGCPAuthConfigType) setWriteOnly(config *SecretGCPAuthConfigType) {
+	if t == nil || config == nil {
+		return
+	}
+
+	t.PrivateKeyWO = config.PrivateKeyWO
+}
+
+// keepWriteOnly keeps the private key sent with private_key_wo out of the
+// state.
+func (t *SecretGCPAuthConfigType) keepWriteOnly(prior *SecretGCPAuthConfigType) {
+	if t == nil || prior == nil {
+		return
+	}
+
+	keepWriteOnly(&t.PrivateKey, &t.PrivateKeyWOVersion, prior.PrivateKeyWOVersion)
+}
@@ -6,17 +6,22 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type SecretVaultType struct {
-	Uri       types.String `tfsdk:"uri"`
-	Prefix    types.String `tfsdk:"prefix"`
-	Token     types.String `tfsdk:"token"`
-	Namespace types.String `tfsdk:"namespace"`
+	Uri            types.String `tfsdk:"uri"`
+	Prefix         types.String `tfsdk:"prefix"`
+	Token          types.String `tfsdk:"token"`
+	TokenWO        types.String `tfsdk:"token_wo"`
+	TokenWOVersion types.Int64  `tfsdk:"token_wo_version"`
+	Namespace      types.String `tfsdk:"namespace"`
 }
 
 var SecretVaultSchemaAttribute = schema.SingleNestedAttribute{
@@ -33,9 +38,15 @@ var SecretVaultSchemaAttribute = schema.SingleNestedAttribute{
 			Required:    true,
 		},
 		"token": schema.StringAttribute{
-			Description: "vault token.",
-			Required:    true,
+			MarkdownDescription: "vault token. Exactly one of `token` and `token_wo` must be set.",
+			Optional:            true,
+			Sensitive:           true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("token_wo")),
+			},
 		},
+		"token_wo":         WriteOnlySchemaAttribute("token"),
+		"token_wo_version": WriteOnlyVersionSchemaAttribute("token"),
 		"namespace": schema.StringAttribute{
 			Description: "Vault namespace, no default value.",
 			Optional:    true,
@@ -55,7 +66,7 @@ func SecretVaultFromTerraformToApi(ctx context.Context, id *string, terraformDat
 		BaseSecret: api_client.BaseSecret{ID: fmt.Sprintf("%s/%s", api_client.Vault, *id)},
 		Uri:        terraformDataModel.Uri.ValueStringPointer(),
 		Prefix:     terraformDataModel.Prefix.ValueStringPointer(),
-		Token:      terraformDataModel.Token.ValueStringPointer(),
+		Token:      WriteOnlyValue(terraformDataModel.Token, terraformDataModel.TokenWO).ValueStringPointer(),
 		Namespace:  terraformDataModel.Namespace.ValueStringPointer(),
 	}
 
@@ -77,3 +88,21 @@ func SecretVaultFromApiToTerraform(ctx context.Context, apiDataModel *api_client
 
 	return &result
 }
+
+// setWriteOnly sets the write-only token of the configuration.
+func (t *SecretVaultType) setWriteOnly(config *SecretVaultType) {
+	if t == nil || config == nil {
+		return
+	}
+
+	t.TokenWO = config.TokenWO
+}
+
+// keepWriteOnly keeps the token sent with token_wo out of the state.
+func (t *SecretVaultType) keepWriteOnly(prior *SecretVaultType) {
+	if t == nil || prior == nil {
+		return
+	}
+
+	keepWriteOnly(&t.Token, &t.TokenWOVersion, prior.TokenWOVersion)
+}
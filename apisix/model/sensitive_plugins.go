@@ -0,0 +1,72 @@
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var SensitivePluginsSchemaAttribute = schema.StringAttribute{
+	MarkdownDescription: "Plugins holding credentials, such as the `key-auth` key or the `openid-connect` client secret, in the same JSON format as `plugins`. " +
+		"The value is masked in the plans and merged into `plugins` before being sent to APISIX: the objects are merged recursively, " +
+		"and the values of `sensitive_plugins` take precedence.",
+	Optional:  true,
+	Sensitive: true,
+}
+
+// PluginsWithSensitiveToJson merges the sensitive plugins into the plugins,
+// both in the JSON format, to send them to APISIX.
+func PluginsWithSensitiveToJson(ctx context.Context, pluginsString types.String, sensitivePluginsString types.String) *map[string]interface{} {
+	plugins := PluginsStringToJson(ctx, pluginsString)
+	sensitivePlugins := PluginsStringToJson(ctx, sensitivePluginsString)
+
+	if sensitivePlugins == nil {
+		return plugins
+	}
+	if plugins == nil {
+		return sensitivePlugins
+	}
+
+	result := mergePluginValues(*plugins, *sensitivePlugins)
+	return &result
+}
+
+// mergePluginValues merges the override object into the base one, recursively
+// for the objects both hold.
+func mergePluginValues(base map[string]interface{}, override map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(base)+len(override))
+	for key, value := range base {
+		result[key] = value
+	}
+
+	for key, value := range override {
+		baseObject, baseIsObject := result[key].(map[string]interface{})
+		overrideObject, overrideIsObject := value.(map[string]interface{})
+		if baseIsObject && overrideIsObject {
+			result[key] = mergePluginValues(baseObject, overrideObject)
+			continue
+		}
+		result[key] = value
+	}
+
+	return result
+}
+
+// PriorPlugins returns the plugins, either as a JSON string or as a dynamic
+// value, and the sensitive plugins of the plan or the prior state to keep in
+// the new state. APISIX only returns them merged, as JSON. The other attributes
+// configuring plugins, like the typed plugins, keep a null `plugins` too.
+func PriorPlugins(plugins types.String, plugin types.Dynamic, sensitivePlugins types.String, others ...attr.Value) (types.String, types.Dynamic, types.String) {
+	if plugins.IsNull() && (!plugin.IsNull() || !sensitivePlugins.IsNull()) {
+		return plugins, plugin, sensitivePlugins
+	}
+	for _, other := range others {
+		if plugins.IsNull() && !other.IsNull() {
+			return plugins, plugin, sensitivePlugins
+		}
+	}
+
+	return types.StringValue(plugins.ValueString()), plugin, sensitivePlugins
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPluginsWithSensitiveToJson(t *testing.T) {
+	testCases := map[string]struct {
+		plugins          types.String
+		sensitivePlugins types.String
+		expected         string
+	}{
+		"without sensitive plugins": {
+			plugins:          types.StringValue(`{"key-auth":{"header":"apikey"}}`),
+			sensitivePlugins: types.StringNull(),
+			expected:         `{"key-auth":{"header":"apikey"}}`,
+		},
+		"only sensitive plugins": {
+			plugins:          types.StringNull(),
+			sensitivePlugins: types.StringValue(`{"key-auth":{"key":"auth-one"}}`),
+			expected:         `{"key-auth":{"key":"auth-one"}}`,
+		},
+		"merged plugin": {
+			plugins:          types.StringValue(`{"openid-connect":{"client_id":"apisix","session":{"cookie":{"lifetime":86400}}},"cors":{}}`),
+			sensitivePlugins: types.StringValue(`{"openid-connect":{"client_secret":"secret","session":{"secret":"session"}}}`),
+			expected: `{"cors":{},"openid-connect":{"client_id":"apisix","client_secret":"secret",` +
+				`"session":{"cookie":{"lifetime":86400},"secret":"session"}}}`,
+		},
+		"sensitive value precedence": {
+			plugins:          types.StringValue(`{"basic-auth":{"username":"jack","password":"placeholder"}}`),
+			sensitivePlugins: types.StringValue(`{"basic-auth":{"password":"secret"}}`),
+			expected:         `{"basic-auth":{"password":"secret","username":"jack"}}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			plugins := PluginsWithSensitiveToJson(context.Background(), testCase.plugins, testCase.sensitivePlugins)
+			result, err := json.Marshal(plugins)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(result) != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestPriorPlugins(t *testing.T) {
+	plugins, plugin, sensitivePlugins := PriorPlugins(types.StringNull(), types.DynamicNull(), types.StringValue(`{"key-auth":{"key":"auth-one"}}`))
+	if !plugins.IsNull() || !plugin.IsNull() || sensitivePlugins.IsNull() {
+		t.Errorf("expected only the sensitive plugins, got %s, %s and %s", plugins, plugin, sensitivePlugins)
+	}
+
+	plugins, plugin, sensitivePlugins = PriorPlugins(types.StringValue(`{"cors":{}}`), types.DynamicNull(), types.StringNull())
+	if plugins.ValueString() != `{"cors":{}}` || !plugin.IsNull() || !sensitivePlugins.IsNull() {
+		t.Errorf("expected only the plugins, got %s, %s and %s", plugins, plugin, sensitivePlugins)
+	}
+
+	cors := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"cors": types.ObjectType{AttrTypes: map[string]attr.Type{}}},
+		map[string]attr.Value{"cors": types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{})},
+	))
+	plugins, plugin, sensitivePlugins = PriorPlugins(types.StringNull(), cors, types.StringNull())
+	if !plugins.IsNull() || !plugin.Equal(cors) || !sensitivePlugins.IsNull() {
+		t.Errorf("expected only the dynamic plugins, got %s, %s and %s", plugins, plugin, sensitivePlugins)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -15,25 +16,46 @@ import (
 
 // ServiceResourceModel maps the resource schema data.
 type ServiceResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"desc"`
-	EnableWebsocket types.Bool   `tfsdk:"enable_websocket"`
-	Hosts           types.List   `tfsdk:"hosts"`
-	Labels          types.Map    `tfsdk:"labels"`
-	Plugins         types.String `tfsdk:"plugins"`
-	UpstreamId      types.String `tfsdk:"upstream_id"`
+	ID                      types.String   `tfsdk:"id"`
+	Name                    types.String   `tfsdk:"name"`
+	Description             types.String   `tfsdk:"desc"`
+	EnableWebsocket         types.Bool     `tfsdk:"enable_websocket"`
+	Hosts                   types.Set      `tfsdk:"hosts"`
+	Labels                  types.Map      `tfsdk:"labels"`
+	Plugins                 types.String   `tfsdk:"plugins"`
+	Plugin                  types.Dynamic  `tfsdk:"plugin"`
+	PluginsTyped            types.Object   `tfsdk:"plugins_typed"`
+	SensitivePlugins        types.String   `tfsdk:"sensitive_plugins"`
+	UpstreamId              types.String   `tfsdk:"upstream_id"`
+	CreateTime              types.Int64    `tfsdk:"create_time"`
+	UpdateTime              types.Int64    `tfsdk:"update_time"`
+	AdoptExisting           types.Bool     `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool     `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+	ForceDelete             types.Bool     `tfsdk:"force_delete"`
+	UpdateStrategy          types.String   `tfsdk:"update_strategy"`
+}
+
+// ServiceStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the service.
+var ServiceStateUpgrades = []StateUpgradeStep{
+	// Version 0 to 1
+	ListsToSets("hosts"),
 }
 
 var ServiceSchema = schema.Schema{
+	Version:     int64(len(ServiceStateUpgrades)),
 	Description: "Manages APISIX services.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the service.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the service. When not set, it is generated randomly on create, or derived from the `name` with the provider `derive_ids`.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators,
 		},
 		"name": schema.StringAttribute{
 			Description: "Identifier for the service.",
@@ -49,7 +71,7 @@ var ServiceSchema = schema.Schema{
 			Computed:            true,
 			Default:             booldefault.StaticBool(false),
 		},
-		"hosts": schema.ListAttribute{
+		"hosts": schema.SetAttribute{
 			MarkdownDescription: "Matches with any one of the multiple `hosts` specified in the form of a non-empty list.",
 			ElementType:         types.StringType,
 			Optional:            true,
@@ -63,10 +85,22 @@ var ServiceSchema = schema.Schema{
 			Description: "Plugins that are executed during the request/response cycle.",
 			Optional:    true,
 		},
+		"sensitive_plugins": SensitivePluginsSchemaAttribute,
+		"plugin":            PluginSchemaAttribute,
+		"plugins_typed":     PluginsTypedSchemaAttribute,
 		"upstream_id": schema.StringAttribute{
 			Description: "Id of the Upstream service.",
 			Optional:    true,
 		},
+		"create_time":               CreateTimeSchemaAttribute,
+		"update_time":               UpdateTimeSchemaAttribute,
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+		"force_delete":              ForceDeleteSchemaAttribute,
+		"update_strategy":           UpdateStrategySchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
@@ -79,7 +113,7 @@ func ServiceFromTerraformToApi(ctx context.Context, terraformDataModel *ServiceR
 	_ = terraformDataModel.Hosts.ElementsAs(ctx, &apiDataModel.Hosts, true)
 	_ = terraformDataModel.Labels.ElementsAs(ctx, &apiDataModel.Labels, true)
 
-	apiDataModel.Plugins = PluginsStringToJson(ctx, terraformDataModel.Plugins)
+	apiDataModel.Plugins = PluginsWithSensitiveToJson(ctx, PluginsTypedValue(ctx, PluginsValue(ctx, terraformDataModel.Plugins, terraformDataModel.Plugin), terraformDataModel.PluginsTyped), terraformDataModel.SensitivePlugins)
 
 	tflog.Debug(ctx, "Result of ServiceFromTerraformToApi", map[string]any{
 		"Values": apiDataModel,
@@ -95,10 +129,11 @@ func ServiceFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Ser
 	terraformDataModel.EnableWebsocket = types.BoolPointerValue(apiDataModel.EnableWebsocket)
 	terraformDataModel.UpstreamId = types.StringPointerValue(apiDataModel.UpstreamId)
 
-	terraformDataModel.Hosts, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.Hosts)
+	terraformDataModel.Hosts, _ = types.SetValueFrom(ctx, types.StringType, apiDataModel.Hosts)
 	terraformDataModel.Labels, _ = types.MapValueFrom(ctx, types.StringType, apiDataModel.Labels)
 
 	terraformDataModel.Plugins = PluginsFromJsonToString(ctx, apiDataModel.Plugins)
+	terraformDataModel.PluginsTyped = types.ObjectNull(PluginsTypedSchemaAttribute.GetType().(types.ObjectType).AttrTypes)
 
 	tflog.Debug(ctx, "Result of the ServiceFromApiToTerraform", map[string]any{
 		"Values": terraformDataModel,
@@ -10,8 +10,10 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -24,35 +26,60 @@ import (
 
 // SSLCertificateResourceModel maps the resource schema data.
 type SSLCertificateResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Status      types.Int64  `tfsdk:"status"`
-	Certificate types.String `tfsdk:"certificate"`
-	PrivateKey  types.String `tfsdk:"private_key"`
-	Snis        types.List   `tfsdk:"snis"`
-	Type        types.String `tfsdk:"type"`
-	Labels      types.Map    `tfsdk:"labels"`
+	ID                      types.String   `tfsdk:"id"`
+	Status                  types.Int64    `tfsdk:"status"`
+	Certificate             types.String   `tfsdk:"certificate"`
+	PrivateKey              types.String   `tfsdk:"private_key"`
+	PrivateKeyWO            types.String   `tfsdk:"private_key_wo"`
+	PrivateKeyWOVersion     types.Int64    `tfsdk:"private_key_wo_version"`
+	Snis                    types.Set      `tfsdk:"snis"`
+	Type                    types.String   `tfsdk:"type"`
+	Labels                  types.Map      `tfsdk:"labels"`
+	CreateTime              types.Int64    `tfsdk:"create_time"`
+	UpdateTime              types.Int64    `tfsdk:"update_time"`
+	AdoptExisting           types.Bool     `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool     `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+	ForceDelete             types.Bool     `tfsdk:"force_delete"`
+	UpdateStrategy          types.String   `tfsdk:"update_strategy"`
+}
+
+// SSLCertificateStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the SSL certificate.
+var SSLCertificateStateUpgrades = []StateUpgradeStep{
+	// Version 0 to 1
+	ListsToSets("snis"),
 }
 
 var SSLCertificateSchema = schema.Schema{
+	Version:     int64(len(SSLCertificateStateUpgrades)),
 	Description: "Manages APISIX SSL certificates.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the certificate.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the certificate. When not set, it is generated randomly on create, or derived from the `certificate` with the provider `derive_ids`.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators,
 		},
 		"certificate": schema.StringAttribute{
 			Description: "HTTPS certificate.",
 			Required:    true,
 		},
 		"private_key": schema.StringAttribute{
-			Description: "HTTPS private key.",
-			Required:    true,
-			Sensitive:   true,
+			MarkdownDescription: "HTTPS private key. Exactly one of `private_key` and `private_key_wo` must be set.",
+			Optional:            true,
+			Sensitive:           true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("private_key_wo")),
+			},
 		},
-		"snis": schema.ListAttribute{
+		"private_key_wo":         WriteOnlySchemaAttribute("private_key"),
+		"private_key_wo_version": WriteOnlyVersionSchemaAttribute("private_key"),
+		"snis": schema.SetAttribute{
 			MarkdownDescription: "A non-empty array of HTTPS SNI. Required if `type` is `server`.",
 			Optional:            true,
 			Computed:            true,
@@ -71,10 +98,9 @@ var SSLCertificateSchema = schema.Schema{
 			},
 		},
 		"labels": schema.MapAttribute{
-			MarkdownDescription: "Attributes of the resource specified as key-value pairs. An individual pair cannot be deleted using APISIX API. " +
-				"In order to delete an individual pair, you can delete all labels and reapply the resource with the desired labels map",
-			Optional:    true,
-			ElementType: types.StringType,
+			MarkdownDescription: "Attributes of the resource specified as key-value pairs.",
+			Optional:            true,
+			ElementType:         types.StringType,
 		},
 		"status": schema.Int64Attribute{
 			MarkdownDescription: "Enables the current SSL. Set to `1` (enabled) by default. `1` to enable, `0` to disable",
@@ -86,13 +112,22 @@ var SSLCertificateSchema = schema.Schema{
 				int64validator.OneOf([]int64{0, 1}...),
 			},
 		},
+		"create_time":               CreateTimeSchemaAttribute,
+		"update_time":               UpdateTimeSchemaAttribute,
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+		"force_delete":              ForceDeleteSchemaAttribute,
+		"update_strategy":           UpdateStrategySchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
 func SSLCertificateFromTerraformToAPI(ctx context.Context, terraformDataModel *SSLCertificateResourceModel) (apiDataModel api_client.SSLCertificate) {
 	apiDataModel.Status = terraformDataModel.Status.ValueInt64Pointer()
 	apiDataModel.Certificate = terraformDataModel.Certificate.ValueStringPointer()
-	apiDataModel.PrivateKey = terraformDataModel.PrivateKey.ValueStringPointer()
+	apiDataModel.PrivateKey = WriteOnlyValue(terraformDataModel.PrivateKey, terraformDataModel.PrivateKeyWO).ValueStringPointer()
 	apiDataModel.Type = terraformDataModel.Type.ValueStringPointer()
 
 	terraformDataModel.Snis.ElementsAs(ctx, &apiDataModel.SNIs, false)
@@ -113,7 +148,7 @@ func SSLCertificateFromAPIToTerraform(ctx context.Context, apiDataModel *api_cli
 	//terraformDataModel.PrivateKey = types.StringValue(apiDataModel.PrivateKey)
 	terraformDataModel.Type = types.StringPointerValue(apiDataModel.Type)
 
-	terraformDataModel.Snis, _ = types.ListValueFrom(ctx, types.StringType, apiDataModel.SNIs)
+	terraformDataModel.Snis, _ = types.SetValueFrom(ctx, types.StringType, apiDataModel.SNIs)
 	terraformDataModel.Labels, _ = types.MapValueFrom(ctx, types.StringType, apiDataModel.Labels)
 
 	tflog.Debug(ctx, "Result of the SSLCertificateFromAPIToTerraform", map[string]any{
@@ -123,6 +158,18 @@ func SSLCertificateFromAPIToTerraform(ctx context.Context, apiDataModel *api_cli
 	return terraformDataModel
 }
 
+// SetWriteOnly sets the write-only private key of the configuration, as the
+// plan doesn't hold it.
+func (m *SSLCertificateResourceModel) SetWriteOnly(config SSLCertificateResourceModel) {
+	m.PrivateKeyWO = config.PrivateKeyWO
+}
+
+// KeepWriteOnly keeps the version of the write-only private key of the plan
+// or the prior state, and the private key sent with it out of the state.
+func (m *SSLCertificateResourceModel) KeepWriteOnly(prior SSLCertificateResourceModel) {
+	keepWriteOnly(&m.PrivateKey, &m.PrivateKeyWOVersion, prior.PrivateKeyWOVersion)
+}
+
 // Get SNIS list from the certificate
 func CertSNIS(crt string, key string) ([]string, error) {
 	certDERBlock, _ := pem.Decode([]byte(crt))
@@ -0,0 +1,252 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// StateUpgradeStep rewrites the raw JSON state of a resource from a schema
+// version to the next one. The state is decoded with json.Number, so the
+// numbers are kept as they are.
+//
+// The schema version of a resource is the number of its upgrade steps: adding
+// a step to the upgrades of a resource bumps its schema version, and the
+// states written by all the prior versions are upgraded by running the steps
+// from their version on.
+type StateUpgradeStep func(state map[string]interface{}) error
+
+// StateUpgraders returns the upgraders of all the prior schema versions of a
+// resource with the given upgrade steps.
+func StateUpgraders(steps []StateUpgradeStep) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(steps))
+
+	for version := range steps {
+		remainingSteps := steps[version:]
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil {
+					resp.Diagnostics.AddError("Error Upgrading State", "The prior state is missing.")
+					return
+				}
+
+				upgraded, err := UpgradeRawState(req.RawState.JSON, remainingSteps)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Error Upgrading State",
+						fmt.Sprintf("Could not upgrade the state from the schema version %d: %s", version, err),
+					)
+					return
+				}
+
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+			},
+		}
+	}
+
+	return upgraders
+}
+
+// UpgradeRawState runs the upgrade steps on a raw JSON state.
+func UpgradeRawState(rawState []byte, steps []StateUpgradeStep) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawState))
+	decoder.UseNumber()
+
+	var state map[string]interface{}
+	if err := decoder.Decode(&state); err != nil {
+		return nil, fmt.Errorf("could not decode the state: %w", err)
+	}
+
+	for _, step := range steps {
+		if err := step(state); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(state)
+}
+
+// PluginsStringToDynamic upgrades an attribute holding the plugins as a JSON
+// string to a dynamic attribute holding them as an object. The attribute is
+// given by its path, the names of the attributes leading to it.
+func PluginsStringToDynamic(attributePath ...string) StateUpgradeStep {
+	return func(state map[string]interface{}) error {
+		return upgradeAttributeAt(state, attributePath, func(value interface{}) (interface{}, error) {
+			plugins, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a JSON string, got %T", value)
+			}
+
+			decoder := json.NewDecoder(strings.NewReader(plugins))
+			decoder.UseNumber()
+
+			var decoded interface{}
+			if err := decoder.Decode(&decoded); err != nil {
+				return nil, fmt.Errorf("could not decode the JSON string: %w", err)
+			}
+
+			return dynamicValue(decoded), nil
+		})
+	}
+}
+
+// TimeoutIntegerToNumber upgrades a timeout attribute with the integer
+// `connect`, `send` and `read` attributes to number attributes. Integers and
+// numbers are both JSON numbers in the state, so the step only checks the
+// values, and converts the ones written as strings.
+func TimeoutIntegerToNumber(attributePath ...string) StateUpgradeStep {
+	return func(state map[string]interface{}) error {
+		return upgradeAttributeAt(state, attributePath, func(value interface{}) (interface{}, error) {
+			timeout, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected an object, got %T", value)
+			}
+
+			for _, name := range []string{"connect", "send", "read"} {
+				switch v := timeout[name].(type) {
+				case nil, json.Number:
+				case string:
+					if _, err := json.Number(v).Float64(); err != nil {
+						return nil, fmt.Errorf("expected a number in %s, got %q", name, v)
+					}
+					timeout[name] = json.Number(v)
+				default:
+					return nil, fmt.Errorf("expected a number in %s, got %T", name, v)
+				}
+			}
+
+			return timeout, nil
+		})
+	}
+}
+
+// ListsToSets upgrades the list attributes with the given names to set
+// attributes. Lists and sets are both JSON arrays in the state, so the step
+// only drops the duplicate elements, which sets can't hold.
+func ListsToSets(names ...string) StateUpgradeStep {
+	return func(state map[string]interface{}) error {
+		for _, name := range names {
+			err := upgradeAttributeAt(state, []string{name}, func(value interface{}) (interface{}, error) {
+				elements, ok := value.([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("expected a list, got %T", value)
+				}
+
+				seen := make(map[string]bool, len(elements))
+				unique := make([]interface{}, 0, len(elements))
+				for _, element := range elements {
+					encoded, err := json.Marshal(element)
+					if err != nil {
+						return nil, err
+					}
+					if !seen[string(encoded)] {
+						seen[string(encoded)] = true
+						unique = append(unique, element)
+					}
+				}
+
+				return unique, nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// upgradeAttributeAt upgrades the attribute at the path, naming it in the
+// errors.
+func upgradeAttributeAt(state map[string]interface{}, attributePath []string, upgrade func(interface{}) (interface{}, error)) error {
+	if err := upgradeAttribute(state, attributePath, upgrade); err != nil {
+		return fmt.Errorf("could not upgrade the %s attribute: %w", strings.Join(attributePath, "."), err)
+	}
+	return nil
+}
+
+// upgradeAttribute replaces the non-null values of the attribute at the path
+// with the result of upgrade. The lists on the path are walked through, so
+// the attributes of all their elements are upgraded.
+func upgradeAttribute(state map[string]interface{}, attributePath []string, upgrade func(interface{}) (interface{}, error)) error {
+	if len(attributePath) == 0 {
+		return fmt.Errorf("missing attribute path")
+	}
+
+	name := attributePath[0]
+	value, ok := state[name]
+	if !ok || value == nil {
+		return nil
+	}
+
+	if len(attributePath) == 1 {
+		upgraded, err := upgrade(value)
+		if err != nil {
+			return err
+		}
+		state[name] = upgraded
+		return nil
+	}
+
+	var objects []interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		objects = []interface{}{v}
+	case []interface{}:
+		objects = v
+	default:
+		return fmt.Errorf("expected an object or a list in %s, got %T", name, value)
+	}
+
+	for _, object := range objects {
+		nested, ok := object.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected an object in %s, got %T", name, object)
+		}
+		if err := upgradeAttribute(nested, attributePath[1:], upgrade); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dynamicValue returns the state encoding of a dynamic attribute holding a
+// decoded JSON value, with the type Terraform's jsondecode gives it.
+func dynamicValue(value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"value": value,
+		"type":  dynamicType(value),
+	}
+}
+
+// dynamicType returns the JSON type of a decoded JSON value.
+func dynamicType(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return "string"
+	case json.Number, float64:
+		return "number"
+	case bool:
+		return "bool"
+	case map[string]interface{}:
+		attributeTypes := make(map[string]interface{}, len(v))
+		for name, attribute := range v {
+			attributeTypes[name] = dynamicType(attribute)
+		}
+		return []interface{}{"object", attributeTypes}
+	case []interface{}:
+		elementTypes := make([]interface{}, len(v))
+		for i, element := range v {
+			elementTypes[i] = dynamicType(element)
+		}
+		return []interface{}{"tuple", elementTypes}
+	}
+
+	return "dynamic"
+}
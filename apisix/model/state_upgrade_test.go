@@ -0,0 +1,309 @@
+package model
+
+import (
+	"context"
+	"maps"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// routeStateV0 is a route state written by the provider before the schema
+// versions, with the uris as a list, the plugins as a JSON string and the
+// integer timeouts.
+const routeStateV0 = `{
+	"id": "00000000000000000042",
+	"name": "checkout",
+	"desc": null,
+	"uri": null,
+	"uris": ["/checkout", "/checkout/*", "/checkout"],
+	"host": null,
+	"hosts": null,
+	"remote_addr": null,
+	"remote_addrs": null,
+	"methods": ["GET", "POST"],
+	"priority": 0,
+	"vars": null,
+	"filter_func": null,
+	"plugins": "{\"ip-restriction\":{\"blacklist\":[\"10.10.10.0/24\"],\"message\":\"Access denied\"},\"limit-count\":{\"count\":2,\"time_window\":60,\"rejected_msg\":null}}",
+	"script": null,
+	"upstream_id": "00000000000000000041",
+	"service_id": null,
+	"plugin_config_id": null,
+	"labels": {"team": "payments"},
+	"timeout": {"connect": 3, "send": 3, "read": 3},
+	"enable_websocket": false,
+	"status": 1
+}`
+
+// upgradedRouteSchema is the route schema with the plugins as a dynamic
+// attribute and the timeouts as numbers, the states are upgraded to with
+// upgradedRouteSteps.
+var upgradedRouteSchema = schema.Schema{
+	Version: int64(len(upgradedRouteSteps)),
+	Attributes: func() map[string]schema.Attribute {
+		attributes := maps.Clone(RouteSchema.Attributes)
+		attributes["plugins"] = schema.DynamicAttribute{
+			Optional: true,
+		}
+		attributes["timeout"] = schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"connect": schema.NumberAttribute{Required: true},
+				"send":    schema.NumberAttribute{Required: true},
+				"read":    schema.NumberAttribute{Required: true},
+			},
+		}
+		return attributes
+	}(),
+	Blocks: RouteSchema.Blocks,
+}
+
+var upgradedRouteSteps = append(RouteStateUpgrades[:len(RouteStateUpgrades):len(RouteStateUpgrades)],
+	// Version 2 to 3
+	PluginsStringToDynamic("plugins"),
+)
+
+func upgradeState(t *testing.T, version int64, rawState string) (map[string]tftypes.Value, *resource.UpgradeStateResponse) {
+	t.Helper()
+
+	upgrader, ok := StateUpgraders(upgradedRouteSteps)[version]
+	if !ok {
+		t.Fatalf("expected an upgrader for the schema version %d", version)
+	}
+
+	resp := &resource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(context.Background(), resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(rawState)},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		return nil, resp
+	}
+
+	state, err := resp.DynamicValue.Unmarshal(upgradedRouteSchema.Type().TerraformType(context.Background()))
+	if err != nil {
+		t.Fatalf("could not decode the upgraded state with the current schema: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+
+	return attributes, resp
+}
+
+func TestStateUpgraders(t *testing.T) {
+	if len(StateUpgraders(upgradedRouteSteps)) != 3 {
+		t.Fatalf("expected an upgrader per prior schema version")
+	}
+
+	attributes, resp := upgradeState(t, 0, routeStateV0)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var uris []tftypes.Value
+	if err := attributes["uris"].As(&uris); err != nil || len(uris) != 2 {
+		t.Errorf("expected the uris without the duplicates, got %s", attributes["uris"])
+	}
+
+	var plugins map[string]tftypes.Value
+	if err := attributes["plugins"].As(&plugins); err != nil {
+		t.Fatalf("expected the plugins to be an object, got %s", attributes["plugins"])
+	}
+	var limitCount map[string]tftypes.Value
+	if err := plugins["limit-count"].As(&limitCount); err != nil {
+		t.Fatal(err)
+	}
+	var count big.Float
+	if err := limitCount["count"].As(&count); err != nil || count.String() != "2" {
+		t.Errorf("expected the plugin numbers to be kept, got %s", limitCount["count"])
+	}
+	var ipRestriction map[string]tftypes.Value
+	if err := plugins["ip-restriction"].As(&ipRestriction); err != nil {
+		t.Fatal(err)
+	}
+	var blacklist []tftypes.Value
+	if err := ipRestriction["blacklist"].As(&blacklist); err != nil || len(blacklist) != 1 {
+		t.Errorf("expected the plugin lists to be kept, got %s", ipRestriction["blacklist"])
+	}
+
+	var timeout map[string]tftypes.Value
+	if err := attributes["timeout"].As(&timeout); err != nil {
+		t.Fatal(err)
+	}
+	var connect big.Float
+	if err := timeout["connect"].As(&connect); err != nil || connect.String() != "3" {
+		t.Errorf("expected the timeouts to be kept, got %s", timeout["connect"])
+	}
+}
+
+func TestStateUpgradersPriorVersion(t *testing.T) {
+	// The version 1 state already holds the uris as a set, and the timeout was written as a string by hand
+	rawState := strings.Replace(routeStateV0, `["/checkout", "/checkout/*", "/checkout"]`, `["/checkout", "/checkout/*"]`, 1)
+	rawState = strings.Replace(rawState, `"connect": 3`, `"connect": "0.5"`, 1)
+
+	attributes, resp := upgradeState(t, 1, rawState)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var timeout map[string]tftypes.Value
+	if err := attributes["timeout"].As(&timeout); err != nil {
+		t.Fatal(err)
+	}
+	var connect big.Float
+	if err := timeout["connect"].As(&connect); err != nil || connect.String() != "0.5" {
+		t.Errorf("expected the timeout written as a string to be converted, got %s", timeout["connect"])
+	}
+}
+
+func TestStateUpgradersNullAttributes(t *testing.T) {
+	rawState := `{"id": "1", "uri": "/status", "uris": null, "methods": null, "plugins": null, "timeout": null, "status": 1}`
+
+	attributes, resp := upgradeState(t, 0, rawState)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	for _, name := range []string{"uris", "plugins", "timeout"} {
+		if !attributes[name].IsNull() {
+			t.Errorf("expected the null %s to stay null, got %s", name, attributes[name])
+		}
+	}
+}
+
+func TestStateUpgradersErrors(t *testing.T) {
+	testCases := map[string]struct {
+		rawState string
+		err      string
+	}{
+		"invalid state": {
+			rawState: `{not json`,
+			err:      "could not decode the state",
+		},
+		"invalid uris": {
+			rawState: strings.Replace(routeStateV0, `["/checkout", "/checkout/*", "/checkout"]`, `"/checkout"`, 1),
+			err:      "could not upgrade the uris attribute: expected a list, got string",
+		},
+		"invalid timeout": {
+			rawState: strings.Replace(routeStateV0, `"connect": 3`, `"connect": "fast"`, 1),
+			err:      `expected a number in connect, got "fast"`,
+		},
+		"invalid plugins": {
+			rawState: strings.Replace(routeStateV0, `"plugins": "{`, `"plugins": "{{`, 1),
+			err:      "could not decode the JSON string",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, resp := upgradeState(t, 0, testCase.rawState)
+
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected an error, got %v", resp.Diagnostics)
+			}
+			detail := resp.Diagnostics.Errors()[0].Detail()
+			if !strings.Contains(detail, "from the schema version 0") || !strings.Contains(detail, testCase.err) {
+				t.Errorf("expected an error containing %q, got %q", testCase.err, detail)
+			}
+		})
+	}
+}
+
+func TestUpgradeRawStateLists(t *testing.T) {
+	upgraded, err := UpgradeRawState(
+		[]byte(`{"checks":[{"active":{"http_statuses":[200,302,200]}},{"active":null}]}`),
+		[]StateUpgradeStep{func(state map[string]interface{}) error {
+			return upgradeAttributeAt(state, []string{"checks", "active", "http_statuses"}, func(value interface{}) (interface{}, error) {
+				return value.([]interface{})[:2], nil
+			})
+		}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"checks":[{"active":{"http_statuses":[200,302]}},{"active":null}]}`
+	if string(upgraded) != expected {
+		t.Errorf("expected %s, got %s", expected, upgraded)
+	}
+}
+
+func TestRouteStateUpgrades(t *testing.T) {
+	resp := &resource.UpgradeStateResponse{}
+	StateUpgraders(RouteStateUpgrades)[0].StateUpgrader(context.Background(), resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(routeStateV0)},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	state, err := resp.DynamicValue.Unmarshal(RouteSchema.Type().TerraformType(context.Background()))
+	if err != nil {
+		t.Fatalf("could not decode the upgraded state with the current schema: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	var uris []tftypes.Value
+	if err := attributes["uris"].As(&uris); err != nil || len(uris) != 2 {
+		t.Errorf("expected the uris without the duplicates, got %s", attributes["uris"])
+	}
+	if !attributes["hosts"].IsNull() {
+		t.Errorf("expected the null hosts to stay null, got %s", attributes["hosts"])
+	}
+	var timeout map[string]tftypes.Value
+	if err := attributes["timeout"].As(&timeout); err != nil {
+		t.Fatal(err)
+	}
+	var connect big.Float
+	if err := timeout["connect"].As(&connect); err != nil || connect.String() != "3" {
+		t.Errorf("expected the timeouts to be kept, got %s", timeout["connect"])
+	}
+}
+
+func TestUpstreamStateUpgrades(t *testing.T) {
+	resp := &resource.UpgradeStateResponse{}
+	StateUpgraders(UpstreamStateUpgrades)[0].StateUpgrader(context.Background(), resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"id":"1","type":"roundrobin","timeout":{"connect":6,"send":6,"read":6},"nodes":[` +
+			`{"host":"127.0.0.1","port":1980,"weight":1},{"host":"::1","port":1970,"weight":2}]}`)},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	state, err := resp.DynamicValue.Unmarshal(UpstreamSchema.Type().TerraformType(context.Background()))
+	if err != nil {
+		t.Fatalf("could not decode the upgraded state with the current schema: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	var nodes map[string]tftypes.Value
+	if err := attributes["nodes"].As(&nodes); err != nil {
+		t.Fatal(err)
+	}
+
+	var node map[string]tftypes.Value
+	if err := nodes["[::1]:1970"].As(&node); err != nil {
+		t.Fatalf("expected the node keyed by its address, got %s", attributes["nodes"])
+	}
+	var weight big.Float
+	if err := node["weight"].As(&weight); err != nil || weight.String() != "2" {
+		t.Errorf("expected the weight of the node to be kept, got %s", node["weight"])
+	}
+	if _, ok := nodes["127.0.0.1:1980"]; !ok || len(nodes) != 2 {
+		t.Errorf("expected the nodes keyed by their addresses, got %s", attributes["nodes"])
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -16,23 +17,36 @@ import (
 
 // StreamRouteModel maps the resource schema data.
 type StreamRouteModel struct {
-	ID         types.String `tfsdk:"id"`
-	UpstreamId types.String `tfsdk:"upstream_id"`
-	RemoteAddr types.String `tfsdk:"remote_addr"`
-	ServerAddr types.String `tfsdk:"server_addr"`
-	ServerPort types.Int64  `tfsdk:"server_port"`
-	SNI        types.String `tfsdk:"sni"`
+	ID                      types.String   `tfsdk:"id"`
+	UpstreamId              types.String   `tfsdk:"upstream_id"`
+	RemoteAddr              types.String   `tfsdk:"remote_addr"`
+	ServerAddr              types.String   `tfsdk:"server_addr"`
+	ServerPort              types.Int64    `tfsdk:"server_port"`
+	SNI                     types.String   `tfsdk:"sni"`
+	CreateTime              types.Int64    `tfsdk:"create_time"`
+	UpdateTime              types.Int64    `tfsdk:"update_time"`
+	AdoptExisting           types.Bool     `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool     `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
+// StreamRouteStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the stream route.
+var StreamRouteStateUpgrades = []StateUpgradeStep{}
+
 var StreamRouteSchema = schema.Schema{
+	Version:     int64(len(StreamRouteStateUpgrades)),
 	Description: "Manages APISIX Routes used in the Stream Proxy.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the stream route.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the stream route. When not set, it is generated randomly on create, or derived from the matching attributes with the provider `derive_ids`.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators,
 		},
 		"upstream_id": schema.StringAttribute{
 			Description: "Id of the Upstream service.",
@@ -57,6 +71,13 @@ var StreamRouteSchema = schema.Schema{
 			MarkdownDescription: "Server Name Indication. Matches with domain names such as `foo.com`",
 			Optional:            true,
 		},
+		"create_time":               CreateTimeSchemaAttribute,
+		"update_time":               UpdateTimeSchemaAttribute,
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
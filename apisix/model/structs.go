@@ -1,5 +1,21 @@
 package model
 
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// ObjectIDValidators validates the identifiers of the APISIX objects.
+var ObjectIDValidators = []validator.String{
+	stringvalidator.LengthBetween(1, 64),
+	stringvalidator.RegexMatches(
+		regexp.MustCompile(`^[a-zA-Z0-9-_.]+$`),
+		"must contain only letters, digits, `-`, `_` and `.`",
+	),
+}
+
 var HttpMethods = []string{
 	"GET",
 	"POST",
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TimeoutsSchemaBlock is the `timeouts` block bounding the create, update and
+// delete operations, including their retries and read backs.
+var TimeoutsSchemaBlock = timeouts.Block(context.Background(), timeouts.Opts{
+	Create: true,
+	Update: true,
+	Delete: true,
+})
+
+// NullTimeouts returns the value of an unset timeouts block, for the states
+// built from the API objects only, e.g. on import.
+func NullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(TimeoutsSchemaBlock.Type().(timeouts.Type).AttrTypes),
+	}
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+var CreateTimeSchemaAttribute = schema.Int64Attribute{
+	MarkdownDescription: "Time the object was created in APISIX, as a Unix timestamp in seconds.",
+	Computed:            true,
+	PlanModifiers: []planmodifier.Int64{
+		int64planmodifier.UseStateForUnknown(),
+	},
+}
+
+var UpdateTimeSchemaAttribute = schema.Int64Attribute{
+	MarkdownDescription: "Time the object was last changed in APISIX, including the changes made outside Terraform, as a Unix timestamp in seconds.",
+	Computed:            true,
+	PlanModifiers: []planmodifier.Int64{
+		int64planmodifier.UseStateForUnknown(),
+	},
+}
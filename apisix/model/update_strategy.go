@@ -0,0 +1,17 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var UpdateStrategySchemaAttribute = schema.StringAttribute{
+	MarkdownDescription: "How the object is updated in APISIX, overriding the provider `update_strategy`. " +
+		"`put` replaces the whole object, `patch` sends only the attributes changed since the last refresh and keeps the fields the provider doesn't manage. " +
+		"Defaults to the provider setting, `put` unless configured otherwise.",
+	Optional: true,
+	Validators: []validator.String{
+		stringvalidator.OneOf([]string{"put", "patch"}...),
+	},
+}
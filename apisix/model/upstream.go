@@ -5,6 +5,7 @@ import (
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,36 +18,56 @@ import (
 )
 
 type UpstreamResourceModel struct {
-	ID            types.String               `tfsdk:"id"`
-	Type          types.String               `tfsdk:"type"`
-	ServiceName   types.String               `tfsdk:"service_name"`
-	DiscoveryType types.String               `tfsdk:"discovery_type"`
-	Timeout       *TimeoutType               `tfsdk:"timeout"`
-	Name          types.String               `tfsdk:"name"`
-	Desc          types.String               `tfsdk:"desc"`
-	PassHost      types.String               `tfsdk:"pass_host"`
-	Scheme        types.String               `tfsdk:"scheme"`
-	Retries       types.Int64                `tfsdk:"retries"`
-	RetryTimeout  types.Int64                `tfsdk:"retry_timeout"`
-	Labels        types.Map                  `tfsdk:"labels"`
-	UpstreamHost  types.String               `tfsdk:"upstream_host"`
-	HashOn        types.String               `tfsdk:"hash_on"`
-	Key           types.String               `tfsdk:"key"`
-	KeepalivePool *UpstreamKeepAlivePoolType `tfsdk:"keepalive_pool"`
-	TLS           *UpstreamTLSType           `tfsdk:"tls"`
-	Checks        *UpstreamChecksType        `tfsdk:"checks"`
-	Nodes         *[]UpstreamNodeType        `tfsdk:"nodes"`
+	ID                      types.String                `tfsdk:"id"`
+	Type                    types.String                `tfsdk:"type"`
+	ServiceName             types.String                `tfsdk:"service_name"`
+	DiscoveryType           types.String                `tfsdk:"discovery_type"`
+	Timeout                 *TimeoutType                `tfsdk:"timeout"`
+	Name                    types.String                `tfsdk:"name"`
+	Desc                    types.String                `tfsdk:"desc"`
+	PassHost                types.String                `tfsdk:"pass_host"`
+	Scheme                  types.String                `tfsdk:"scheme"`
+	Retries                 types.Int64                 `tfsdk:"retries"`
+	RetryTimeout            types.Int64                 `tfsdk:"retry_timeout"`
+	Labels                  types.Map                   `tfsdk:"labels"`
+	UpstreamHost            types.String                `tfsdk:"upstream_host"`
+	HashOn                  types.String                `tfsdk:"hash_on"`
+	Key                     types.String                `tfsdk:"key"`
+	KeepalivePool           *UpstreamKeepAlivePoolType  `tfsdk:"keepalive_pool"`
+	TLS                     *UpstreamTLSType            `tfsdk:"tls"`
+	Checks                  *UpstreamChecksType         `tfsdk:"checks"`
+	Nodes                   map[string]UpstreamNodeType `tfsdk:"nodes"`
+	CreateTime              types.Int64                 `tfsdk:"create_time"`
+	UpdateTime              types.Int64                 `tfsdk:"update_time"`
+	AdoptExisting           types.Bool                  `tfsdk:"adopt_existing"`
+	IgnoreConcurrentChanges types.Bool                  `tfsdk:"ignore_concurrent_changes"`
+	Timeouts                timeouts.Value              `tfsdk:"timeouts"`
+	ForceDelete             types.Bool                  `tfsdk:"force_delete"`
+	UpdateStrategy          types.String                `tfsdk:"update_strategy"`
+}
+
+// UpstreamStateUpgrades are the steps upgrading the states of the prior schema
+// versions of the upstream.
+var UpstreamStateUpgrades = []StateUpgradeStep{
+	// Version 0 to 1
+	UpstreamNodesListToMap("nodes"),
+	// Version 1 to 2
+	TimeoutIntegerToNumber("timeout"),
 }
 
 var UpstreamSchema = schema.Schema{
+	Version:     int64(len(UpstreamStateUpgrades)),
 	Description: "Manages APISIX Upstreams.",
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
-			Description: "Identifier of the upstream.",
-			Computed:    true,
+			MarkdownDescription: "Identifier of the upstream. When not set, it is generated randomly on create, or derived from the `name` with the provider `derive_ids`.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
+			Validators: ObjectIDValidators,
 		},
 		"type": schema.StringAttribute{
 			MarkdownDescription: "Load balancing algorithm to be used, and the default value is `roundrobin`.\n" +
@@ -125,10 +146,19 @@ var UpstreamSchema = schema.Schema{
 			Optional:            true,
 			ElementType:         types.StringType,
 		},
-		"keepalive_pool": UpstreamKeepAlivePoolSchemaAttribute,
-		"tls":            UpstreamTLSSchemaAttribute,
-		"checks":         UpstreamChecksSchemaAttribute,
-		"nodes":          UpstreamNodesSchemaAttribute,
+		"keepalive_pool":            UpstreamKeepAlivePoolSchemaAttribute,
+		"tls":                       UpstreamTLSSchemaAttribute,
+		"checks":                    UpstreamChecksSchemaAttribute,
+		"nodes":                     UpstreamNodesSchemaAttribute,
+		"create_time":               CreateTimeSchemaAttribute,
+		"update_time":               UpdateTimeSchemaAttribute,
+		"adopt_existing":            AdoptExistingSchemaAttribute,
+		"ignore_concurrent_changes": IgnoreConcurrentChangesSchemaAttribute,
+		"force_delete":              ForceDeleteSchemaAttribute,
+		"update_strategy":           UpdateStrategySchemaAttribute,
+	},
+	Blocks: map[string]schema.Block{
+		"timeouts": TimeoutsSchemaBlock,
 	},
 }
 
@@ -190,3 +220,15 @@ func UpstreamFromApiToTerraform(ctx context.Context, apiDataModel *api_client.Up
 
 	return terraformDataModel, labelsDiag
 }
+
+// SetWriteOnly sets the write-only client key of the configuration, as the
+// plan doesn't hold it.
+func (m *UpstreamResourceModel) SetWriteOnly(config UpstreamResourceModel) {
+	m.TLS.setWriteOnly(config.TLS)
+}
+
+// KeepWriteOnly keeps the version of the write-only client key of the plan or
+// the prior state, and the client key sent with it out of the state.
+func (m *UpstreamResourceModel) KeepWriteOnly(prior UpstreamResourceModel) {
+	m.TLS.keepWriteOnly(prior.TLS)
+}
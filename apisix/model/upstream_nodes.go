@@ -2,72 +2,193 @@ package model
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/holubovskyi/apisix-client-go"
 )
 
 type UpstreamNodeType struct {
-	Host   types.String `tfsdk:"host"`
-	Port   types.Int64  `tfsdk:"port"`
-	Weight types.Int64  `tfsdk:"weight"`
+	Weight types.Int64 `tfsdk:"weight"`
 }
 
-var UpstreamNodesSchemaAttribute = schema.ListNestedAttribute{
-	MarkdownDescription: "Configures the parameters for the health check.",
-	Optional:            true,
+// upstreamNodeAddressPattern matches the `host:port` addresses of the nodes,
+// with the IPv6 hosts in brackets.
+var upstreamNodeAddressPattern = regexp.MustCompile(`^(\[[0-9A-Fa-f:.]+\]|[^:\[\]]+):[0-9]{1,5}$`)
+
+var UpstreamNodesSchemaAttribute = schema.MapNestedAttribute{
+	MarkdownDescription: "Nodes of the upstream, keyed by their address in the `host:port` form, with the IPv6 hosts compressed and in brackets like `[::1]:80`. " +
+		"Keying the nodes by their address makes their order irrelevant, and changing the weight of a node changes only this node in the plan.",
+	Optional: true,
+	Validators: []validator.Map{
+		mapvalidator.KeysAre(
+			stringvalidator.RegexMatches(upstreamNodeAddressPattern, "must be an address in the host:port form"),
+			upstreamNodeAddressValidator{},
+		),
+	},
 
 	NestedObject: schema.NestedAttributeObject{
 		Attributes: map[string]schema.Attribute{
-			"host": schema.StringAttribute{
-				Required: true,
-			},
-			"port": schema.Int64Attribute{
-				Required: true,
-			},
 			"weight": schema.Int64Attribute{
-				Optional: true,
-				Computed: true,
-				Default:  int64default.StaticInt64(1),
+				MarkdownDescription: "Weight of the node, `1` by default.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
 			},
 		},
 	},
 }
 
-func UpstreamNodesFromTerraformToAPI(ctx context.Context, terraformDataModel *[]UpstreamNodeType) (apiDataModel *[]api_client.UpstreamNodeType) {
+// upstreamNodeAddressValidator makes sure the nodes are keyed by the address
+// read back from APISIX, without leading zeros in the port and with the
+// compressed IPv6 hosts, so the keys don't change on refresh.
+type upstreamNodeAddressValidator struct{}
+
+func (v upstreamNodeAddressValidator) Description(_ context.Context) string {
+	return "must be an address in the canonical host:port form"
+}
+
+func (v upstreamNodeAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v upstreamNodeAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	address := req.ConfigValue.ValueString()
+	host, port, err := SplitUpstreamNodeAddress(address)
+	if err != nil {
+		// Reported by the pattern
+		return
+	}
+
+	if canonical := UpstreamNodeAddress(host, port); canonical != address {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Upstream Node Address",
+			fmt.Sprintf("The node address %q isn't in the form APISIX returns it, use %q instead.", address, canonical),
+		)
+	}
+}
+
+func UpstreamNodesFromTerraformToAPI(ctx context.Context, terraformDataModel map[string]UpstreamNodeType) (apiDataModel *[]api_client.UpstreamNodeType) {
 	if terraformDataModel == nil {
 		tflog.Debug(ctx, "Can't transform upstream nodes to api model")
 		return
 	}
 
+	// Send the nodes in the order of their addresses, so the requests are stable
+	addresses := make([]string, 0, len(terraformDataModel))
+	for address := range terraformDataModel {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
 	var result = []api_client.UpstreamNodeType{}
 
-	for _, v := range *terraformDataModel {
+	for _, address := range addresses {
+		host, port, err := SplitUpstreamNodeAddress(address)
+		if err != nil {
+			tflog.Error(ctx, "Can't transform the upstream node address to api model", map[string]interface{}{"error": err.Error()})
+			continue
+		}
+
 		result = append(result, api_client.UpstreamNodeType{
-			Host:   v.Host.ValueString(),
-			Port:   v.Port.ValueInt64(),
-			Weight: v.Weight.ValueInt64()})
+			Host:   host,
+			Port:   port,
+			Weight: terraformDataModel[address].Weight.ValueInt64()})
 	}
 	return &result
 }
 
-func UpstreamNodesFromApiToTerraform(ctx context.Context, apiDataModel *[]api_client.UpstreamNodeType) (terraformDataModel *[]UpstreamNodeType) {
+func UpstreamNodesFromApiToTerraform(ctx context.Context, apiDataModel *[]api_client.UpstreamNodeType) (terraformDataModel map[string]UpstreamNodeType) {
 	if apiDataModel == nil {
 		tflog.Debug(ctx, "Can't transform upstream nodes to terraform model")
 		return
 	}
 
-	var result = []UpstreamNodeType{}
+	var result = map[string]UpstreamNodeType{}
 
 	for _, v := range *apiDataModel {
-		result = append(result, UpstreamNodeType{
-			Host:   types.StringValue(v.Host),
-			Port:   types.Int64Value(int64(v.Port)),
+		result[UpstreamNodeAddress(v.Host, v.Port)] = UpstreamNodeType{
 			Weight: types.Int64Value(int64(v.Weight)),
+		}
+	}
+	return result
+}
+
+// UpstreamNodeAddress returns the `host:port` address keying a node, with
+// the IPv6 hosts compressed.
+func UpstreamNodeAddress(host string, port int64) string {
+	if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+		host = ip.String()
+	}
+
+	return net.JoinHostPort(host, strconv.FormatInt(port, 10))
+}
+
+// SplitUpstreamNodeAddress splits the `host:port` address keying a node.
+func SplitUpstreamNodeAddress(address string) (string, int64, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid node address %q: %w", address, err)
+	}
+
+	portNumber, err := strconv.ParseInt(port, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port in the node address %q: %w", address, err)
+	}
+
+	return host, portNumber, nil
+}
+
+// UpstreamNodesListToMap upgrades the nodes held as a list of objects with
+// the `host`, `port` and `weight` attributes to the map keyed by their
+// address. The attribute is given by its path.
+func UpstreamNodesListToMap(attributePath ...string) StateUpgradeStep {
+	return func(state map[string]interface{}) error {
+		return upgradeAttributeAt(state, attributePath, func(value interface{}) (interface{}, error) {
+			nodes, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected a list, got %T", value)
+			}
+
+			result := make(map[string]interface{}, len(nodes))
+			for i, item := range nodes {
+				node, ok := item.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("expected an object in the node %d, got %T", i, item)
+				}
+
+				host, _ := node["host"].(string)
+				port, ok := node["port"].(json.Number)
+				if host == "" || !ok {
+					return nil, fmt.Errorf("expected a host and a port in the node %d", i)
+				}
+				portNumber, err := port.Int64()
+				if err != nil {
+					return nil, fmt.Errorf("expected an integer port in the node %d, got %s", i, port)
+				}
+
+				result[UpstreamNodeAddress(host, portNumber)] = map[string]interface{}{
+					"weight": node["weight"],
+				}
+			}
+
+			return result, nil
 		})
 	}
-	return &result
 }
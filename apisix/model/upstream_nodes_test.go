@@ -0,0 +1,54 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUpstreamNodeAddressValidator(t *testing.T) {
+	testCases := map[string]bool{
+		"127.0.0.1:80":              true,
+		"checkout.internal:8080":    true,
+		"[::1]:1980":                true,
+		"[fd00::1]:443":             true,
+		"checkout.internal:080":     false,
+		"[0:0:0:0:0:0:0:1]:1980":    false,
+		"[FD00::1]:443":             false,
+		"[fd00:0:0:0:0:0:0:1]:8443": false,
+		// Reported by the pattern
+		"checkout.internal": true,
+	}
+
+	for address, valid := range testCases {
+		t.Run(address, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			upstreamNodeAddressValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("nodes").AtMapKey(address),
+				ConfigValue: types.StringValue(address),
+			}, resp)
+
+			if resp.Diagnostics.HasError() == valid {
+				t.Errorf("expected the address to be valid: %t, got %v", valid, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestUpstreamNodesListToMap(t *testing.T) {
+	upgraded, err := UpgradeRawState(
+		[]byte(`{"nodes":[{"host":"0:0:0:0:0:0:0:1","port":1980,"weight":1},{"host":"127.0.0.1","port":80,"weight":2}]}`),
+		[]StateUpgradeStep{UpstreamNodesListToMap("nodes")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"nodes":{"127.0.0.1:80":{"weight":2},"[::1]:1980":{"weight":1}}}`
+	if string(upgraded) != expected {
+		t.Errorf("expected %s, got %s", expected, upgraded)
+	}
+}
@@ -10,9 +10,11 @@ import (
 )
 
 type UpstreamTLSType struct {
-	ClientCertID types.String `tfsdk:"client_cert_id"`
-	ClientCert   types.String `tfsdk:"client_cert"`
-	ClientKey    types.String `tfsdk:"client_key"`
+	ClientCertID       types.String `tfsdk:"client_cert_id"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	ClientKeyWO        types.String `tfsdk:"client_key_wo"`
+	ClientKeyWOVersion types.Int64  `tfsdk:"client_key_wo_version"`
 }
 
 var UpstreamTLSSchemaAttribute = schema.SingleNestedAttribute{
@@ -33,6 +35,8 @@ var UpstreamTLSSchemaAttribute = schema.SingleNestedAttribute{
 			Optional:            true,
 			Sensitive:           true,
 		},
+		"client_key_wo":         WriteOnlySchemaAttribute("client_key", "client_cert_id"),
+		"client_key_wo_version": WriteOnlyVersionSchemaAttribute("client_key"),
 	},
 }
 
@@ -45,7 +49,7 @@ func UpstreamTLSFromTerraformToAPI(ctx context.Context, terraformDataModel *Upst
 	result := &api_client.UpstreamTLSType{
 		ClientCertID: terraformDataModel.ClientCertID.ValueStringPointer(),
 		ClientCert:   terraformDataModel.ClientCert.ValueStringPointer(),
-		ClientKey:    terraformDataModel.ClientKey.ValueStringPointer(),
+		ClientKey:    WriteOnlyValue(terraformDataModel.ClientKey, terraformDataModel.ClientKeyWO).ValueStringPointer(),
 	}
 
 	return result
@@ -65,3 +69,21 @@ func UpstreamTLSFromAPIToTerraform(ctx context.Context, apiDataModel *api_client
 
 	return result
 }
+
+// setWriteOnly sets the write-only client key of the configuration.
+func (t *UpstreamTLSType) setWriteOnly(config *UpstreamTLSType) {
+	if t == nil || config == nil {
+		return
+	}
+
+	t.ClientKeyWO = config.ClientKeyWO
+}
+
+// keepWriteOnly keeps the client key sent with client_key_wo out of the state.
+func (t *UpstreamTLSType) keepWriteOnly(prior *UpstreamTLSType) {
+	if t == nil || prior == nil {
+		return
+	}
+
+	keepWriteOnly(&t.ClientKey, &t.ClientKeyWOVersion, prior.ClientKeyWOVersion)
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// WriteOnlySchemaAttribute returns the `<name>_wo` write-only variant of the
+// secret attribute with the given name. Its value is sent to APISIX but never
+// stored in the state, so it is only sent again when `<name>_wo_version`
+// changes. The conflicting attributes, like `tls.client_cert_id`, are given
+// by their names in the same object.
+func WriteOnlySchemaAttribute(name string, conflictingNames ...string) schema.StringAttribute {
+	conflicting := []path.Expression{path.MatchRelative().AtParent().AtName(name)}
+	for _, conflictingName := range conflictingNames {
+		conflicting = append(conflicting, path.MatchRelative().AtParent().AtName(conflictingName))
+	}
+
+	return schema.StringAttribute{
+		MarkdownDescription: "Write-only variant of `" + name + "`, sent to APISIX but never stored in the state. Requires Terraform 1.11 or later, " +
+			"and `" + name + "_wo_version` to be set: bump it to send a new value.",
+		Optional:  true,
+		Sensitive: true,
+		WriteOnly: true,
+		Validators: []validator.String{
+			stringvalidator.ConflictsWith(conflicting...),
+			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName(name + "_wo_version")),
+		},
+	}
+}
+
+// WriteOnlyVersionSchemaAttribute returns the `<name>_wo_version` attribute
+// triggering the update of the `<name>_wo` write-only attribute.
+func WriteOnlyVersionSchemaAttribute(name string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: "Version of `" + name + "_wo`. Changing it sends the current value of `" + name + "_wo` to APISIX.",
+		Optional:            true,
+		Validators: []validator.Int64{
+			int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName(name + "_wo")),
+		},
+	}
+}
+
+// WriteOnlyValue returns the value of a secret configured either with its
+// attribute or with its write-only variant. The write-only values are only
+// available in the configuration.
+func WriteOnlyValue(value types.String, writeOnly types.String) types.String {
+	if !writeOnly.IsNull() {
+		return writeOnly
+	}
+
+	return value
+}
+
+// keepWriteOnly keeps the version of a write-only secret from the plan or the
+// prior state, as APISIX doesn't know it, and the secret sent with the
+// write-only variant out of the state.
+func keepWriteOnly(value *types.String, version *types.Int64, priorVersion types.Int64) {
+	*version = priorVersion
+	if !priorVersion.IsNull() {
+		*value = types.StringNull()
+	}
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+func TestSecretWriteOnly(t *testing.T) {
+	ctx := context.Background()
+
+	// The plan holds null for the write-only attributes, their values are only in the configuration
+	plan := SecretResourceModel{
+		ID: types.StringValue("1"),
+		Vault: &SecretVaultType{
+			Uri:            types.StringValue("http://127.0.0.1:8200"),
+			Prefix:         types.StringValue("kv/apisix"),
+			Token:          types.StringNull(),
+			TokenWO:        types.StringNull(),
+			TokenWOVersion: types.Int64Value(2),
+		},
+	}
+	config := plan
+	configVault := *plan.Vault
+	configVault.TokenWO = types.StringValue("root")
+	config.Vault = &configVault
+
+	plan.SetWriteOnly(config)
+	_, secret := SecretFromTerraformToApi(ctx, &plan)
+	if vault, ok := secret.(*api_client.VaultSecret); !ok || vault.Token == nil || *vault.Token != "root" {
+		t.Fatalf("expected the write-only token to be sent, got %+v", secret)
+	}
+
+	// APISIX returns the token it was sent
+	newState := plan
+	newVault := *plan.Vault
+	newVault.Token = types.StringValue("root")
+	newVault.TokenWO = types.StringNull()
+	newVault.TokenWOVersion = types.Int64Null()
+	newState.Vault = &newVault
+
+	newState.KeepWriteOnly(plan)
+	if !newState.Vault.Token.IsNull() {
+		t.Errorf("expected the write-only token to be kept out of the state, got %s", newState.Vault.Token)
+	}
+	if newState.Vault.TokenWOVersion.ValueInt64() != 2 {
+		t.Errorf("expected the version of the token to be kept, got %s", newState.Vault.TokenWOVersion)
+	}
+}
+
+func TestSecretWithoutWriteOnly(t *testing.T) {
+	prior := SecretResourceModel{
+		Vault: &SecretVaultType{
+			Token:          types.StringValue("root"),
+			TokenWOVersion: types.Int64Null(),
+		},
+	}
+	newState := SecretResourceModel{
+		Vault: &SecretVaultType{
+			Token:          types.StringValue("root"),
+			TokenWOVersion: types.Int64Null(),
+		},
+	}
+
+	newState.KeepWriteOnly(prior)
+	if newState.Vault.Token.ValueString() != "root" {
+		t.Errorf("expected the token to be kept in the state, got %s", newState.Vault.Token)
+	}
+}
+
+func TestUpstreamWriteOnly(t *testing.T) {
+	plan := UpstreamResourceModel{
+		TLS: &UpstreamTLSType{
+			ClientCert:         types.StringValue("cert"),
+			ClientKey:          types.StringNull(),
+			ClientKeyWOVersion: types.Int64Value(1),
+		},
+	}
+	config := UpstreamResourceModel{
+		TLS: &UpstreamTLSType{
+			ClientKeyWO: types.StringValue("key"),
+		},
+	}
+
+	plan.SetWriteOnly(config)
+	if tls := UpstreamTLSFromTerraformToAPI(context.Background(), plan.TLS); tls == nil || tls.ClientKey == nil || *tls.ClientKey != "key" {
+		t.Fatalf("expected the write-only client key to be sent, got %+v", tls)
+	}
+
+	newState := UpstreamResourceModel{
+		TLS: &UpstreamTLSType{
+			ClientCert: types.StringValue("cert"),
+			ClientKey:  plan.TLS.ClientKey,
+		},
+	}
+	newState.KeepWriteOnly(plan)
+	if !newState.TLS.ClientKey.IsNull() || newState.TLS.ClientKeyWOVersion.ValueInt64() != 1 {
+		t.Errorf("expected only the version of the client key in the state, got %+v", newState.TLS)
+	}
+
+	// Without TLS, nothing is kept
+	(&UpstreamResourceModel{}).KeepWriteOnly(plan)
+}
@@ -0,0 +1,209 @@
+package apisix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apisixProviderType is the type of the APISIX providers, the last part of
+// their address, such as `registry.terraform.io/example/apisix`.
+const apisixProviderType = "apisix"
+
+// stateMoveTranslation translates the raw state of a resource of another
+// APISIX provider to the shape of the resource of this provider. The state is
+// decoded with json.Number, so the numbers are kept as they are.
+type stateMoveTranslation func(state map[string]interface{}) error
+
+// moveStateFrom returns the state mover of the resources of the other APISIX
+// providers with the given type names, without the provider prefix, such as
+// `route` for `apisix_route`. The source state is translated by the given
+// translations, and the attributes this provider doesn't have are dropped.
+//
+// The other providers model the objects on the Admin API, so their attributes
+// mostly have the names and shapes of this provider. The differences are
+// translated: the plugins and the vars as objects and lists rather than JSON
+// strings, the upstream nodes as a `host:port` to weight map, the SSL
+// certificate `cert` and `key`, and the status as a boolean.
+func moveStateFrom(targetSchema schema.Schema, sourceTypeNames []string, translations ...stateMoveTranslation) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				// Only the resources of the other APISIX providers are moved
+				providerType := req.SourceProviderAddress[strings.LastIndex(req.SourceProviderAddress, "/")+1:]
+				sourceTypeName, ok := strings.CutPrefix(req.SourceTypeName, apisixProviderType+"_")
+				if providerType != apisixProviderType || !ok || req.SourceRawState == nil || !slices.Contains(sourceTypeNames, sourceTypeName) {
+					return
+				}
+
+				tflog.Debug(ctx, "Moving the state of "+req.SourceTypeName+" from "+req.SourceProviderAddress)
+
+				state, err := translateMovedState(req.SourceRawState.JSON, translations)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Error Moving State",
+						fmt.Sprintf("Could not move the state of %s from %s: %s", req.SourceTypeName, req.SourceProviderAddress, err),
+					)
+					return
+				}
+
+				value, err := tftypes.ValueFromJSONWithOpts(state, targetSchema.Type().TerraformType(ctx), tftypes.ValueFromJSONOpts{
+					IgnoreUndefinedAttributes: true,
+				})
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Error Moving State",
+						fmt.Sprintf("Could not move the state of %s from %s, its attributes don't match this provider: %s", req.SourceTypeName, req.SourceProviderAddress, err),
+					)
+					return
+				}
+				resp.TargetState.Raw = value
+
+				// Identify the object by the attributes of the identity
+				if resp.TargetIdentity == nil {
+					return
+				}
+				for name := range resp.TargetIdentity.Schema.GetAttributes() {
+					var attribute types.String
+					resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root(name), &attribute)...)
+					resp.Diagnostics.Append(resp.TargetIdentity.SetAttribute(ctx, path.Root(name), attribute)...)
+				}
+			},
+		},
+	}
+}
+
+// translateMovedState runs the translations on the raw source state. The
+// attributes only this provider has, such as `adopt_existing`, are left null
+// like when they aren't configured, so the first plan after the move shows no
+// changes.
+func translateMovedState(rawState []byte, translations []stateMoveTranslation) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawState))
+	decoder.UseNumber()
+
+	var state map[string]interface{}
+	if err := decoder.Decode(&state); err != nil {
+		return nil, fmt.Errorf("could not decode the state: %w", err)
+	}
+
+	for _, translation := range translations {
+		if err := translation(state); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(state)
+}
+
+// moveJSONStrings translates the attributes holding objects or lists, where
+// this provider holds them as JSON strings, such as `plugins` and `vars`. The
+// dynamic attributes are encoded with their type in the state, which is
+// dropped.
+func moveJSONStrings(names ...string) stateMoveTranslation {
+	return func(state map[string]interface{}) error {
+		for _, name := range names {
+			value := state[name]
+			if object, ok := value.(map[string]interface{}); ok && len(object) == 2 && object["type"] != nil {
+				if dynamicValue, ok := object["value"]; ok {
+					value = dynamicValue
+				}
+			}
+
+			switch value.(type) {
+			case nil, string:
+				continue
+			}
+
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("could not encode the %s attribute: %w", name, err)
+			}
+			state[name] = string(encoded)
+		}
+
+		return nil
+	}
+}
+
+// moveStatus translates the status held as a boolean to the 1 and 0 of the
+// Admin API.
+func moveStatus(state map[string]interface{}) error {
+	if enabled, ok := state["status"].(bool); ok {
+		state["status"] = 0
+		if enabled {
+			state["status"] = 1
+		}
+	}
+
+	return nil
+}
+
+// moveUpstreamNodes translates the nodes held as a `host:port` to weight map
+// or as a list of objects to the nodes keyed by their address. The nodes
+// without a port get the default port of the upstream scheme.
+func moveUpstreamNodes(state map[string]interface{}) error {
+	if _, ok := state["nodes"].([]interface{}); ok {
+		return model.UpstreamNodesListToMap("nodes")(state)
+	}
+
+	nodes, ok := state["nodes"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	defaultPort := "80"
+	if scheme, _ := state["scheme"].(string); scheme == "https" || scheme == "grpcs" {
+		defaultPort = "443"
+	}
+
+	result := make(map[string]interface{}, len(nodes))
+	for address, weight := range nodes {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(strings.Trim(address, "[]"), defaultPort)
+		}
+
+		result[address] = map[string]interface{}{"weight": weight}
+	}
+	state["nodes"] = result
+
+	return nil
+}
+
+// moveSSLCertificate translates the `cert`, `key` and `sni` attributes of the
+// SSL certificates.
+func moveSSLCertificate(state map[string]interface{}) error {
+	for source, target := range map[string]string{"cert": "certificate", "key": "private_key"} {
+		if value, ok := state[source]; ok && state[target] == nil {
+			state[target] = value
+		}
+	}
+
+	if sni, ok := state["sni"].(string); ok && state["snis"] == nil {
+		state["snis"] = []interface{}{sni}
+	}
+
+	return nil
+}
+
+// moveWithoutInlineUpstream fails the moves of the routes and services with
+// an inline upstream, which this provider manages as an apisix_upstream.
+func moveWithoutInlineUpstream(state map[string]interface{}) error {
+	if upstream, ok := state["upstream"]; ok && upstream != nil {
+		return fmt.Errorf("the inline upstream isn't supported. Move it to an apisix_upstream referenced by upstream_id first")
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package apisix
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-apisix/apisix/model"
+)
+
+const moveStateSourceProvider = "registry.terraform.io/example/apisix"
+
+// moveState moves the fixture state of a resource of another provider with
+// the state movers of the resource, as Terraform does for a moved block.
+func moveState(t *testing.T, r resource.Resource, targetSchema schema.Schema, identitySchema identityschema.Schema, sourceTypeName string, fixture string) *resource.MoveStateResponse {
+	t.Helper()
+
+	return moveStateFromProvider(t, r, targetSchema, identitySchema, moveStateSourceProvider, sourceTypeName, fixture)
+}
+
+func moveStateFromProvider(t *testing.T, r resource.Resource, targetSchema schema.Schema, identitySchema identityschema.Schema, sourceProvider string, sourceTypeName string, fixture string) *resource.MoveStateResponse {
+	t.Helper()
+
+	rawState, err := os.ReadFile(filepath.Join("testdata", "move_state", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	resp := &resource.MoveStateResponse{
+		TargetState: tfsdk.State{
+			Schema: targetSchema,
+			Raw:    tftypes.NewValue(targetSchema.Type().TerraformType(ctx), nil),
+		},
+		TargetIdentity: &tfsdk.ResourceIdentity{
+			Schema: identitySchema,
+			Raw:    tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	for _, mover := range r.(resource.ResourceWithMoveState).MoveState(ctx) {
+		mover.StateMover(ctx, resource.MoveStateRequest{
+			SourceProviderAddress: sourceProvider,
+			SourceTypeName:        sourceTypeName,
+			SourceRawState:        &tfprotov6.RawState{JSON: rawState},
+		}, resp)
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			break
+		}
+	}
+
+	return resp
+}
+
+func TestMoveStateRoute(t *testing.T) {
+	resp := moveState(t, NewRouteResource(), model.RouteSchema, model.ObjectIdentitySchema, "apisix_route", "route.json")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state model.RouteResourceModel
+	if diags := resp.TargetState.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("could not read the moved state: %v", diags)
+	}
+
+	if state.ID.ValueString() != "1" || state.Name.ValueString() != "checkout" || state.UpstreamId.ValueString() != "100" {
+		t.Errorf("unexpected attributes: %+v", state)
+	}
+	if state.Plugins.ValueString() != `{"limit-count":{"count":2,"time_window":60}}` {
+		t.Errorf("expected the plugins as a JSON string, got %s", state.Plugins)
+	}
+	if state.Vars.ValueString() != `[["http_user","==","ios"]]` {
+		t.Errorf("expected the vars as a JSON string, got %s", state.Vars)
+	}
+	if state.Status.ValueInt64() != 1 || state.Priority.ValueInt64() != 10 || state.Timeout.Read.ValueInt64() != 3 {
+		t.Errorf("unexpected attributes: %+v", state)
+	}
+	if !state.AdoptExisting.IsNull() || !state.IgnoreConcurrentChanges.IsNull() {
+		t.Errorf("expected the attributes of this provider to be left unset, got %s and %s", state.AdoptExisting, state.IgnoreConcurrentChanges)
+	}
+
+	var identity model.ObjectIdentityModel
+	if diags := resp.TargetIdentity.Get(context.Background(), &identity); diags.HasError() || identity.ID.ValueString() != "1" {
+		t.Errorf("expected the identity of the route, got %s (%v)", identity.ID, diags)
+	}
+}
+
+func TestMoveStateOtherProvider(t *testing.T) {
+	testCases := map[string]struct {
+		sourceProvider string
+		sourceTypeName string
+	}{
+		"other provider":          {sourceProvider: "registry.terraform.io/hashicorp/aws", sourceTypeName: "aws_route"},
+		"other provider resource": {sourceProvider: "registry.terraform.io/hashicorp/aws", sourceTypeName: "apisix_route"},
+		"other type prefix":       {sourceProvider: moveStateSourceProvider, sourceTypeName: "other_route"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := moveStateFromProvider(t, NewRouteResource(), model.RouteSchema, model.ObjectIdentitySchema, testCase.sourceProvider, testCase.sourceTypeName, "route.json")
+
+			if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+				t.Errorf("expected the state not to be moved, got %s (%v)", resp.TargetState.Raw, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMoveStateRouteInlineUpstream(t *testing.T) {
+	resp := moveState(t, NewRouteResource(), model.RouteSchema, model.ObjectIdentitySchema, "apisix_route", "route_inline_upstream.json")
+
+	if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "the inline upstream isn't supported") {
+		t.Errorf("expected the inline upstream error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestMoveStateUpstream(t *testing.T) {
+	resp := moveState(t, NewUpstreamResource(), model.UpstreamSchema, model.ObjectIdentitySchema, "apisix_upstream", "upstream.json")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state model.UpstreamResourceModel
+	if diags := resp.TargetState.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("could not read the moved state: %v", diags)
+	}
+
+	expected := map[string]int64{"10.0.0.1:8443": 1, "10.0.0.2:8443": 2, "[fd00::1]:8443": 1, "checkout.internal:443": 1}
+	if len(state.Nodes) != len(expected) {
+		t.Errorf("expected the nodes %v, got %v", expected, state.Nodes)
+	}
+	for address, weight := range expected {
+		if node, ok := state.Nodes[address]; !ok || node.Weight.ValueInt64() != weight {
+			t.Errorf("expected the node %s with the weight %d, got %v", address, weight, state.Nodes)
+		}
+	}
+	if state.KeepalivePool == nil || state.KeepalivePool.Size.ValueInt64() != 320 {
+		t.Errorf("expected the keepalive pool, got %+v", state.KeepalivePool)
+	}
+	if !state.ForceDelete.IsNull() {
+		t.Errorf("expected force_delete to be left unset, got %s", state.ForceDelete)
+	}
+}
+
+func TestMoveStateService(t *testing.T) {
+	resp := moveState(t, NewServiceResource(), model.ServiceSchema, model.ObjectIdentitySchema, "apisix_service", "service.json")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state model.ServiceResourceModel
+	if diags := resp.TargetState.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("could not read the moved state: %v", diags)
+	}
+
+	if state.Plugins.ValueString() != `{"cors":{}}` {
+		t.Errorf("expected the dynamic plugins as a JSON string, got %s", state.Plugins)
+	}
+}
+
+func TestMoveStateConsumer(t *testing.T) {
+	resp := moveState(t, NewConsumerResource(), model.ConsumerSchema, model.ConsumerIdentitySchema, "apisix_consumer", "consumer.json")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state model.ConsumerResourceModel
+	if diags := resp.TargetState.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("could not read the moved state: %v", diags)
+	}
+
+	if state.Username.ValueString() != "jack" || state.Plugins.ValueString() != `{"key-auth":{"key":"auth-one"}}` || state.GroupId.ValueString() != "company_a" {
+		t.Errorf("unexpected attributes: %+v", state)
+	}
+
+	var identity model.ConsumerIdentityModel
+	if diags := resp.TargetIdentity.Get(context.Background(), &identity); diags.HasError() || identity.Username.ValueString() != "jack" {
+		t.Errorf("expected the identity of the consumer, got %s (%v)", identity.Username, diags)
+	}
+}
+
+func TestMoveStateSSLCertificate(t *testing.T) {
+	for _, sourceTypeName := range []string{"apisix_ssl", "apisix_ssl_certificate"} {
+		t.Run(sourceTypeName, func(t *testing.T) {
+			resp := moveState(t, NewSSLCertificateResource(), model.SSLCertificateSchema, model.ObjectIdentitySchema, sourceTypeName, "ssl.json")
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var state model.SSLCertificateResourceModel
+			if diags := resp.TargetState.Get(context.Background(), &state); diags.HasError() {
+				t.Fatalf("could not read the moved state: %v", diags)
+			}
+
+			if !strings.Contains(state.Certificate.ValueString(), "BEGIN CERTIFICATE") || !strings.Contains(state.PrivateKey.ValueString(), "BEGIN PRIVATE KEY") {
+				t.Errorf("expected the certificate and the key, got %+v", state)
+			}
+			if state.Snis.String() != `["shop.example.com"]` || state.Status.ValueInt64() != 0 {
+				t.Errorf("expected the SNI and the disabled status, got %+v", state)
+			}
+		})
+	}
+}
+
+func TestMoveStateGlobalRule(t *testing.T) {
+	resp := moveState(t, NewGlobalRuleResource(), model.GlobalRuleSchema, model.ObjectIdentitySchema, "apisix_global_rule", "global_rule.json")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state model.GlobalRuleResourceModel
+	if diags := resp.TargetState.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("could not read the moved state: %v", diags)
+	}
+
+	if state.ID.ValueString() != "1" || state.Plugins.ValueString() != `{"prometheus":{"prefer_name":true}}` {
+		t.Errorf("unexpected attributes: %+v", state)
+	}
+}
+
+func TestMoveStateOtherResourceType(t *testing.T) {
+	// A stream route isn't a route
+	resp := moveState(t, NewRouteResource(), model.RouteSchema, model.ObjectIdentitySchema, "apisix_stream_route", "route.json")
+
+	if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+		t.Errorf("expected the state to be left to the other movers, got %v", resp.Diagnostics)
+	}
+}
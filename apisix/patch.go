@@ -0,0 +1,120 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	updateStrategyPut   = "put"
+	updateStrategyPatch = "patch"
+)
+
+// resolveUpdateStrategy returns the update strategy of a resource, which
+// overrides the provider-wide one when set.
+func (c *apisixClient) resolveUpdateStrategy(resourceStrategy types.String) string {
+	if !resourceStrategy.IsNull() && !resourceStrategy.IsUnknown() && resourceStrategy.ValueString() != "" {
+		return resourceStrategy.ValueString()
+	}
+
+	if c.updateStrategy != "" {
+		return c.updateStrategy
+	}
+
+	return updateStrategyPut
+}
+
+// updateObject writes the planned object to APISIX and returns it as APISIX
+// stored it. With the put strategy it calls put, which replaces the whole
+// object. With the patch strategy only the changes between the prior and the
+// planned object are sent in a PATCH request, so the fields the provider
+// doesn't manage are preserved. The PUT and PATCH responses hold the whole
+// object, get only reads it when there is nothing to patch.
+func updateObject[T any](ctx context.Context, c *apisixClient, resourceStrategy types.String, objectPath string, prior, planned any, put func() (*T, error), get func() (*T, error)) (*T, error) {
+	if c.resolveUpdateStrategy(resourceStrategy) != updateStrategyPatch {
+		return put()
+	}
+
+	patch, err := mergePatch(prior, planned)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(patch) == 0 {
+		tflog.Debug(ctx, "Nothing to patch", map[string]any{"path": objectPath})
+		return get()
+	}
+
+	var updated T
+	if err := c.adminRequest(ctx, http.MethodPatch, objectPath, patch, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// mergePatch returns the JSON merge patch (RFC 7386) turning the prior object
+// into the planned one. Both are compared in their JSON form. The fields
+// missing from the planned object are set to null, which makes APISIX remove
+// them. Arrays are replaced as a whole.
+func mergePatch(prior, planned any) (map[string]interface{}, error) {
+	priorFields, err := jsonObject(prior)
+	if err != nil {
+		return nil, err
+	}
+
+	plannedFields, err := jsonObject(planned)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffObjects(priorFields, plannedFields), nil
+}
+
+// jsonObject converts an API request body into a generic JSON object.
+func jsonObject(object any) (map[string]interface{}, error) {
+	body, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func diffObjects(prior, planned map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+
+	for key, priorValue := range prior {
+		if _, ok := planned[key]; !ok && priorValue != nil {
+			patch[key] = nil
+		}
+	}
+
+	for key, plannedValue := range planned {
+		priorValue := prior[key]
+		if reflect.DeepEqual(priorValue, plannedValue) {
+			continue
+		}
+
+		priorObject, priorIsObject := priorValue.(map[string]interface{})
+		plannedObject, plannedIsObject := plannedValue.(map[string]interface{})
+		if priorIsObject && plannedIsObject {
+			patch[key] = diffObjects(priorObject, plannedObject)
+			continue
+		}
+
+		patch[key] = plannedValue
+	}
+
+	return patch
+}
@@ -0,0 +1,121 @@
+package apisix
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+func TestMergePatch(t *testing.T) {
+	prior := map[string]interface{}{
+		"id":      "1",
+		"desc":    "Checkout API",
+		"uri":     "/checkout",
+		"hosts":   []string{"a.example.com", "b.example.com"},
+		"labels":  map[string]string{"team": "payments", "tier": "gold"},
+		"plugins": map[string]interface{}{"ip-restriction": map[string]interface{}{"whitelist": []string{"10.0.0.0/8"}}},
+	}
+	planned := map[string]interface{}{
+		"id":     "1",
+		"uri":    "/checkout",
+		"hosts":  []string{"a.example.com"},
+		"labels": map[string]string{"team": "payments", "tier": "silver"},
+		"plugins": map[string]interface{}{
+			"ip-restriction": nil,
+			"limit-count":    map[string]interface{}{"count": 10},
+		},
+	}
+
+	patch, err := mergePatch(prior, planned)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"desc":   nil,
+		"hosts":  []interface{}{"a.example.com"},
+		"labels": map[string]interface{}{"tier": "silver"},
+		"plugins": map[string]interface{}{
+			"ip-restriction": nil,
+			"limit-count":    map[string]interface{}{"count": float64(10)},
+		},
+	}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Errorf("expected %v, got %v", expected, patch)
+	}
+
+	patch, err = mergePatch(prior, prior)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(patch) != 0 {
+		t.Errorf("expected an empty patch, got %v", patch)
+	}
+}
+
+func TestMergePatchRemovedAttributes(t *testing.T) {
+	prior := map[string]interface{}{
+		"desc":           "Example",
+		"labels":         map[string]string{"version": "v1", "team": "payments"},
+		"timeout":        map[string]int{"connect": 3, "send": 3, "read": 3},
+		"keepalive_pool": map[string]int{"idle_timeout": 5, "requests": 10, "size": 15},
+	}
+	planned := map[string]interface{}{
+		"labels": map[string]string{"version": "v1"},
+	}
+
+	patch, err := mergePatch(prior, planned)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"desc":           nil,
+		"labels":         map[string]interface{}{"team": nil},
+		"timeout":        nil,
+		"keepalive_pool": nil,
+	}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Errorf("expected %v, got %v", expected, patch)
+	}
+}
+
+func TestUpdateObjectPatch(t *testing.T) {
+	server := newFakeAdminServer(t)
+	server.setObject("routes/1", `{"id":"1","uri":"/api","desc":"kept"}`)
+	client := server.client()
+
+	uri, patchedURI := "/api", "/patched"
+	get := func() (*api_client.Route, error) {
+		return client.GetRoute("1")
+	}
+	put := func() (*api_client.Route, error) {
+		t.Fatal("expected the patch strategy to send a PATCH request")
+		return nil, nil
+	}
+
+	// The PATCH response holds the updated route
+	route, err := updateObject(context.Background(), client, types.StringValue(updateStrategyPatch), "routes/1",
+		api_client.Route{URI: &uri}, api_client.Route{URI: &patchedURI}, put, get)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *route.URI != "/patched" || *route.Description != "kept" {
+		t.Errorf("expected the patched route, got %+v", route)
+	}
+	if requests := server.requests(); len(requests) != 1 || requests[0] != "PATCH /apisix/admin/routes/1" {
+		t.Errorf("expected a single PATCH request, got %v", requests)
+	}
+
+	// Without changes, the route is only read
+	if _, err := updateObject(context.Background(), client, types.StringValue(updateStrategyPatch), "routes/1",
+		api_client.Route{URI: &patchedURI}, api_client.Route{URI: &patchedURI}, put, get); err != nil {
+		t.Fatal(err)
+	}
+	if requests := server.requests(); len(requests) != 2 || requests[1] != "GET /apisix/admin/routes/1" {
+		t.Errorf("expected a single GET request, got %v", requests)
+	}
+}
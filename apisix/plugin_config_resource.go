@@ -4,21 +4,24 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/holubovskyi/apisix-client-go"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &pluginConfigResource{}
-	_ resource.ResourceWithConfigure   = &pluginConfigResource{}
-	_ resource.ResourceWithImportState = &pluginConfigResource{}
+	_ resource.Resource                   = &pluginConfigResource{}
+	_ resource.ResourceWithConfigure      = &pluginConfigResource{}
+	_ resource.ResourceWithImportState    = &pluginConfigResource{}
+	_ resource.ResourceWithIdentity       = &pluginConfigResource{}
+	_ resource.ResourceWithUpgradeState   = &pluginConfigResource{}
+	_ resource.ResourceWithValidateConfig = &pluginConfigResource{}
+	_ resource.ResourceWithModifyPlan     = &pluginConfigResource{}
 )
 
 // NewPluginConfigResource is a helper function to simplify the provider implementation.
@@ -28,7 +31,7 @@ func NewPluginConfigResource() resource.Resource {
 
 // pluginConfigResource is the resource implementation.
 type pluginConfigResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -41,18 +44,49 @@ func (r *pluginConfigResource) Schema(_ context.Context, _ resource.SchemaReques
 	resp.Schema = model.PluginConfigSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *pluginConfigResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.ObjectIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *pluginConfigResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.PluginConfigStateUpgrades)
+}
+
+// Implement config validation
+func (r *pluginConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Reject the unknown plugins and the plugin configurations not matching their JSON schema
+	r.client.checkPluginSchemas(ctx, req.Config, "", &resp.Diagnostics)
+}
+
+// Implement plan modification
+func (r *pluginConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_plugin_config", req.Plan, &resp.Diagnostics)
+
+	// Expect a new update time for the plugin configs about to be updated
+	planUpdateTime(ctx, req, resp)
+
+	// Make sure the objects referenced in the plugins exist before anything is applied
+	r.client.checkPluginReferences(ctx, resp.Plan, &resp.Diagnostics)
+
+	// Let the references to the new plugin configs pass before they are created
+	r.client.registerPlannedObject(ctx, "plugin_configs", req, resp, path.Root("id"))
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *pluginConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -71,13 +105,48 @@ func (r *pluginConfigResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Plugin Config", plan.ID, createTimeout)
+
 	// Generate API request body from plan
 	newPluginConfigRequest := model.PluginConfigFromTerraformToApi(ctx, &plan)
 
+	// Keep the other resources writing the plugin config waiting until it's created
+	unlock := client.lockObject(ctx, "Plugin Config", "plugin_configs/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing plugin config when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetPluginConfig(plan.ID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Plugin Config",
+			"Could not read APISIX Plugin Config by ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Plugin Config", plan.ID.ValueString(), existing, newPluginConfigRequest)
+		} else if !sameObject(existing, newPluginConfigRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "Plugin Config", plan.ID.ValueString())
+			return
+		}
+	}
+
 	// Create new plugin config
-	newPluginConfigResponse, err := r.client.CreatePluginConfig(plan.ID.ValueString(), newPluginConfigRequest)
+	newPluginConfigResponse, err := client.CreatePluginConfig(plan.ID.ValueString(), newPluginConfigRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Plugin Config",
 			"Could not create Plugin Config, unexpected error: "+err.Error(),
 		)
@@ -86,10 +155,22 @@ func (r *pluginConfigResource) Create(ctx context.Context, req resource.CreateRe
 
 	// Map response body to schema and populate Computed attribute values
 	newState := model.PluginConfigFromApiToTerraform(ctx, newPluginConfigResponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("plugin_configs/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins)
 	}
 
+	// Remember the version of the plugin config to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "plugin_configs/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the plugin config by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -110,7 +191,7 @@ func (r *pluginConfigResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Get refreshed plugin config from the APISIX
-	pluginConfigStateResponse, err := r.client.GetPluginConfig(state.ID.ValueString())
+	pluginConfigStateResponse, err := r.client.readClient(ctx, req.Private, &resp.Diagnostics).GetPluginConfig(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Plugin Config",
@@ -121,10 +202,23 @@ func (r *pluginConfigResource) Read(ctx context.Context, req resource.ReadReques
 
 	// Overwrite with refreshed state
 	newState := model.PluginConfigFromApiToTerraform(ctx, pluginConfigStateResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.ForceDelete = state.ForceDelete
+	newState.CreateTime, newState.UpdateTime = r.client.objectTimestamps("plugin_configs/" + state.ID.ValueString())
+	newState.UpdateStrategy = state.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+		// Compare with the plugins of APISIX without the defaults it sets
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins, _ = r.client.readPlugins(ctx, "", newState.Plugins, state.Plugins, state.Plugin, state.SensitivePlugins, types.ObjectNull(nil))
 	}
 
+	// Remember the version of the plugin config to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "plugin_configs/"+state.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the plugin config by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -144,34 +238,74 @@ func (r *pluginConfigResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Plugin Config", plan.ID, updateTimeout)
+
+	// Keep the other resources writing the plugin config waiting until it's updated
+	unlock := client.lockObject(ctx, "Plugin Config", "plugin_configs/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the plugin config wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Plugin Config", "plugin_configs/"+plan.ID.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	updatePluginConfigRequest := model.PluginConfigFromTerraformToApi(ctx, &plan)
 
-	// Update existing plugin config
-	_, err := r.client.UpdatePluginConfig(plan.ID.ValueString(), updatePluginConfigRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating APISIX Plugin Config",
-			"Could not update Plugin Config, unexpected error: "+err.Error(),
-		)
+	// Retrieve values from state, the patch update strategy sends only the changes
+	var state model.PluginConfigResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Fetch updated rule
-	updatedPluginConfig, err := r.client.GetPluginConfig(plan.ID.ValueString())
+	// Generate API request body from state
+	priorPluginConfigRequest := model.PluginConfigFromTerraformToApi(ctx, &state)
+
+	// Update existing plugin config, the response holds the updated plugin config
+	updatedPluginConfig, err := updateObject(ctx, client, plan.UpdateStrategy, "plugin_configs/"+plan.ID.ValueString(), priorPluginConfigRequest, updatePluginConfigRequest, func() (*api_client.PluginConfig, error) {
+		return client.UpdatePluginConfig(plan.ID.ValueString(), updatePluginConfigRequest)
+	}, func() (*api_client.PluginConfig, error) {
+		return client.GetPluginConfig(plan.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Plugin Config",
-			"Could not read APISIX Plugin Config by ID "+plan.ID.ValueString()+": "+err.Error(),
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
+			"Error Updating APISIX Plugin Config",
+			"Could not update Plugin Config, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
 	newState := model.PluginConfigFromApiToTerraform(ctx, updatedPluginConfig)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("plugin_configs/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins)
 	}
 
+	// Remember the version of the plugin config to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "plugin_configs/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the plugin config by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -191,10 +325,35 @@ func (r *pluginConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Plugin Config", state.ID, deleteTimeout)
+
+	// Keep the other resources writing the plugin config waiting until it's deleted
+	unlock := client.lockObject(ctx, "Plugin Config", "plugin_configs/"+state.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the plugin config wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Plugin Config", "plugin_configs/"+state.ID.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the plugin config
-	err := r.client.DeletePluginConfig(state.ID.ValueString())
+	err := client.deleteObject(ctx, "plugin_configs/"+state.ID.ValueString(), state.ForceDelete, func() error {
+		return client.DeletePluginConfig(state.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		client.addDeleteError(ctx, &resp.Diagnostics, "plugin_configs/"+state.ID.ValueString(), err,
 			"Error Deleting APISIX Plugin Config",
 			"Could not delete Plugin Config, unexpected error: "+err.Error(),
 		)
@@ -205,6 +364,15 @@ func (r *pluginConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 // Import resource into state
 func (r *pluginConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the plugin config importing")
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Resolve the import ID, identity or name or labels selector into the ID, and save it to the id attribute
+	id, err := r.client.resolveImportID(ctx, "Plugin Config", "plugin_configs", importIdentifier(ctx, req, path.Root("id"), &resp.Diagnostics), false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing APISIX Plugin Config",
+			"Could not import Plugin Config: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: types.StringValue(id)}, &resp.Diagnostics)
 }
@@ -0,0 +1,243 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// pluginMetaField is the `_meta` block APISIX adds to the plugins, like the
+// priority or the filter of a plugin.
+const pluginMetaField = "_meta"
+
+// readPlugins returns the attributes configuring the plugins to keep in the
+// new state after reading the object from APISIX: `plugins`, `plugin`,
+// `sensitive_plugins` and `plugins_typed`, null for the objects without typed
+// plugins.
+//
+// APISIX returns the plugins with the defaults of their JSON schema set, like
+// the `local` policy of limit-count. These values are left out of the plugins
+// read from APISIX unless they are configured, with the `_meta` blocks not
+// configured, so that the prior attributes are kept when the rest matches.
+// Otherwise the plugins were changed outside Terraform, and the plugins read
+// from APISIX replace the prior attributes to show the changes in the plan.
+func (c *apisixClient) readPlugins(ctx context.Context, schemaType string, read types.String, plugins types.String, plugin types.Dynamic, sensitivePlugins types.String, pluginsTyped types.Object) (types.String, types.Dynamic, types.String, types.Object) {
+	var readPlugins map[string]interface{}
+	if err := decodeJSONNumbers([]byte(read.ValueString()), &readPlugins); err != nil {
+		return plugins, plugin, sensitivePlugins, pluginsTyped
+	}
+
+	// Configured like the plugins sent to APISIX
+	var configured map[string]interface{}
+	if merged := model.PluginsWithSensitiveToJson(ctx, model.PluginsTypedValue(ctx, model.PluginsValue(ctx, plugins, plugin), pluginsTyped), sensitivePlugins); merged != nil {
+		configured = normalizeJSONNumbers(*merged).(map[string]interface{})
+	}
+
+	store := c.pluginSchemaStore()
+	normalized := make(map[string]interface{}, len(readPlugins))
+	for name, value := range readPlugins {
+		configuredValue, isConfigured := configured[name]
+		if object, ok := value.(map[string]interface{}); ok {
+			configuredObject, _ := configuredValue.(map[string]interface{})
+			_, hasMeta := object[pluginMetaField]
+			if _, ok := configuredObject[pluginMetaField]; !ok && hasMeta {
+				object = withoutJSONField(object, pluginMetaField)
+			}
+			value = object
+		}
+		if schema := store.schema(ctx, name, schemaType); schema.Schema != nil {
+			value = withoutSchemaDefaults(schema.Schema, value, configuredValue, isConfigured)
+		}
+		normalized[name] = value
+	}
+
+	if equalJSONValues(normalized, configured) {
+		newPlugins, newPlugin, newSensitivePlugins := model.PriorPlugins(plugins, plugin, sensitivePlugins, pluginsTyped)
+		return newPlugins, newPlugin, newSensitivePlugins, pluginsTyped
+	}
+	tflog.Debug(ctx, "The plugins were changed outside Terraform", map[string]any{"plugins": sortedKeys(normalized)})
+
+	// The sensitive values stay in sensitive_plugins, to keep them masked
+	newSensitivePlugins := types.StringNull()
+	if prior := model.PluginsStringToJson(ctx, sensitivePlugins); prior != nil {
+		var sensitive map[string]interface{}
+		normalized, sensitive = splitJSONValues(normalized, *prior)
+		newSensitivePlugins = jsonObjectString(sensitive)
+	}
+
+	// The typed plugins stay in plugins_typed
+	newPluginsTyped := pluginsTyped
+	if !pluginsTyped.IsNull() {
+		var typedNames map[string]interface{}
+		_ = json.Unmarshal([]byte(model.PluginsTypedValue(ctx, types.StringNull(), pluginsTyped).ValueString()), &typedNames)
+
+		typed := map[string]interface{}{}
+		for name := range typedNames {
+			if value, ok := normalized[name]; ok {
+				typed[name] = value
+				delete(normalized, name)
+			}
+		}
+		if schemaType == pluginSchemaTypeConsumer {
+			newPluginsTyped = model.ConsumerPluginsTypedFromApiToTerraform(ctx, typed)
+		} else {
+			newPluginsTyped = model.PluginsTypedFromApiToTerraform(ctx, typed)
+		}
+	}
+
+	// The other plugins go in the attribute the plugins were configured with
+	if !plugin.IsNull() {
+		newPlugin := types.DynamicNull()
+		if len(normalized) > 0 {
+			var err error
+			if newPlugin, err = model.JsonToDynamic(normalized); err != nil {
+				tflog.Error(ctx, "Failed to convert the plugins read from APISIX", map[string]any{"error": err.Error()})
+			}
+		}
+		return types.StringNull(), newPlugin, newSensitivePlugins, newPluginsTyped
+	}
+
+	return jsonObjectString(normalized), types.DynamicNull(), newSensitivePlugins, newPluginsTyped
+}
+
+// withoutSchemaDefaults returns the value read from APISIX without the fields
+// that aren't configured and hold the default of their schema, recursively.
+// The objects only made of such defaults are left out as well.
+func withoutSchemaDefaults(schema map[string]interface{}, value interface{}, configured interface{}, isConfigured bool) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		configuredObject, _ := configured.(map[string]interface{})
+		result := make(map[string]interface{}, len(value))
+		for name, item := range value {
+			configuredItem, isItemConfigured := configuredObject[name]
+			properties := jsonPropertySchemas(schema, name)
+			if !isItemConfigured && hasJSONDefault(properties, item) {
+				continue
+			}
+
+			stripped := item
+			for _, property := range properties {
+				stripped = withoutSchemaDefaults(property, stripped, configuredItem, isItemConfigured)
+			}
+			if object, ok := stripped.(map[string]interface{}); ok && len(object) == 0 && !isItemConfigured && !equalJSONValues(item, stripped) {
+				continue
+			}
+			result[name] = stripped
+		}
+		return result
+	case []interface{}:
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok || !isConfigured {
+			return value
+		}
+		configuredArray, _ := configured.([]interface{})
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			var configuredItem interface{}
+			if i < len(configuredArray) {
+				configuredItem = configuredArray[i]
+			}
+			result[i] = withoutSchemaDefaults(items, item, configuredItem, i < len(configuredArray))
+		}
+		return result
+	}
+
+	return value
+}
+
+// jsonPropertySchemas returns the schemas of the property, including the ones
+// of the alternatives and conditional parts of the schema.
+func jsonPropertySchemas(schema map[string]interface{}, name string) []map[string]interface{} {
+	var schemas []map[string]interface{}
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			schemas = append(schemas, property)
+		}
+	}
+
+	var subschemas []interface{}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if alternatives, ok := schema[keyword].([]interface{}); ok {
+			subschemas = append(subschemas, alternatives...)
+		}
+	}
+	for _, keyword := range []string{"then", "else"} {
+		subschemas = append(subschemas, schema[keyword])
+	}
+	if dependencies, ok := schema["dependencies"].(map[string]interface{}); ok {
+		for _, dependency := range dependencies {
+			subschemas = append(subschemas, dependency)
+		}
+	}
+	for _, subschema := range jsonSchemas(subschemas) {
+		schemas = append(schemas, jsonPropertySchemas(subschema, name)...)
+	}
+
+	return schemas
+}
+
+func hasJSONDefault(schemas []map[string]interface{}, value interface{}) bool {
+	for _, schema := range schemas {
+		if defaultValue, ok := schema["default"]; ok && equalJSONValues(defaultValue, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitJSONValues splits the value into the fields outside the paths of the
+// selection and the ones inside, recursively for the objects. The objects
+// emptied by the split are left out.
+func splitJSONValues(value map[string]interface{}, selection map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	rest := make(map[string]interface{}, len(value))
+	selected := map[string]interface{}{}
+	for name, item := range value {
+		selectionItem, ok := selection[name]
+		if !ok {
+			rest[name] = item
+			continue
+		}
+
+		object, isObject := item.(map[string]interface{})
+		selectionObject, isSelectionObject := selectionItem.(map[string]interface{})
+		if !isObject || !isSelectionObject {
+			selected[name] = item
+			continue
+		}
+
+		restObject, selectedObject := splitJSONValues(object, selectionObject)
+		if len(restObject) > 0 || len(object) == 0 {
+			rest[name] = restObject
+		}
+		if len(selectedObject) > 0 {
+			selected[name] = selectedObject
+		}
+	}
+
+	return rest, selected
+}
+
+func withoutJSONField(object map[string]interface{}, field string) map[string]interface{} {
+	result := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		if key != field {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+// jsonObjectString returns the object as a JSON string, null when empty.
+func jsonObjectString(object map[string]interface{}) types.String {
+	if len(object) == 0 {
+		return types.StringNull()
+	}
+
+	return model.PluginsFromJsonToString(context.Background(), &object)
+}
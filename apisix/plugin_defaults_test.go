@@ -0,0 +1,99 @@
+package apisix
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReadPlugins(t *testing.T) {
+	client := &apisixClient{pluginSchemas: &pluginSchemaStore{}}
+	limitCountDefaults := `"policy": "local", "allow_degradation": false, "show_limit_quota_header": true, "key": "remote_addr", "key_type": "var", "rejected_code": 503`
+
+	testCases := map[string]struct {
+		read             string
+		plugins          types.String
+		plugin           types.Dynamic
+		sensitivePlugins types.String
+		schemaType       string
+
+		expectedPlugins          types.String
+		expectedPlugin           types.Dynamic
+		expectedSensitivePlugins types.String
+	}{
+		"defaults": {
+			read:            `{"limit-count": {"count": 2, "time_window": 60, ` + limitCountDefaults + `, "_meta": {"priority": 1000}}}`,
+			plugins:         types.StringValue(`{"limit-count":{"count":2,"time_window":60}}`),
+			expectedPlugins: types.StringValue(`{"limit-count":{"count":2,"time_window":60}}`),
+		},
+		"configured defaults": {
+			read:            `{"limit-count": {"count": 2, "time_window": 60, ` + limitCountDefaults + `}}`,
+			plugins:         types.StringValue(`{"limit-count":{"count":2,"time_window":60,"policy":"local"}}`),
+			expectedPlugins: types.StringValue(`{"limit-count":{"count":2,"time_window":60,"policy":"local"}}`),
+		},
+		"changed outside terraform": {
+			read:            `{"limit-count": {"count": 5, "time_window": 60, ` + limitCountDefaults + `, "_meta": {"priority": 1000}}}`,
+			plugins:         types.StringValue(`{"limit-count":{"count":2,"time_window":60}}`),
+			expectedPlugins: types.StringValue(`{"limit-count":{"count":5,"time_window":60}}`),
+		},
+		"changed default": {
+			read:            `{"limit-count": {"count": 2, "time_window": 60, "policy": "local", "rejected_code": 429}}`,
+			plugins:         types.StringValue(`{"limit-count":{"count":2,"time_window":60}}`),
+			expectedPlugins: types.StringValue(`{"limit-count":{"count":2,"rejected_code":429,"time_window":60}}`),
+		},
+		"configured meta": {
+			read:            `{"limit-count": {"count": 2, "time_window": 60, "_meta": {"priority": 1000}}}`,
+			plugins:         types.StringValue(`{"limit-count":{"count":2,"time_window":60,"_meta":{"priority":2000}}}`),
+			expectedPlugins: types.StringValue(`{"limit-count":{"_meta":{"priority":1000},"count":2,"time_window":60}}`),
+		},
+		"imported": {
+			read:            `{"limit-count": {"count": 2, "time_window": 60, ` + limitCountDefaults + `}, "custom-auth": {"header": "X-Auth"}}`,
+			plugins:         types.StringNull(),
+			expectedPlugins: types.StringValue(`{"custom-auth":{"header":"X-Auth"},"limit-count":{"count":2,"time_window":60}}`),
+		},
+		"plugin object": {
+			read:           `{"limit-count": {"count": 5, "time_window": 60, ` + limitCountDefaults + `}}`,
+			plugins:        types.StringNull(),
+			plugin:         mustJsonToDynamic(t, map[string]interface{}{"limit-count": map[string]interface{}{"count": 2.0, "time_window": 60.0}}),
+			expectedPlugin: mustJsonToDynamic(t, map[string]interface{}{"limit-count": map[string]interface{}{"count": 5.0, "time_window": 60.0}}),
+		},
+		"sensitive plugins": {
+			read:                     `{"key-auth": {"key": "rotated", "hide_credentials": true}}`,
+			plugins:                  types.StringValue(`{"key-auth":{}}`),
+			sensitivePlugins:         types.StringValue(`{"key-auth":{"key":"secret"}}`),
+			schemaType:               pluginSchemaTypeConsumer,
+			expectedPlugins:          types.StringValue(`{"key-auth":{"hide_credentials":true}}`),
+			expectedSensitivePlugins: types.StringValue(`{"key-auth":{"key":"rotated"}}`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			plugins, actualPlugin, actualSensitivePlugins, _ := client.readPlugins(context.Background(), testCase.schemaType, types.StringValue(testCase.read), testCase.plugins, testCase.plugin, testCase.sensitivePlugins, types.ObjectNull(nil))
+
+			if !plugins.Equal(testCase.expectedPlugins) {
+				t.Errorf("expected the plugins %s, got %s", testCase.expectedPlugins, plugins)
+			}
+			if !actualPlugin.Equal(testCase.expectedPlugin) {
+				t.Errorf("expected the plugin %s, got %s", testCase.expectedPlugin, actualPlugin)
+			}
+			if !actualSensitivePlugins.Equal(testCase.expectedSensitivePlugins) {
+				t.Errorf("expected the sensitive plugins %s, got %s", testCase.expectedSensitivePlugins, actualSensitivePlugins)
+			}
+		})
+	}
+}
+
+func mustJsonToDynamic(t *testing.T, value interface{}) types.Dynamic {
+	t.Helper()
+
+	dynamic, err := model.JsonToDynamic(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dynamic
+}
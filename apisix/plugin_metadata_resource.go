@@ -4,8 +4,7 @@ import (
 	"context"
 	"fmt"
 
-	api_client "github.com/holubovskyi/apisix-client-go"
-
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-apisix/apisix/model"
@@ -13,9 +12,12 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &pluginMetadataResource{}
-	_ resource.ResourceWithConfigure   = &pluginMetadataResource{}
-	_ resource.ResourceWithImportState = &pluginMetadataResource{}
+	_ resource.Resource                 = &pluginMetadataResource{}
+	_ resource.ResourceWithConfigure    = &pluginMetadataResource{}
+	_ resource.ResourceWithImportState  = &pluginMetadataResource{}
+	_ resource.ResourceWithIdentity     = &pluginMetadataResource{}
+	_ resource.ResourceWithUpgradeState = &pluginMetadataResource{}
+	_ resource.ResourceWithModifyPlan   = &pluginMetadataResource{}
 )
 
 // NewPluginMetadataResource is a helper function to simplify the provider implementation.
@@ -25,7 +27,7 @@ func NewPluginMetadataResource() resource.Resource {
 
 // pluginMetadataResource is the resource implementation.
 type pluginMetadataResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -38,18 +40,34 @@ func (r *pluginMetadataResource) Schema(_ context.Context, _ resource.SchemaRequ
 	resp.Schema = model.PluginMetadataSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *pluginMetadataResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.PluginMetadataIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *pluginMetadataResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.PluginMetadataStateUpgrades)
+}
+
+// Implement plan modification
+func (r *pluginMetadataResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_plugin_metadata", req.Plan, &resp.Diagnostics)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *pluginMetadataResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -68,6 +86,16 @@ func (r *pluginMetadataResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Plugin Metadata", plan.Id, createTimeout)
+
 	// Generate API request body from plan
 	newPluginMetadataRequest := model.PluginMetadataFromTerraformToApi(ctx, &plan)
 	// Debug: Log what we're about to send
@@ -77,10 +105,35 @@ func (r *pluginMetadataResource) Create(ctx context.Context, req resource.Create
 		"plan_metadata": plan.Metadata.ValueString(),
 	})
 
+	// Keep the other resources writing the plugin metadata waiting until it's created
+	unlock := client.lockObject(ctx, "Plugin Metadata", "plugin_metadata/"+plan.Id.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing plugin metadata when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetPluginMetadata(plan.Id.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Plugin Metadata",
+			"Could not read APISIX Plugin Metadata by plugin name "+plan.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Plugin Metadata", plan.Id.ValueString(), existing, newPluginMetadataRequest)
+		} else if !sameObject(existing, newPluginMetadataRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "Plugin Metadata", plan.Id.ValueString())
+			return
+		}
+	}
+
 	// Create new plugin metadata
-	newPluginMetadataResponse, err := r.client.CreatePluginMetadata(plan.Id.ValueString(), newPluginMetadataRequest)
+	newPluginMetadataResponse, err := client.CreatePluginMetadata(plan.Id.ValueString(), newPluginMetadataRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Plugin Metadata",
 			"Could not create Plugin Metadata, unexpected error: "+err.Error(),
 		)
@@ -92,6 +145,10 @@ func (r *pluginMetadataResource) Create(ctx context.Context, req resource.Create
 
 	// Map response body to schema
 	newState := model.PluginMetadataFromApiToTerraform(ctx, newPluginMetadataResponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.KeepMetadataObject(plan)
 
 	// Debug: Log the converted state
 	tflog.Debug(ctx, "Create - Converted state", map[string]interface{}{
@@ -99,6 +156,12 @@ func (r *pluginMetadataResource) Create(ctx context.Context, req resource.Create
 		"metadata_value":   newState.Metadata.ValueString(),
 	})
 
+	// Remember the version of the plugin metadata to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "plugin_metadata/"+plan.Id.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the plugin metadata by its plugin name
+	setIdentity(ctx, resp.Identity, model.PluginMetadataIdentityModel{PluginName: newState.Id}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -122,9 +185,19 @@ func (r *pluginMetadataResource) Read(ctx context.Context, req resource.ReadRequ
 
 	// Convert API response to Terraform state
 	newState := model.PluginMetadataFromApiToTerraform(ctx, pluginMetadataResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
 	if newState.Metadata.IsNull() && !state.Metadata.IsNull() {
 		newState.Metadata = state.Metadata
 	}
+	newState.KeepMetadataObject(state)
+
+	// Remember the version of the plugin metadata to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "plugin_metadata/"+state.Id.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the plugin metadata by its plugin name
+	setIdentity(ctx, resp.Identity, model.PluginMetadataIdentityModel{PluginName: newState.Id}, &resp.Diagnostics)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
@@ -142,6 +215,29 @@ func (r *pluginMetadataResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Plugin Metadata", plan.Id, updateTimeout)
+
+	// Keep the other resources writing the plugin metadata waiting until it's updated
+	unlock := client.lockObject(ctx, "Plugin Metadata", "plugin_metadata/"+plan.Id.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the plugin metadata wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Plugin Metadata", "plugin_metadata/"+plan.Id.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	updatePluginMetadataRequest := model.PluginMetadataFromTerraformToApi(ctx, &plan)
 	// Debug: Log what we're about to send
 	tflog.Debug(ctx, "Update - Sending to API", map[string]interface{}{
@@ -150,10 +246,10 @@ func (r *pluginMetadataResource) Update(ctx context.Context, req resource.Update
 		"plan_metadata": plan.Metadata.ValueString(),
 	})
 
-	// Update existing plugin metadata
-	updateResponse, err := r.client.UpdatePluginMetadata(plan.Id.ValueString(), updatePluginMetadataRequest)
+	// Update existing plugin metadata, the response holds the updated plugin metadata
+	updatedPluginMetadata, err := client.UpdatePluginMetadata(plan.Id.ValueString(), updatePluginMetadataRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error Updating APISIX Plugin Metadata",
 			"Could not update plugin metadata, unexpected error: "+err.Error(),
 		)
@@ -161,23 +257,14 @@ func (r *pluginMetadataResource) Update(ctx context.Context, req resource.Update
 	}
 
 	// Debug: Log the update response
-	tflog.Debug(ctx, "Update - API response", map[string]interface{}{"response": updateResponse})
-
-	// Fetch updated metadata
-	updatedPluginMetadata, err := r.client.GetPluginMetadata(plan.Id.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Plugin Metadata After Update",
-			"Could not read plugin metadata after update: "+err.Error(),
-		)
-		return
-	}
-
-	// Debug: Log what we got back
-	tflog.Debug(ctx, "Update - Get response", map[string]interface{}{"response": updatedPluginMetadata})
+	tflog.Debug(ctx, "Update - API response", map[string]interface{}{"response": updatedPluginMetadata})
 
 	// Convert to state
 	newState := model.PluginMetadataFromApiToTerraform(ctx, updatedPluginMetadata)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.KeepMetadataObject(plan)
 
 	// Debug: Log the converted state
 	tflog.Debug(ctx, "Update - Converted state", map[string]interface{}{
@@ -185,6 +272,12 @@ func (r *pluginMetadataResource) Update(ctx context.Context, req resource.Update
 		"metadata_value":   newState.Metadata.ValueString(),
 	})
 
+	// Remember the version of the plugin metadata to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "plugin_metadata/"+plan.Id.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the plugin metadata by its plugin name
+	setIdentity(ctx, resp.Identity, model.PluginMetadataIdentityModel{PluginName: newState.Id}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -201,8 +294,31 @@ func (r *pluginMetadataResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Plugin Metadata", state.Id, deleteTimeout)
+
+	// Keep the other resources writing the plugin metadata waiting until it's deleted
+	unlock := client.lockObject(ctx, "Plugin Metadata", "plugin_metadata/"+state.Id.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the plugin metadata wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Plugin Metadata", "plugin_metadata/"+state.Id.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the plugin metadata
-	err := r.client.DeletePluginMetadata(state.Id.ValueString())
+	err := client.DeletePluginMetadata(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Plugin Metadata",
@@ -214,8 +330,8 @@ func (r *pluginMetadataResource) Delete(ctx context.Context, req resource.Delete
 
 // Import resource into state
 func (r *pluginMetadataResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// The import ID should be the plugin name
-	pluginName := req.ID
+	// The import ID or identity should be the plugin name
+	pluginName := importIdentifier(ctx, req, path.Root("plugin_name"), &resp.Diagnostics)
 
 	// Read the plugin metadata from API
 	pluginMetadataResponse, err := r.client.GetPluginMetadata(pluginName)
@@ -232,6 +348,7 @@ func (r *pluginMetadataResource) ImportState(ctx context.Context, req resource.I
 
 	// Convert API response to Terraform state
 	state := model.PluginMetadataFromApiToTerraform(ctx, pluginMetadataResponse)
+	state.Timeouts = model.NullTimeouts()
 
 	// Debug: Log the converted state
 	tflog.Debug(ctx, "Import - Converted state", map[string]interface{}{"metadata": state.Metadata.ValueString()})
@@ -239,4 +356,5 @@ func (r *pluginMetadataResource) ImportState(ctx context.Context, req resource.I
 	// Set the imported state
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	setIdentity(ctx, resp.Identity, model.PluginMetadataIdentityModel{PluginName: state.Id}, &resp.Diagnostics)
 }
@@ -65,3 +65,47 @@ resource "apisix_plugin_metadata" "test" {
 		},
 	})
 }
+
+func TestPluginMetadataResourceObject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with the metadata as an object
+			{
+				Config: providerConfig + `
+resource "apisix_plugin_metadata" "test" {
+  id = "http-logger"
+  metadata_object = {
+    log_format = {
+      host      = "$host"
+      client_ip = "$remote_addr"
+    }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_plugin_metadata.test", "metadata_object.log_format.host", "$host"),
+					resource.TestCheckNoResourceAttr("apisix_plugin_metadata.test", "metadata"),
+				),
+			},
+			// Update a single metadata field
+			{
+				Config: providerConfig + `
+resource "apisix_plugin_metadata" "test" {
+  id = "http-logger"
+  metadata_object = {
+    log_format = {
+      host      = "$host"
+      client_ip = "$http_x_real_ip"
+    }
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_plugin_metadata.test", "metadata_object.log_format.client_ip", "$http_x_real_ip"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,254 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// pluginReference is a reference to another APISIX object found in the
+// configuration of a plugin.
+type pluginReference struct {
+	// Location is the place of the reference in the plugins, e.g. `traffic-split.rules[0].weighted_upstreams[0].upstream_id`
+	Location   string
+	ObjectType string
+	Collection string
+	ID         string
+}
+
+// consumerRestrictionTypes maps the `type` of the consumer-restriction plugin
+// to the objects listed in its `whitelist` and `blacklist`.
+var consumerRestrictionTypes = map[string]struct {
+	ObjectType string
+	Collection string
+}{
+	"consumer_name":     {ObjectType: "Consumer", Collection: "consumers"},
+	"consumer_group_id": {ObjectType: "Consumer Group", Collection: "consumer_groups"},
+	"service_id":        {ObjectType: "Service", Collection: "services"},
+	"route_id":          {ObjectType: "Route", Collection: "routes"},
+}
+
+// secretReferencePattern matches the secret references like `$secret://vault/1/jack/auth-key`.
+var secretReferencePattern = regexp.MustCompile(`^\$secret://([^/]+)/([^/]+)/`)
+
+// pluginReferences extracts the references to other objects from the plugins
+// JSON: the upstreams of traffic-split, the consumers, consumer groups,
+// services and routes of consumer-restriction, and the secrets referenced
+// with `$secret://` in any plugin. An invalid JSON has no references.
+func pluginReferences(plugins string) []pluginReference {
+	decoder := json.NewDecoder(strings.NewReader(plugins))
+	decoder.UseNumber()
+
+	var configs map[string]interface{}
+	if err := decoder.Decode(&configs); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var references []pluginReference
+	for _, name := range names {
+		config, ok := configs[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch name {
+		case "traffic-split":
+			references = append(references, trafficSplitReferences(name, config)...)
+		case "consumer-restriction":
+			references = append(references, consumerRestrictionReferences(name, config)...)
+		}
+
+		references = append(references, secretReferences(name, config)...)
+	}
+
+	return references
+}
+
+func trafficSplitReferences(location string, config map[string]interface{}) []pluginReference {
+	var references []pluginReference
+
+	rules, _ := config["rules"].([]interface{})
+	for i, rule := range rules {
+		rule, _ := rule.(map[string]interface{})
+		upstreams, _ := rule["weighted_upstreams"].([]interface{})
+		for j, upstream := range upstreams {
+			upstream, _ := upstream.(map[string]interface{})
+			id, ok := referenceID(upstream["upstream_id"])
+			if !ok {
+				continue
+			}
+
+			references = append(references, pluginReference{
+				Location:   location + ".rules[" + strconv.Itoa(i) + "].weighted_upstreams[" + strconv.Itoa(j) + "].upstream_id",
+				ObjectType: "Upstream",
+				Collection: "upstreams",
+				ID:         id,
+			})
+		}
+	}
+
+	return references
+}
+
+func consumerRestrictionReferences(location string, config map[string]interface{}) []pluginReference {
+	restrictionType, _ := config["type"].(string)
+	if restrictionType == "" {
+		restrictionType = "consumer_name"
+	}
+	referenced, ok := consumerRestrictionTypes[restrictionType]
+	if !ok {
+		return nil
+	}
+
+	var references []pluginReference
+	for _, list := range []string{"whitelist", "blacklist"} {
+		ids, _ := config[list].([]interface{})
+		for i, id := range ids {
+			id, ok := referenceID(id)
+			if !ok {
+				continue
+			}
+
+			references = append(references, pluginReference{
+				Location:   location + "." + list + "[" + strconv.Itoa(i) + "]",
+				ObjectType: referenced.ObjectType,
+				Collection: referenced.Collection,
+				ID:         id,
+			})
+		}
+	}
+
+	// The methods are restricted per consumer name, whatever the type
+	methods, _ := config["allowed_by_methods"].([]interface{})
+	for i, method := range methods {
+		method, _ := method.(map[string]interface{})
+		username, ok := referenceID(method["user"])
+		if !ok {
+			continue
+		}
+
+		references = append(references, pluginReference{
+			Location:   location + ".allowed_by_methods[" + strconv.Itoa(i) + "].user",
+			ObjectType: "Consumer",
+			Collection: "consumers",
+			ID:         username,
+		})
+	}
+
+	return references
+}
+
+func secretReferences(location string, value interface{}) []pluginReference {
+	switch value := value.(type) {
+	case string:
+		match := secretReferencePattern.FindStringSubmatch(value)
+		if match == nil {
+			return nil
+		}
+
+		return []pluginReference{{
+			Location:   location,
+			ObjectType: "Secret",
+			Collection: "secrets/" + match[1],
+			ID:         match[2],
+		}}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var references []pluginReference
+		for _, key := range keys {
+			references = append(references, secretReferences(location+"."+key, value[key])...)
+		}
+		return references
+	case []interface{}:
+		var references []pluginReference
+		for i, item := range value {
+			references = append(references, secretReferences(location+"["+strconv.Itoa(i)+"]", item)...)
+		}
+		return references
+	}
+
+	return nil
+}
+
+// referenceID returns the identifier in a plugin configuration value, which
+// APISIX accepts both as a string and as an integer.
+func referenceID(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, value != ""
+	case json.Number:
+		return value.String(), true
+	}
+
+	return "", false
+}
+
+// checkPluginReferences reports the references in the planned plugins, either
+// as a JSON string, as a dynamic value or typed, and sensitive plugins to
+// objects that don't exist in APISIX nor are planned for creation.
+func (c *apisixClient) checkPluginReferences(ctx context.Context, plan tfsdk.Plan, diags *diag.Diagnostics) {
+	// The provider isn't configured yet, the check is disabled or the resource is planned for destruction
+	if c == nil || !c.checkReferences || plan.Raw.IsNull() {
+		return
+	}
+
+	checked := map[string]bool{}
+	for _, attributeName := range []string{"plugins", "plugin", "plugins_typed", "sensitive_plugins"} {
+		var plugins types.String
+		switch attributeName {
+		case "plugin":
+			var plugin types.Dynamic
+			diags.Append(plan.GetAttribute(ctx, path.Root(attributeName), &plugin)...)
+			plugins = model.PluginsValue(ctx, types.StringNull(), plugin)
+		case "plugins_typed":
+			// Only the routes, services and consumers have typed plugins
+			if _, ok := plan.Schema.GetAttributes()[attributeName]; !ok {
+				continue
+			}
+			var pluginsTyped types.Object
+			diags.Append(plan.GetAttribute(ctx, path.Root(attributeName), &pluginsTyped)...)
+			plugins = model.PluginsTypedValue(ctx, types.StringNull(), pluginsTyped)
+		default:
+			diags.Append(plan.GetAttribute(ctx, path.Root(attributeName), &plugins)...)
+		}
+		if plugins.IsNull() || plugins.IsUnknown() {
+			continue
+		}
+
+		for _, reference := range pluginReferences(plugins.ValueString()) {
+			objectPath := reference.Collection + "/" + reference.ID
+			if checked[objectPath] {
+				continue
+			}
+			checked[objectPath] = true
+
+			c.checkObjectReference(ctx, objectReference{
+				Path:       path.Root(attributeName),
+				ObjectType: reference.ObjectType,
+				Collection: reference.Collection,
+				Location:   reference.Location,
+			}, reference.ID, diags)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package apisix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPluginReferences(t *testing.T) {
+	testCases := map[string]struct {
+		plugins  string
+		expected []pluginReference
+	}{
+		"traffic-split": {
+			plugins: `{"traffic-split":{"rules":[{"weighted_upstreams":[{"upstream_id":"canary","weight":1},{"weight":9}]},{"weighted_upstreams":[{"upstream_id":2}]}]}}`,
+			expected: []pluginReference{
+				{Location: "traffic-split.rules[0].weighted_upstreams[0].upstream_id", ObjectType: "Upstream", Collection: "upstreams", ID: "canary"},
+				{Location: "traffic-split.rules[1].weighted_upstreams[0].upstream_id", ObjectType: "Upstream", Collection: "upstreams", ID: "2"},
+			},
+		},
+		"consumer-restriction consumer names": {
+			plugins: `{"consumer-restriction":{"whitelist":["jack"],"allowed_by_methods":[{"user":"jane","methods":["GET"]}]}}`,
+			expected: []pluginReference{
+				{Location: "consumer-restriction.whitelist[0]", ObjectType: "Consumer", Collection: "consumers", ID: "jack"},
+				{Location: "consumer-restriction.allowed_by_methods[0].user", ObjectType: "Consumer", Collection: "consumers", ID: "jane"},
+			},
+		},
+		"consumer-restriction consumer groups": {
+			plugins: `{"consumer-restriction":{"type":"consumer_group_id","blacklist":["banned"]}}`,
+			expected: []pluginReference{
+				{Location: "consumer-restriction.blacklist[0]", ObjectType: "Consumer Group", Collection: "consumer_groups", ID: "banned"},
+			},
+		},
+		"secrets": {
+			plugins: `{"jwt-auth":{"key":"jack","secret":"$secret://vault/1/jack/secret"},"limit-count":{"redis_password":"$env://REDIS_PASSWORD","count":1}}`,
+			expected: []pluginReference{
+				{Location: "jwt-auth.secret", ObjectType: "Secret", Collection: "secrets/vault", ID: "1"},
+			},
+		},
+		"no references": {
+			plugins:  `{"proxy-rewrite":{"uri":"/"}}`,
+			expected: nil,
+		},
+		"invalid json": {
+			plugins:  `{"traffic-split":`,
+			expected: nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := pluginReferences(testCase.plugins)
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("expected %+v, got %+v", testCase.expected, got)
+			}
+		})
+	}
+}
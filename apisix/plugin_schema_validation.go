@@ -0,0 +1,669 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// schemaViolation is a value of a plugin configuration not matching the
+// plugin JSON schema.
+type schemaViolation struct {
+	// Field is the path of the value in the plugin configuration, e.g. `rules[0].count`, empty for the whole configuration
+	Field   string
+	Message string
+}
+
+func (v schemaViolation) String() string {
+	if v.Field == "" {
+		return "the configuration " + v.Message
+	}
+
+	return "`" + v.Field + "` " + v.Message
+}
+
+// checkPluginSchemas reports the planned plugins, either as a JSON string, as
+// a dynamic value or typed, merged with the sensitive plugins, that APISIX
+// doesn't know or whose configuration doesn't match the plugin JSON schema.
+// The consumers are checked against the consumer schemas of the plugins.
+func (c *apisixClient) checkPluginSchemas(ctx context.Context, config tfsdk.Config, schemaType string, diags *diag.Diagnostics) {
+	var plugins, sensitivePlugins types.String
+	var plugin types.Dynamic
+	pluginsTyped := types.ObjectNull(nil)
+
+	// The plugins attribute of each plugin, to report the diagnostics on it
+	attributes := map[string]string{}
+	for _, attributeName := range pluginsAttributeNames {
+		if _, ok := config.Schema.GetAttributes()[attributeName]; !ok {
+			continue
+		}
+
+		var value types.String
+		switch attributeName {
+		case "plugins":
+			diags.Append(config.GetAttribute(ctx, path.Root(attributeName), &plugins)...)
+			value = plugins
+		case "plugin":
+			diags.Append(config.GetAttribute(ctx, path.Root(attributeName), &plugin)...)
+			value = model.PluginsValue(ctx, types.StringNull(), plugin)
+		case "plugins_typed":
+			diags.Append(config.GetAttribute(ctx, path.Root(attributeName), &pluginsTyped)...)
+			if pluginsTyped.IsUnknown() {
+				return
+			}
+			value = model.PluginsTypedValue(ctx, types.StringNull(), pluginsTyped)
+		case "sensitive_plugins":
+			diags.Append(config.GetAttribute(ctx, path.Root(attributeName), &sensitivePlugins)...)
+			value = sensitivePlugins
+		}
+		if diags.HasError() || value.IsUnknown() {
+			// Validated once known
+			return
+		}
+		if value.IsNull() {
+			continue
+		}
+
+		var pluginsJSON map[string]interface{}
+		if err := json.Unmarshal([]byte(value.ValueString()), &pluginsJSON); err != nil {
+			// The invalid JSON is reported when converting the plugins
+			return
+		}
+		for name := range pluginsJSON {
+			if _, ok := attributes[name]; !ok {
+				attributes[name] = attributeName
+			}
+		}
+	}
+	if len(attributes) == 0 {
+		return
+	}
+
+	// Merged like the plugins sent to APISIX
+	merged := model.PluginsWithSensitiveToJson(ctx, model.PluginsTypedValue(ctx, model.PluginsValue(ctx, plugins, plugin), pluginsTyped), sensitivePlugins)
+	if merged == nil {
+		return
+	}
+
+	store := c.pluginSchemaStore()
+	for _, name := range sortedKeys(*merged) {
+		attributePath := path.Root(attributes[name])
+
+		schema := store.schema(ctx, name, schemaType)
+		if !schema.Known {
+			detail := fmt.Sprintf("The %s plugin is unknown to %s. Check the name of the plugin and that it is enabled in APISIX.", name, store.source())
+			if store.client == nil {
+				detail += fmt.Sprintf("\n\nIf it is a custom plugin, add its JSON schema, as returned by `GET /apisix/admin/schema/plugins/%s`, "+
+					"to the directory of the APISIX version, like `3.15`, in the %s directory, or set the %s environment variable to a directory holding it.", name, store.cacheDir, pluginSchemasCacheDirEnv)
+			}
+			diags.AddAttributeError(attributePath, "Unknown Plugin", detail)
+			continue
+		}
+		if schema.Schema == nil {
+			// Known from the list of the plugins only, validated by APISIX on apply
+			continue
+		}
+
+		violations := validateJSONSchema(schema.Schema, (*merged)[name], "", true)
+		if len(violations) == 0 {
+			continue
+		}
+
+		messages := make([]string, 0, len(violations))
+		for _, violation := range violations {
+			messages = append(messages, "- "+violation.String())
+		}
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Plugin Configuration",
+			fmt.Sprintf("The configuration of the %s plugin doesn't match its JSON schema from %s:\n\n%s", name, schema.Source, strings.Join(messages, "\n")),
+		)
+	}
+}
+
+// validateJSONSchema validates the value against the JSON schema, with the
+// keywords of the draft 4 to 7 the APISIX plugin schemas use. Like APISIX, the
+// defaults of the missing properties are set first, so that the conditions
+// like `if` see them, e.g. the `local` policy of limit-count. The unknown
+// properties of the objects with properties are reported as well, unless the
+// schema allows additional properties explicitly, as APISIX ignores them and
+// they are most likely misspelled. The alternatives of oneOf, anyOf, allOf,
+// not and if aren't strict, as they usually only hold a part of the object.
+func validateJSONSchema(schema map[string]interface{}, value interface{}, field string, strict bool) []schemaViolation {
+	var violations []schemaViolation
+	addViolation := func(format string, args ...interface{}) {
+		violations = append(violations, schemaViolation{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if schemaTypes := jsonSchemaTypes(schema["type"]); len(schemaTypes) > 0 && !matchesJSONType(value, schemaTypes) {
+		addViolation("must be %s", describeJSONTypes(schemaTypes))
+		return violations
+	}
+
+	if object, ok := value.(map[string]interface{}); ok {
+		value = withJSONDefaults(schema, object)
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && !containsJSONValue(enum, value) {
+		addViolation("must be one of %s", formatJSONValues(enum))
+	}
+	if constant, ok := schema["const"]; ok && !equalJSONValues(constant, value) {
+		addViolation("must be %s", formatJSONValues([]interface{}{constant}))
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		violations = append(violations, validateJSONObject(schema, value, field, strict)...)
+	case []interface{}:
+		violations = append(violations, validateJSONArray(schema, value, field)...)
+	case string:
+		length := int64(utf8.RuneCountInString(value))
+		if minLength, ok := jsonInteger(schema["minLength"]); ok && length < minLength {
+			addViolation("must be at least %d characters long", minLength)
+		}
+		if maxLength, ok := jsonInteger(schema["maxLength"]); ok && length > maxLength {
+			addViolation("must be at most %d characters long", maxLength)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re := compileSchemaPattern(pattern); re != nil && !re.MatchString(value) {
+				addViolation("must match the pattern %s", pattern)
+			}
+		}
+	default:
+		if number, ok := jsonFloat(value); ok {
+			violations = append(violations, validateJSONNumber(schema, number, field)...)
+		}
+	}
+
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, subschema := range jsonSchemas(allOf) {
+			violations = append(violations, validateJSONSchema(subschema, value, field, false)...)
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		if matches, closest := matchJSONSchemas(jsonSchemas(anyOf), value, field); matches == 0 {
+			violations = append(violations, closest...)
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		matches, closest := matchJSONSchemas(jsonSchemas(oneOf), value, field)
+		if matches == 0 {
+			violations = append(violations, closest...)
+		} else if matches > 1 {
+			addViolation("must match only one of the %d allowed configurations, matches %d", len(oneOf), matches)
+		}
+	}
+	if not, ok := schema["not"].(map[string]interface{}); ok && len(validateJSONSchema(not, value, field, false)) == 0 {
+		addViolation("must not match %s", formatJSONValues([]interface{}{not}))
+	}
+	if condition, ok := schema["if"].(map[string]interface{}); ok {
+		branch := "else"
+		if len(validateJSONSchema(condition, value, field, false)) == 0 {
+			branch = "then"
+		}
+		if subschema, ok := schema[branch].(map[string]interface{}); ok {
+			violations = append(violations, validateJSONSchema(subschema, value, field, false)...)
+		}
+	}
+
+	return violations
+}
+
+// withJSONDefaults returns the object with the defaults of its missing
+// properties set.
+func withJSONDefaults(schema map[string]interface{}, value map[string]interface{}) map[string]interface{} {
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	var withDefaults map[string]interface{}
+	for name, property := range properties {
+		property, ok := property.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		defaultValue, ok := property["default"]
+		if _, set := value[name]; !ok || set {
+			continue
+		}
+
+		if withDefaults == nil {
+			withDefaults = make(map[string]interface{}, len(value)+1)
+			for key, item := range value {
+				withDefaults[key] = item
+			}
+		}
+		withDefaults[name] = defaultValue
+	}
+
+	if withDefaults == nil {
+		return value
+	}
+	return withDefaults
+}
+
+func validateJSONObject(schema map[string]interface{}, value map[string]interface{}, field string, strict bool) []schemaViolation {
+	var violations []schemaViolation
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	patternProperties, _ := schema["patternProperties"].(map[string]interface{})
+	additionalProperties, hasAdditionalProperties := schema["additionalProperties"]
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, ok := value[name]; !ok {
+					violations = append(violations, schemaViolation{Field: joinSchemaField(field, name), Message: "is required"})
+				}
+			}
+		}
+	}
+
+	if minProperties, ok := jsonInteger(schema["minProperties"]); ok && int64(len(value)) < minProperties {
+		violations = append(violations, schemaViolation{Field: field, Message: fmt.Sprintf("must have at least %d fields", minProperties)})
+	}
+	if maxProperties, ok := jsonInteger(schema["maxProperties"]); ok && int64(len(value)) > maxProperties {
+		violations = append(violations, schemaViolation{Field: field, Message: fmt.Sprintf("must have at most %d fields", maxProperties)})
+	}
+
+	for _, name := range sortedKeys(value) {
+		propertyField := joinSchemaField(field, name)
+		matched := false
+
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			matched = true
+			violations = append(violations, validateJSONSchema(property, value[name], propertyField, true)...)
+		}
+		for _, pattern := range sortedKeys(patternProperties) {
+			if re := compileSchemaPattern(pattern); re != nil && re.MatchString(name) {
+				matched = true
+				if property, ok := patternProperties[pattern].(map[string]interface{}); ok {
+					violations = append(violations, validateJSONSchema(property, value[name], propertyField, true)...)
+				}
+			}
+		}
+		if matched {
+			continue
+		}
+
+		switch additionalProperties := additionalProperties.(type) {
+		case bool:
+			if !additionalProperties {
+				violations = append(violations, schemaViolation{Field: propertyField, Message: "is not allowed"})
+			}
+		case map[string]interface{}:
+			violations = append(violations, validateJSONSchema(additionalProperties, value[name], propertyField, true)...)
+		default:
+			if strict && !hasAdditionalProperties && len(properties) > 0 && !isKnownJSONProperty(schema, name) {
+				violations = append(violations, schemaViolation{Field: propertyField, Message: "is not a known field"})
+			}
+		}
+	}
+
+	if dependencies, ok := schema["dependencies"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(dependencies) {
+			if _, ok := value[name]; !ok {
+				continue
+			}
+			switch dependency := dependencies[name].(type) {
+			case []interface{}:
+				for _, required := range dependency {
+					if required, ok := required.(string); ok {
+						if _, ok := value[required]; !ok {
+							violations = append(violations, schemaViolation{Field: joinSchemaField(field, required), Message: fmt.Sprintf("is required with `%s`", joinSchemaField(field, name))})
+						}
+					}
+				}
+			case map[string]interface{}:
+				violations = append(violations, validateJSONSchema(dependency, value, field, false)...)
+			}
+		}
+	}
+
+	return violations
+}
+
+// isKnownJSONProperty reports whether the property is described by the
+// schema, including its alternatives and conditional parts.
+func isKnownJSONProperty(schema map[string]interface{}, name string) bool {
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		if _, ok := properties[name]; ok {
+			return true
+		}
+	}
+	if patternProperties, ok := schema["patternProperties"].(map[string]interface{}); ok {
+		for pattern := range patternProperties {
+			if re := compileSchemaPattern(pattern); re == nil || re.MatchString(name) {
+				return true
+			}
+		}
+	}
+	if additionalProperties, ok := schema["additionalProperties"]; ok && additionalProperties != false {
+		return true
+	}
+
+	var subschemas []interface{}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if alternatives, ok := schema[keyword].([]interface{}); ok {
+			subschemas = append(subschemas, alternatives...)
+		}
+	}
+	for _, keyword := range []string{"then", "else"} {
+		subschemas = append(subschemas, schema[keyword])
+	}
+	if dependencies, ok := schema["dependencies"].(map[string]interface{}); ok {
+		for _, dependency := range dependencies {
+			subschemas = append(subschemas, dependency)
+		}
+	}
+
+	for _, subschema := range jsonSchemas(subschemas) {
+		if isKnownJSONProperty(subschema, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateJSONArray(schema map[string]interface{}, value []interface{}, field string) []schemaViolation {
+	var violations []schemaViolation
+
+	if minItems, ok := jsonInteger(schema["minItems"]); ok && int64(len(value)) < minItems {
+		violations = append(violations, schemaViolation{Field: field, Message: fmt.Sprintf("must have at least %d items", minItems)})
+	}
+	if maxItems, ok := jsonInteger(schema["maxItems"]); ok && int64(len(value)) > maxItems {
+		violations = append(violations, schemaViolation{Field: field, Message: fmt.Sprintf("must have at most %d items", maxItems)})
+	}
+	if uniqueItems, _ := schema["uniqueItems"].(bool); uniqueItems {
+		for i := range value {
+			for j := 0; j < i; j++ {
+				if equalJSONValues(value[i], value[j]) {
+					violations = append(violations, schemaViolation{Field: fmt.Sprintf("%s[%d]", field, i), Message: fmt.Sprintf("must be unique, it repeats item %d", j)})
+				}
+			}
+		}
+	}
+
+	switch items := schema["items"].(type) {
+	case map[string]interface{}:
+		for i, item := range value {
+			violations = append(violations, validateJSONSchema(items, item, fmt.Sprintf("%s[%d]", field, i), true)...)
+		}
+	case []interface{}:
+		for i, itemSchema := range jsonSchemas(items) {
+			if i < len(value) {
+				violations = append(violations, validateJSONSchema(itemSchema, value[i], fmt.Sprintf("%s[%d]", field, i), true)...)
+			}
+		}
+	}
+
+	return violations
+}
+
+func validateJSONNumber(schema map[string]interface{}, value float64, field string) []schemaViolation {
+	var violations []schemaViolation
+	addViolation := func(format string, args ...interface{}) {
+		violations = append(violations, schemaViolation{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// The exclusive bounds are booleans modifying minimum and maximum in the draft 4, numbers since the draft 6
+	exclusiveMinimum, exclusiveMinimumFlag := schema["exclusiveMinimum"].(bool)
+	if minimum, ok := jsonFloat(schema["minimum"]); ok {
+		if exclusiveMinimumFlag && exclusiveMinimum && value <= minimum {
+			addViolation("must be greater than %s", formatJSONNumber(minimum))
+		} else if value < minimum {
+			addViolation("must be at least %s", formatJSONNumber(minimum))
+		}
+	}
+	if minimum, ok := jsonFloat(schema["exclusiveMinimum"]); ok && value <= minimum {
+		addViolation("must be greater than %s", formatJSONNumber(minimum))
+	}
+
+	exclusiveMaximum, exclusiveMaximumFlag := schema["exclusiveMaximum"].(bool)
+	if maximum, ok := jsonFloat(schema["maximum"]); ok {
+		if exclusiveMaximumFlag && exclusiveMaximum && value >= maximum {
+			addViolation("must be less than %s", formatJSONNumber(maximum))
+		} else if value > maximum {
+			addViolation("must be at most %s", formatJSONNumber(maximum))
+		}
+	}
+	if maximum, ok := jsonFloat(schema["exclusiveMaximum"]); ok && value >= maximum {
+		addViolation("must be less than %s", formatJSONNumber(maximum))
+	}
+
+	if multipleOf, ok := jsonFloat(schema["multipleOf"]); ok && multipleOf > 0 {
+		if quotient := value / multipleOf; quotient != math.Trunc(quotient) {
+			addViolation("must be a multiple of %s", formatJSONNumber(multipleOf))
+		}
+	}
+
+	return violations
+}
+
+// matchJSONSchemas returns the number of the alternatives the value matches,
+// and the violations of the closest alternative when it matches none.
+func matchJSONSchemas(alternatives []map[string]interface{}, value interface{}, field string) (int, []schemaViolation) {
+	matches := 0
+	var closest []schemaViolation
+	for _, alternative := range alternatives {
+		violations := validateJSONSchema(alternative, value, field, false)
+		if len(violations) == 0 {
+			matches++
+			continue
+		}
+		if closest == nil || len(violations) < len(closest) {
+			closest = violations
+		}
+	}
+
+	return matches, closest
+}
+
+func joinSchemaField(field string, name string) string {
+	if field == "" {
+		return name
+	}
+
+	return field + "." + name
+}
+
+// jsonSchemas returns the schemas of the list, skipping the boolean schemas.
+func jsonSchemas(values []interface{}) []map[string]interface{} {
+	var schemas []map[string]interface{}
+	for _, value := range values {
+		if schema, ok := value.(map[string]interface{}); ok {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	return schemas
+}
+
+func jsonSchemaTypes(value interface{}) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var schemaTypes []string
+		for _, schemaType := range value {
+			if schemaType, ok := schemaType.(string); ok {
+				schemaTypes = append(schemaTypes, schemaType)
+			}
+		}
+		return schemaTypes
+	}
+
+	return nil
+}
+
+func matchesJSONType(value interface{}, schemaTypes []string) bool {
+	for _, schemaType := range schemaTypes {
+		switch schemaType {
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "number":
+			if _, ok := jsonFloat(value); ok {
+				return true
+			}
+		case "integer":
+			if number, ok := jsonFloat(value); ok && number == math.Trunc(number) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func describeJSONTypes(schemaTypes []string) string {
+	descriptions := make([]string, 0, len(schemaTypes))
+	for _, schemaType := range schemaTypes {
+		switch schemaType {
+		case "object", "array", "integer":
+			descriptions = append(descriptions, "an "+schemaType)
+		case "null":
+			descriptions = append(descriptions, "null")
+		default:
+			descriptions = append(descriptions, "a "+schemaType)
+		}
+	}
+
+	return strings.Join(descriptions, " or ")
+}
+
+// jsonFloat returns the number of the JSON value, decoded either as float64
+// or as json.Number.
+func jsonFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case int64:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case json.Number:
+		number, err := value.Float64()
+		return number, err == nil
+	}
+
+	return 0, false
+}
+
+func jsonInteger(value interface{}) (int64, bool) {
+	number, ok := jsonFloat(value)
+	return int64(number), ok
+}
+
+func formatJSONNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatJSONValues(values []interface{}) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		valueBytes, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		formatted = append(formatted, string(valueBytes))
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+func containsJSONValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if equalJSONValues(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equalJSONValues compares the JSON values, whatever the type of their numbers.
+func equalJSONValues(a interface{}, b interface{}) bool {
+	return reflect.DeepEqual(normalizeJSONNumbers(a), normalizeJSONNumbers(b))
+}
+
+func normalizeJSONNumbers(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			normalized[key] = normalizeJSONNumbers(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(value))
+		for i, item := range value {
+			normalized[i] = normalizeJSONNumbers(item)
+		}
+		return normalized
+	}
+	if number, ok := jsonFloat(value); ok {
+		return number
+	}
+
+	return value
+}
+
+// schemaPatterns caches the compiled patterns of the schemas. The patterns
+// Go doesn't support, like the PCRE lookaheads, are left to APISIX.
+var schemaPatterns sync.Map
+
+func compileSchemaPattern(pattern string) *regexp.Regexp {
+	if re, ok := schemaPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		re = nil
+	}
+	schemaPatterns.Store(pattern, re)
+
+	return re
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
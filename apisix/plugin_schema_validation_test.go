@@ -0,0 +1,377 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+func TestValidateJSONSchema(t *testing.T) {
+	limitCount := `{
+		"type": "object",
+		"properties": {
+			"count": {"type": "integer", "exclusiveMinimum": 0},
+			"time_window": {"type": "integer", "exclusiveMinimum": 0},
+			"policy": {"type": "string", "enum": ["local", "redis"], "default": "local"},
+			"rules": {"type": "array", "items": {"type": "object", "properties": {"key": {"type": "string", "minLength": 1}}, "required": ["key"]}, "uniqueItems": true}
+		},
+		"required": ["count", "time_window"],
+		"if": {"properties": {"policy": {"enum": ["redis"]}}},
+		"then": {"properties": {"redis_host": {"type": "string"}}, "required": ["redis_host"]}
+	}`
+
+	testCases := map[string]struct {
+		schema     string
+		value      string
+		violations []string
+	}{
+		"valid": {
+			schema: limitCount,
+			value:  `{"count": 2, "time_window": 60, "rules": [{"key": "a"}]}`,
+		},
+		"misspelled field": {
+			schema:     limitCount,
+			value:      `{"count": 2, "time_windw": 60}`,
+			violations: []string{"`time_window` is required", "`time_windw` is not a known field"},
+		},
+		"invalid values": {
+			schema: limitCount,
+			value:  `{"count": 0, "time_window": 1.5, "policy": "cluster", "rules": [{"key": ""}, {"key": ""}]}`,
+			violations: []string{
+				"`count` must be greater than 0",
+				"`policy` must be one of \"local\", \"redis\"",
+				"`rules[1]` must be unique, it repeats item 0",
+				"`rules[0].key` must be at least 1 characters long",
+				"`rules[1].key` must be at least 1 characters long",
+				"`time_window` must be an integer",
+			},
+		},
+		"conditional field": {
+			schema:     limitCount,
+			value:      `{"count": 2, "time_window": 60, "policy": "redis"}`,
+			violations: []string{"`redis_host` is required"},
+		},
+		"conditional field known": {
+			schema: limitCount,
+			value:  `{"count": 2, "time_window": 60, "policy": "redis", "redis_host": "127.0.0.1"}`,
+		},
+		"not an object": {
+			schema:     limitCount,
+			value:      `[]`,
+			violations: []string{"the configuration must be an object"},
+		},
+		"one of": {
+			schema:     `{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}, "oneOf": [{"required": ["a"]}, {"required": ["b"]}]}`,
+			value:      `{"a": "x", "b": "y"}`,
+			violations: []string{"the configuration must match only one of the 2 allowed configurations, matches 2"},
+		},
+		"any of the closest": {
+			schema:     `{"anyOf": [{"type": "string", "pattern": "^\\d+$"}, {"type": "object"}]}`,
+			value:      `"abc"`,
+			violations: []string{"the configuration must match the pattern ^\\d+$"},
+		},
+		"additional properties": {
+			schema:     `{"type": "object", "properties": {"a": {"type": "string"}}, "patternProperties": {"^x-": {"type": "integer"}}, "additionalProperties": false}`,
+			value:      `{"a": "x", "x-b": "y", "c": 1}`,
+			violations: []string{"`c` is not allowed", "`x-b` must be an integer"},
+		},
+		"draft 4 exclusive bounds": {
+			schema:     `{"type": "number", "minimum": 0, "exclusiveMinimum": true, "maximum": 1}`,
+			value:      `0`,
+			violations: []string{"the configuration must be greater than 0"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var schema map[string]interface{}
+			if err := decodeJSONNumbers([]byte(testCase.schema), &schema); err != nil {
+				t.Fatal(err)
+			}
+			var value interface{}
+			if err := decodeJSONNumbers([]byte(testCase.value), &value); err != nil {
+				t.Fatal(err)
+			}
+
+			var violations []string
+			for _, violation := range validateJSONSchema(schema, value, "", true) {
+				violations = append(violations, violation.String())
+			}
+
+			if !reflect.DeepEqual(violations, testCase.violations) {
+				t.Errorf("expected %q, got %q", testCase.violations, violations)
+			}
+		})
+	}
+}
+
+func TestCheckPluginSchemas(t *testing.T) {
+	cacheDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(cacheDir, "3.15.0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cacheDir, "3.15.0", "custom-auth.json"), []byte(`{"type": "object", "properties": {"header": {"type": "string"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client := &apisixClient{pluginSchemas: &pluginSchemaStore{cacheDir: cacheDir}}
+
+	testCases := map[string]struct {
+		schema     schema.Schema
+		schemaType string
+		plugins    string
+		sensitive  string
+		errors     map[string]string
+	}{
+		"bundled snapshot": {
+			schema:  model.RouteSchema,
+			plugins: `{"limit-count": {"count": 2, "time_window": 60}, "traffic-split": {"rules": []}}`,
+		},
+		"bundled snapshot alternatives": {
+			schema:  model.RouteSchema,
+			plugins: `{"proxy-rewrite": {"uri": "/", "headers": {"X-Api-Version": "v1"}}, "response-rewrite": {"headers": {"set": {"X-Server": "apisix"}}}}`,
+		},
+		"cached custom plugin": {
+			schema:  model.RouteSchema,
+			plugins: `{"custom-auth": {"header": "X-Auth"}}`,
+		},
+		"unknown plugin": {
+			schema:  model.RouteSchema,
+			plugins: `{"limit-cuont": {"count": 2, "time_window": 60}}`,
+			errors:  map[string]string{"plugins": "Unknown Plugin"},
+		},
+		"invalid configuration": {
+			schema:  model.RouteSchema,
+			plugins: `{"limit-count": {"count": 2, "time_windw": 60}}`,
+			errors:  map[string]string{"plugins": "Invalid Plugin Configuration"},
+		},
+		"consumer schema": {
+			schema:     model.ConsumerSchema,
+			schemaType: pluginSchemaTypeConsumer,
+			plugins:    `{"key-auth": {}}`,
+			errors:     map[string]string{"plugins": "Invalid Plugin Configuration"},
+		},
+		"consumer schema with sensitive plugins": {
+			schema:     model.ConsumerSchema,
+			schemaType: pluginSchemaTypeConsumer,
+			plugins:    `{"key-auth": {}}`,
+			sensitive:  `{"key-auth": {"key": "secret"}}`,
+		},
+		"sensitive plugin": {
+			schema:     model.ConsumerSchema,
+			schemaType: pluginSchemaTypeConsumer,
+			sensitive:  `{"basic-auth": {"username": "user"}}`,
+			errors:     map[string]string{"sensitive_plugins": "Invalid Plugin Configuration"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			values := map[string]tftypes.Value{}
+			if testCase.plugins != "" {
+				values["plugins"] = tftypes.NewValue(tftypes.String, testCase.plugins)
+			}
+			if testCase.sensitive != "" {
+				values["sensitive_plugins"] = tftypes.NewValue(tftypes.String, testCase.sensitive)
+			}
+
+			var diags diag.Diagnostics
+			client.checkPluginSchemas(context.Background(), testConfig(t, testCase.schema, values), testCase.schemaType, &diags)
+
+			errors := map[string]string{}
+			for _, d := range diags {
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if !ok {
+					t.Fatalf("expected the diagnostic on an attribute, got %v", d)
+				}
+				errors[withPath.Path().String()] = d.Summary()
+			}
+			if len(errors) == 0 {
+				errors = nil
+			}
+			if !reflect.DeepEqual(errors, testCase.errors) {
+				t.Errorf("expected %v, got %v", testCase.errors, diags)
+			}
+		})
+	}
+}
+
+func TestPluginSchemaStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "APISIX/3.12.0")
+		switch r.URL.Path {
+		case "/apisix/admin/schema/plugins/custom-auth":
+			if r.URL.Query().Get("schema_type") == pluginSchemaTypeConsumer {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error_msg":"not found consumer schema"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"type":"object","properties":{"header":{"type":"string"}}}`))
+		case "/apisix/admin/schema/plugins/limit-count":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error_msg":"not found plugin"}`))
+		}
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	client := &apisixClient{ApiClient: &api_client.ApiClient{Endpoint: server.URL, HTTPClient: server.Client()}}
+	store := &pluginSchemaStore{client: client, cacheDir: cacheDir}
+
+	// The schemas of APISIX are cached on disk, and the consumer schema falls back to the main one
+	custom := store.schema(context.Background(), "custom-auth", pluginSchemaTypeConsumer)
+	if !custom.Known || custom.Source != "APISIX" || custom.Schema["type"] != "object" {
+		t.Errorf("unexpected custom-auth schema %+v", custom)
+	}
+	if store.version != "3.12.0" {
+		t.Errorf("expected the APISIX version 3.12.0, got %q", store.version)
+	}
+	cached, err := os.ReadFile(filepath.Join(cacheDir, "3.12.0", "custom-auth.json"))
+	if err != nil || !json.Valid(cached) {
+		t.Errorf("expected the custom-auth schema cached for APISIX 3.12.0, got %s, %v", cached, err)
+	}
+
+	// The bundled snapshot is used while APISIX is unavailable
+	limitCount := store.schema(context.Background(), "limit-count", "")
+	if !limitCount.Known || !strings.Contains(limitCount.Source, "snapshot") {
+		t.Errorf("unexpected limit-count schema %+v", limitCount)
+	}
+
+	// APISIX tells the unknown plugins
+	if unknown := store.schema(context.Background(), "limit-count-typo", ""); unknown.Known {
+		t.Errorf("expected an unknown plugin, got %+v", unknown)
+	}
+
+	// The cache of the previous runs serves the offline validation
+	offline := &pluginSchemaStore{cacheDir: cacheDir}
+	if custom := offline.schema(context.Background(), "custom-auth", ""); !custom.Known || custom.Schema == nil {
+		t.Errorf("expected the cached custom-auth schema, got %+v", custom)
+	}
+
+	// The schemas cached for another APISIX version are kept apart
+	if err := os.MkdirAll(filepath.Join(cacheDir, "3.9.0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cacheDir, "3.9.0", "custom-auth.json"), []byte(`{"type":"object","properties":{"token":{"type":"string"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for version, expected := range map[string]string{"3.9.2": "token", "3.12.0": "header", "3.16.0": "header", "": "header"} {
+		offline := &pluginSchemaStore{cacheDir: cacheDir, version: version}
+		custom := offline.schema(context.Background(), "custom-auth", "")
+		properties, _ := custom.Schema["properties"].(map[string]interface{})
+		if _, ok := properties[expected]; !ok {
+			t.Errorf("expected the custom-auth schema with %s cached for APISIX %q, got %+v", expected, version, custom)
+		}
+	}
+}
+
+func TestPluginSchemaStoreConcurrentFetches(t *testing.T) {
+	released := make(chan struct{})
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/apisix/admin/schema/plugins/slow-auth":
+			// Answers once the other plugin was fetched meanwhile
+			select {
+			case <-released:
+			case <-time.After(5 * time.Second):
+				w.WriteHeader(http.StatusGatewayTimeout)
+				return
+			}
+		case "/apisix/admin/schema/plugins/fast-auth":
+			close(released)
+		}
+		_, _ = w.Write([]byte(`{"type":"object"}`))
+	}))
+	defer server.Close()
+
+	client := &apisixClient{ApiClient: &api_client.ApiClient{Endpoint: server.URL, HTTPClient: server.Client()}}
+	store := &pluginSchemaStore{client: client}
+
+	var wg sync.WaitGroup
+	slowSchemas := make([]pluginSchema, 3)
+	for i := range slowSchemas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slowSchemas[i] = store.schema(context.Background(), "slow-auth", "")
+		}()
+	}
+	// Lets the slow fetch start before fetching the other plugin
+	for {
+		mu.Lock()
+		started := requests["/apisix/admin/schema/plugins/slow-auth"] > 0
+		mu.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if fast := store.schema(context.Background(), "fast-auth", ""); fast.Source != "APISIX" {
+		t.Errorf("unexpected fast-auth schema %+v", fast)
+	}
+	wg.Wait()
+
+	for _, slow := range slowSchemas {
+		if slow.Source != "APISIX" {
+			t.Errorf("expected the slow-auth schema to be fetched while fetching fast-auth, got %+v", slow)
+		}
+	}
+	if count := requests["/apisix/admin/schema/plugins/slow-auth"]; count != 1 {
+		t.Errorf("expected the concurrent reads of the slow-auth schema to wait for a single fetch, got %d requests", count)
+	}
+}
+
+func TestBundledPluginSchemaVersion(t *testing.T) {
+	for version, expected := range map[string]string{
+		"":       "3.15",
+		"3.15.0": "3.15",
+		"3.16.1": "3.15",
+		"3.9.0":  "3.15",
+	} {
+		if actual := bundledPluginSchemaVersion(version); actual != expected {
+			t.Errorf("expected the %s snapshot for %q, got %s", expected, version, actual)
+		}
+	}
+}
+
+// testConfig returns the configuration of the schema with the given values,
+// the other attributes being null.
+func testConfig(t *testing.T, configSchema schema.Schema, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	objectType := configSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+			continue
+		}
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	return tfsdk.Config{Schema: configSchema, Raw: tftypes.NewValue(objectType, attributes)}
+}
@@ -0,0 +1,418 @@
+package apisix
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// bundledPluginSchemas holds the snapshots of the APISIX plugin JSON schemas,
+// one directory per APISIX version, refreshed with tools/pluginschemagen.
+//
+//go:embed plugin_schemas
+var bundledPluginSchemas embed.FS
+
+const (
+	// pluginSchemasCacheDirEnv overrides the directory of the plugin JSON
+	// schemas cached from APISIX, in the layout of the bundled snapshots.
+	pluginSchemasCacheDirEnv = "APISIX_PLUGIN_SCHEMAS_CACHE_DIR"
+
+	// pluginSchemaTypeConsumer selects the schema of the plugin configuration
+	// on the consumers, like the key of key-auth.
+	pluginSchemaTypeConsumer = "consumer"
+)
+
+// serverVersionPattern matches the APISIX version in the Server header of the
+// Admin API responses, like `APISIX/3.15.0`.
+var serverVersionPattern = regexp.MustCompile(`APISIX/(\d+\.\d+(?:\.\d+)?)`)
+
+// pluginSchemaVersionPattern matches the directories of the plugin schemas of
+// an APISIX version, like `3.15` or `3.12.0`.
+var pluginSchemaVersionPattern = regexp.MustCompile(`^\d+\.\d+(?:\.\d+)?$`)
+
+// pluginSchema is the JSON schema of a plugin, as resolved by pluginSchemaStore.
+type pluginSchema struct {
+	// Known reports whether the plugin exists, even when its schema isn't available offline
+	Known bool
+
+	// Schema is the JSON schema of the plugin configuration, nil when it isn't available
+	Schema map[string]interface{}
+
+	// Source describes where the schema comes from, e.g. `APISIX` or `the APISIX 3.15 snapshot`
+	Source string
+}
+
+// pluginSchemaStore resolves the JSON schemas of the plugins: from APISIX when
+// the provider is configured, then from the schemas cached on disk when they
+// were fetched before, then from the snapshots bundled with the provider,
+// which `terraform validate` uses as it runs without the provider configured.
+type pluginSchemaStore struct {
+	// client fetches the schemas from APISIX, nil when the provider isn't configured
+	client *apisixClient
+
+	// cacheDir is the directory of the schemas fetched from APISIX, empty to disable the cache
+	cacheDir string
+
+	mu      sync.Mutex
+	schemas map[string]*pluginSchemaEntry
+
+	// version is the APISIX version, detected from the Admin API responses
+	version string
+}
+
+// pluginSchemaEntry holds the schema of a plugin once resolved. A zero
+// resolved means the schema must be resolved.
+type pluginSchemaEntry struct {
+	mu       sync.Mutex
+	resolved bool
+	schema   pluginSchema
+}
+
+// offlinePluginSchemas resolves the schemas when the provider isn't
+// configured, like during `terraform validate`.
+var offlinePluginSchemas = newPluginSchemaStore(nil)
+
+func newPluginSchemaStore(client *apisixClient) *pluginSchemaStore {
+	cacheDir := os.Getenv(pluginSchemasCacheDirEnv)
+	if cacheDir == "" {
+		if userCacheDir, err := os.UserCacheDir(); err == nil {
+			cacheDir = filepath.Join(userCacheDir, "terraform-provider-apisix", "plugin_schemas")
+		}
+	}
+
+	return &pluginSchemaStore{
+		client:   client,
+		cacheDir: cacheDir,
+	}
+}
+
+// pluginSchemaStore returns the store of the plugin schemas of the client,
+// the offline store when the provider isn't configured.
+func (c *apisixClient) pluginSchemaStore() *pluginSchemaStore {
+	if c == nil || c.pluginSchemas == nil {
+		return offlinePluginSchemas
+	}
+
+	return c.pluginSchemas
+}
+
+func (s *pluginSchemaStore) entry(key string) *pluginSchemaEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.schemas == nil {
+		s.schemas = map[string]*pluginSchemaEntry{}
+	}
+	entry, ok := s.schemas[key]
+	if !ok {
+		entry = &pluginSchemaEntry{}
+		s.schemas[key] = entry
+	}
+
+	return entry
+}
+
+// schema returns the JSON schema of the plugin, of the given schema type or
+// the main one when the type is empty. The consumer schema falls back to the
+// main one for the plugins without a consumer schema, like APISIX does. The
+// concurrent reads of a schema wait for a single fetch, while the schemas of
+// the other plugins are fetched meanwhile.
+func (s *pluginSchemaStore) schema(ctx context.Context, name string, schemaType string) pluginSchema {
+	key := name
+	if schemaType != "" {
+		key += "." + schemaType
+	}
+	entry := s.entry(key)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.resolved {
+		return entry.schema
+	}
+
+	schema, ok := s.fetchSchema(ctx, name, schemaType)
+	if !ok {
+		schema = s.offlineSchema(name, schemaType)
+	}
+	if schemaType != "" && schema.Schema == nil {
+		schema = s.schema(ctx, name, "")
+	}
+
+	entry.schema = schema
+	entry.resolved = true
+	return schema
+}
+
+// apisixVersion returns the APISIX version detected so far, empty when it's
+// unknown.
+func (s *pluginSchemaStore) apisixVersion() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.version
+}
+
+func (s *pluginSchemaStore) setAPISIXVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.version = version
+}
+
+// fetchSchema fetches the schema from APISIX, and caches it on disk. It
+// returns false when APISIX couldn't tell, e.g. when the provider isn't
+// configured or APISIX is unavailable.
+func (s *pluginSchemaStore) fetchSchema(ctx context.Context, name string, schemaType string) (pluginSchema, bool) {
+	if s.client == nil {
+		return pluginSchema{}, false
+	}
+
+	objectPath := "schema/plugins/" + name
+	if schemaType != "" {
+		objectPath += "?schema_type=" + schemaType
+	}
+
+	body, header, err := s.client.adminDoResponse(ctx, http.MethodGet, objectPath, nil)
+	if header != nil {
+		if match := serverVersionPattern.FindStringSubmatch(header.Get("Server")); match != nil {
+			s.setAPISIXVersion(match[1])
+		}
+	}
+	if isStatusError(err, http.StatusBadRequest, http.StatusNotFound) {
+		// APISIX doesn't know the plugin, or the plugin has no schema of this type
+		return pluginSchema{Known: schemaType != ""}, true
+	}
+	if err != nil {
+		tflog.Warn(ctx, "Could not fetch the plugin schema from APISIX, using the offline schemas", map[string]any{
+			"plugin": name,
+			"error":  err.Error(),
+		})
+		return pluginSchema{}, false
+	}
+
+	var schema map[string]interface{}
+	if err := decodeJSONNumbers(body, &schema); err != nil {
+		tflog.Warn(ctx, "Could not decode the plugin schema from APISIX, using the offline schemas", map[string]any{
+			"plugin": name,
+			"error":  err.Error(),
+		})
+		return pluginSchema{}, false
+	}
+
+	s.cacheSchema(ctx, name, schemaType, body)
+	return pluginSchema{Known: true, Schema: schema, Source: "APISIX"}, true
+}
+
+// cacheSchema writes the schema fetched from APISIX into the directory of
+// the APISIX version in the cache directory, so that the next runs validate
+// the plugins offline with it. The schemas are only cached once the version
+// is known, to not mix the schemas of several versions.
+func (s *pluginSchemaStore) cacheSchema(ctx context.Context, name string, schemaType string, schema []byte) {
+	version := s.apisixVersion()
+	if s.cacheDir == "" || version == "" {
+		return
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, schema, "", "  "); err != nil {
+		return
+	}
+	indented.WriteByte('\n')
+
+	versionDir := filepath.Join(s.cacheDir, version)
+	err := os.MkdirAll(versionDir, 0o755)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(versionDir, pluginSchemaFile(name, schemaType)), indented.Bytes(), 0o644)
+	}
+	if err != nil {
+		tflog.Debug(ctx, "Could not cache the plugin schema", map[string]any{"plugin": name, "error": err.Error()})
+	}
+}
+
+// offlineSchema returns the schema cached on disk for the APISIX version, or
+// else the one of the bundled snapshot of the APISIX version.
+func (s *pluginSchemaStore) offlineSchema(name string, schemaType string) pluginSchema {
+	file := pluginSchemaFile(name, schemaType)
+
+	cachedVersion := s.cachedPluginSchemaVersion()
+	if cachedVersion != "" {
+		if data, err := os.ReadFile(filepath.Join(s.cacheDir, cachedVersion, file)); err == nil {
+			var schema map[string]interface{}
+			if err := decodeJSONNumbers(data, &schema); err == nil {
+				return pluginSchema{Known: true, Schema: schema, Source: "the APISIX " + cachedVersion + " schemas cached in " + s.cacheDir}
+			}
+		}
+	}
+
+	version := bundledPluginSchemaVersion(s.apisixVersion())
+	if version == "" {
+		return pluginSchema{Known: s.knownPlugin(name, cachedVersion, "")}
+	}
+	if data, err := bundledPluginSchemas.ReadFile("plugin_schemas/" + version + "/" + file); err == nil {
+		var schema map[string]interface{}
+		if err := decodeJSONNumbers(data, &schema); err == nil {
+			return pluginSchema{Known: true, Schema: schema, Source: "the APISIX " + version + " snapshot"}
+		}
+	}
+
+	return pluginSchema{Known: s.knownPlugin(name, cachedVersion, version)}
+}
+
+// knownPlugin reports whether the plugin is listed in the `plugins.txt` of
+// the cached schemas or of the bundled snapshot of the given versions.
+func (s *pluginSchemaStore) knownPlugin(name string, cachedVersion string, version string) bool {
+	var lists [][]byte
+	if cachedVersion != "" {
+		if data, err := os.ReadFile(filepath.Join(s.cacheDir, cachedVersion, "plugins.txt")); err == nil {
+			lists = append(lists, data)
+		}
+	}
+	if version != "" {
+		if data, err := bundledPluginSchemas.ReadFile("plugin_schemas/" + version + "/plugins.txt"); err == nil {
+			lists = append(lists, data)
+		}
+	}
+
+	for _, list := range lists {
+		for _, line := range strings.Split(string(list), "\n") {
+			if strings.TrimSpace(line) == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// source describes where the schemas of the plugins come from, for the
+// diagnostics.
+func (s *pluginSchemaStore) source() string {
+	if s.client != nil {
+		return "APISIX"
+	}
+
+	sources := []string{}
+	if s.cacheDir != "" {
+		sources = append(sources, "the schemas cached in "+s.cacheDir)
+	}
+	if version := bundledPluginSchemaVersion(s.apisixVersion()); version != "" {
+		sources = append(sources, "the APISIX "+version+" snapshot bundled with the provider")
+	}
+
+	return strings.Join(sources, " and ")
+}
+
+func pluginSchemaFile(name string, schemaType string) string {
+	if schemaType != "" {
+		return name + "." + schemaType + ".json"
+	}
+
+	return name + ".json"
+}
+
+// cachedPluginSchemaVersion returns the directory of the cached schemas to
+// use for the APISIX version, selected like the bundled snapshots.
+func (s *pluginSchemaStore) cachedPluginSchemaVersion() string {
+	if s.cacheDir == "" {
+		return ""
+	}
+
+	entries, err := os.ReadDir(s.cacheDir)
+	if err != nil {
+		return ""
+	}
+
+	return selectPluginSchemaVersion(entries, s.apisixVersion())
+}
+
+// bundledPluginSchemaVersion returns the bundled snapshot to use for the APISIX
+// version, see selectPluginSchemaVersion.
+func bundledPluginSchemaVersion(version string) string {
+	entries, err := fs.ReadDir(bundledPluginSchemas, "plugin_schemas")
+	if err != nil {
+		return ""
+	}
+
+	return selectPluginSchemaVersion(entries, version)
+}
+
+// selectPluginSchemaVersion returns the directory of the schemas to use for
+// the APISIX version among the directories named after the APISIX versions:
+// the latest one not newer than the version, the oldest one for the older
+// versions, and the latest one when the version is unknown.
+func selectPluginSchemaVersion(entries []fs.DirEntry, version string) string {
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() && pluginSchemaVersionPattern.MatchString(entry.Name()) {
+			versions = append(versions, entry.Name())
+		}
+	}
+	if len(versions) == 0 {
+		return ""
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+
+	if version == "" {
+		return versions[len(versions)-1]
+	}
+
+	selected := versions[0]
+	for _, bundled := range versions {
+		if compareVersions(bundled, version) <= 0 {
+			selected = bundled
+		}
+	}
+
+	return selected
+}
+
+// compareVersions compares the dotted versions, like `3.15` and `3.9.1`, on
+// the components both have.
+func compareVersions(a string, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aPart, _ := strconv.Atoi(aParts[i])
+		bPart, _ := strconv.Atoi(bParts[i])
+		if aPart != bPart {
+			return aPart - bPart
+		}
+	}
+
+	return 0
+}
+
+// decodeJSONNumbers decodes the JSON with the numbers kept as json.Number.
+func decodeJSONNumbers(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(value)
+}
+
+// isStatusError reports whether the Admin API responded with one of the statuses.
+func isStatusError(err error, statuses ...int) bool {
+	for _, status := range statuses {
+		if err != nil && strings.HasPrefix(err.Error(), fmt.Sprintf("status: %d", status)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,15 +2,19 @@ package apisix
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/holubovskyi/apisix-client-go"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -39,8 +43,14 @@ type apisixProvider struct {
 
 // apisixProviderModel maps provider schema data to a Go type.
 type apisixProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	ApiKey   types.String `tfsdk:"api_key"`
+	Endpoint           types.String `tfsdk:"endpoint"`
+	ApiKey             types.String `tfsdk:"api_key"`
+	ApiVersion         types.String `tfsdk:"api_version"`
+	UpdateStrategy     types.String `tfsdk:"update_strategy"`
+	CheckReferences    types.Bool   `tfsdk:"check_references"`
+	DeriveIDs          types.Bool   `tfsdk:"derive_ids"`
+	DeleteRetryTimeout types.String `tfsdk:"delete_retry_timeout"`
+	ReadCacheTTL       types.String `tfsdk:"read_cache_ttl"`
 }
 
 // Metadata returns the provider type name.
@@ -62,6 +72,57 @@ func (p *apisixProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Description: "API Key for APISIX API. May also be provided via APISIX_APIKEY environment variable.",
 				Optional:    true,
 			},
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: "Generation of the APISIX Admin API, `v3` for APISIX 3.x or `v2` for APISIX 2.x. Defaults to `v3`. " +
+					"With `v2`, requests and responses are translated to the APISIX 2.x format, and attributes that APISIX 2.x doesn't support are rejected at plan time. " +
+					"May also be provided via APISIX_API_VERSION environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{apiVersionV2, apiVersionV3}...),
+				},
+			},
+			"update_strategy": schema.StringAttribute{
+				MarkdownDescription: "How the objects are updated in APISIX, `put` or `patch`. Defaults to `put`. " +
+					"`put` replaces the whole object with the configuration. `patch` sends only the attributes changed since the last refresh, " +
+					"with `null` for the removed ones, so the fields the provider doesn't manage, e.g. the ones set by the APISIX Dashboard, are preserved. " +
+					"Applies to routes, services, upstreams, SSL certificates, consumer groups, plugin configs and global rules, " +
+					"and can be overridden with their `update_strategy` attribute.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{updateStrategyPut, updateStrategyPatch}...),
+				},
+			},
+			"check_references": schema.BoolAttribute{
+				MarkdownDescription: "Check at plan time that the objects referenced by ID exist in APISIX, e.g. the `upstream_id` of routes, services and stream routes, " +
+					"the `service_id` and `plugin_config_id` of routes, the `group_id` of consumers and the `tls.client_cert_id` of upstreams, " +
+					"which must also point to a `client` certificate. The references inside `plugins` are checked as well: the `upstream_id` of `traffic-split`, " +
+					"the consumers, consumer groups, services and routes of `consumer-restriction`, and the secrets of the `$secret://` values. " +
+					"The references to the objects created in the same plan pass when their ID is known at plan time. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"derive_ids": schema.BoolAttribute{
+				MarkdownDescription: "Derive at plan time the `id` of the new routes, services and upstreams configured without one from their `name`, " +
+					"of the SSL certificates from their certificate and of the stream routes from their matching attributes, " +
+					"so the same configuration gets the same IDs across environments and an interrupted create is retried on the same object. " +
+					"These values must then be unique per resource type, as the objects created with the same ID and configuration are the same APISIX object. " +
+					"Defaults to `false`, a random UUID is generated on create.",
+				Optional: true,
+			},
+			"delete_retry_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long the deletes of the upstreams, services, plugin configs, consumer groups, SSL certificates and secrets " +
+					"still used by other objects are retried, with an exponential backoff, e.g. `2m`. " +
+					"Helps when the objects using them are destroyed in parallel, e.g. from another module. Defaults to `0s`, no retry.",
+				Optional: true,
+			},
+			"read_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the objects listed from APISIX serve the reads, e.g. `1m`. " +
+					"The routes, services, upstreams, consumers, consumer groups, SSL certificates, global rules, plugin configs and stream routes " +
+					"are listed once per type, page by page, instead of being read one by one on refresh. " +
+					"Writing an object lists its type again on the next read, and the objects are read from APISIX before being created or after being imported. " +
+					"Defaults to `0s`, every object is read from APISIX.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -107,6 +168,7 @@ func (p *apisixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	endpoint := os.Getenv("APISIX_ENDPOINT")
 	apiKey := os.Getenv("APISIX_APIKEY")
+	apiVersion := os.Getenv("APISIX_API_VERSION")
 
 	if !config.Endpoint.IsNull() {
 		endpoint = config.Endpoint.ValueString()
@@ -116,6 +178,14 @@ func (p *apisixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		apiKey = config.ApiKey.ValueString()
 	}
 
+	if !config.ApiVersion.IsNull() {
+		apiVersion = config.ApiVersion.ValueString()
+	}
+
+	if apiVersion == "" {
+		apiVersion = apiVersionV3
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -139,11 +209,49 @@ func (p *apisixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if apiVersion != apiVersionV2 && apiVersion != apiVersionV3 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_version"),
+			"Invalid APISIX API Version",
+			"The provider cannot create the APISIX API client as the APISIX API version "+apiVersion+" is not supported. "+
+				"Set the api_version value in the configuration or the APISIX_API_VERSION environment variable to either v2 or v3.",
+		)
+	}
+
+	var deleteRetryTimeout time.Duration
+	if !config.DeleteRetryTimeout.IsNull() {
+		timeout, err := time.ParseDuration(config.DeleteRetryTimeout.ValueString())
+		if err != nil || timeout < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("delete_retry_timeout"),
+				"Invalid Delete Retry Timeout",
+				"The delete_retry_timeout value "+config.DeleteRetryTimeout.ValueString()+" is not a valid duration. "+
+					"Set it to a positive duration like 30s or 2m.",
+			)
+		}
+		deleteRetryTimeout = timeout
+	}
+
+	var readCacheTTL time.Duration
+	if !config.ReadCacheTTL.IsNull() {
+		ttl, err := time.ParseDuration(config.ReadCacheTTL.ValueString())
+		if err != nil || ttl < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_cache_ttl"),
+				"Invalid Read Cache TTL",
+				"The read_cache_ttl value "+config.ReadCacheTTL.ValueString()+" is not a valid duration. "+
+					"Set it to a positive duration like 30s or 5m, or to 0s to read every object from APISIX.",
+			)
+		}
+		readCacheTTL = ttl
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	ctx = tflog.SetField(ctx, "apisix_endpoint", endpoint)
+	ctx = tflog.SetField(ctx, "apisix_api_version", apiVersion)
 	ctx = tflog.SetField(ctx, "apisix_apikey", apiKey)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "apisix_apikey")
 
@@ -162,10 +270,48 @@ func (p *apisixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	// APISIX 2.x wraps the objects into etcd nodes, translate them on the fly
+	if apiVersion == apiVersionV2 {
+		client.HTTPClient = &http.Client{
+			Transport: adminAPIV2Transport{Nested: client.HTTPClient.Transport},
+		}
+	}
+
+	// Serve the reads from the lists of the objects, listed once per type
+	if readCacheTTL > 0 {
+		client.HTTPClient = &http.Client{
+			Transport: listCacheTransport{Nested: client.HTTPClient.Transport, cache: newListCache(readCacheTTL)},
+		}
+	}
+
+	// Record the version of the objects read from APISIX to detect the concurrent changes
+	versions := newObjectVersions()
+	client.HTTPClient = &http.Client{
+		Transport: objectVersionTransport{Nested: client.HTTPClient.Transport, versions: versions},
+	}
+
+	updateStrategy := updateStrategyPut
+	if !config.UpdateStrategy.IsNull() {
+		updateStrategy = config.UpdateStrategy.ValueString()
+	}
+
+	providerClient := &apisixClient{
+		ApiClient:          client,
+		apiVersion:         apiVersion,
+		updateStrategy:     updateStrategy,
+		versions:           versions,
+		checkReferences:    config.CheckReferences.ValueBool(),
+		deriveIDs:          config.DeriveIDs.ValueBool(),
+		planned:            newPlannedObjects(),
+		deleteRetryTimeout: deleteRetryTimeout,
+		locks:              newObjectLocks(),
+	}
+	providerClient.pluginSchemas = newPluginSchemaStore(providerClient)
+
 	// Make the APISIX client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = providerClient
+	resp.ResourceData = providerClient
 
 	tflog.Info(ctx, "Configured APISIX client", map[string]any{"success": true})
 }
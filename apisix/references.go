@@ -0,0 +1,179 @@
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// objectReference describes an attribute holding the identifier of another APISIX object.
+type objectReference struct {
+	Path path.Path
+	// ObjectType is the name of the referenced object in the diagnostics
+	ObjectType string
+	// Collection is the Admin API path of the referenced objects, e.g. `upstreams`
+	Collection string
+	// SSLType limits the references to the SSL objects of this type
+	SSLType string
+	// Location is the place of the reference inside the attribute value, if any
+	Location string
+}
+
+// objectReferences lists the attributes referencing other objects, per resource type.
+var objectReferences = map[string][]objectReference{
+	"apisix_route": {
+		{Path: path.Root("upstream_id"), ObjectType: "Upstream", Collection: "upstreams"},
+		{Path: path.Root("service_id"), ObjectType: "Service", Collection: "services"},
+		{Path: path.Root("plugin_config_id"), ObjectType: "Plugin Config", Collection: "plugin_configs"},
+	},
+	"apisix_service": {
+		{Path: path.Root("upstream_id"), ObjectType: "Upstream", Collection: "upstreams"},
+	},
+	"apisix_stream_route": {
+		{Path: path.Root("upstream_id"), ObjectType: "Upstream", Collection: "upstreams"},
+	},
+	"apisix_consumer": {
+		{Path: path.Root("group_id"), ObjectType: "Consumer Group", Collection: "consumer_groups"},
+	},
+	"apisix_upstream": {
+		{Path: path.Root("tls").AtName("client_cert_id"), ObjectType: "SSL Certificate", Collection: "ssls", SSLType: "client"},
+	},
+}
+
+// plannedObjects remembers the objects planned for creation by this provider
+// instance, keyed by their Admin API path, e.g. `upstreams/1`. Terraform plans
+// the referenced objects first, so their identifiers are known here before
+// the references to them are checked.
+type plannedObjects struct {
+	mu      sync.Mutex
+	objects map[string]bool
+}
+
+func newPlannedObjects() *plannedObjects {
+	return &plannedObjects{objects: map[string]bool{}}
+}
+
+func (p *plannedObjects) add(objectPath string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.objects[objectPath] = true
+}
+
+func (p *plannedObjects) has(objectPath string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.objects[objectPath]
+}
+
+// registerPlannedObject records the identifier of a new object, so the
+// references to it aren't reported as missing before it is created.
+func (c *apisixClient) registerPlannedObject(ctx context.Context, collection string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, idPath path.Path) {
+	// The provider isn't configured yet or the object already exists
+	if c == nil || !c.checkReferences || !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, idPath, &id)...)
+	if id.IsNull() || id.IsUnknown() {
+		return
+	}
+
+	c.planned.add(collection + "/" + id.ValueString())
+}
+
+// checkObjectReferences reports the planned references to objects that don't
+// exist in APISIX nor are planned for creation. The references not known at
+// plan time are skipped.
+func (c *apisixClient) checkObjectReferences(ctx context.Context, resourceType string, plan tfsdk.Plan, diags *diag.Diagnostics) {
+	// The provider isn't configured yet, the check is disabled or the resource is planned for destruction
+	if c == nil || !c.checkReferences || plan.Raw.IsNull() {
+		return
+	}
+
+	for _, reference := range objectReferences[resourceType] {
+		var id types.String
+		diags.Append(plan.GetAttribute(ctx, reference.Path, &id)...)
+		if id.IsNull() || id.IsUnknown() || id.ValueString() == "" {
+			continue
+		}
+
+		c.checkObjectReference(ctx, reference, id.ValueString(), diags)
+	}
+}
+
+// checkObjectReference makes sure a single referenced object exists.
+func (c *apisixClient) checkObjectReference(ctx context.Context, reference objectReference, id string, diags *diag.Diagnostics) {
+	objectPath := reference.Collection + "/" + id
+	if c.planned.has(objectPath) {
+		return
+	}
+
+	referencedBy := ""
+	if reference.Location != "" {
+		referencedBy = fmt.Sprintf(", referenced by %s,", reference.Location)
+	}
+
+	body, err := c.adminDo(ctx, http.MethodGet, objectPath, nil)
+	if isNotFoundError(err) {
+		diags.AddAttributeError(
+			reference.Path,
+			"Missing Referenced "+reference.ObjectType,
+			fmt.Sprintf("The %s with the ID %q%s doesn't exist in APISIX and isn't planned for creation. "+
+				"Check the ID, or create the %s first.", reference.ObjectType, id, referencedBy, strings.ToLower(reference.ObjectType)),
+		)
+		return
+	}
+	if err != nil {
+		diags.AddAttributeWarning(
+			reference.Path,
+			"Could Not Check Referenced "+reference.ObjectType,
+			fmt.Sprintf("The %s with the ID %q%s could not be read from APISIX: %s", reference.ObjectType, id, referencedBy, err),
+		)
+		return
+	}
+
+	if reference.SSLType == "" {
+		return
+	}
+
+	var response struct {
+		Value struct {
+			Type string `json:"type"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		tflog.Debug(ctx, "Could not decode the referenced object", map[string]any{"path": objectPath, "error": err.Error()})
+		return
+	}
+
+	// APISIX defaults to server certificates
+	sslType := response.Value.Type
+	if sslType == "" {
+		sslType = "server"
+	}
+	if sslType != reference.SSLType {
+		diags.AddAttributeError(
+			reference.Path,
+			"Invalid Referenced "+reference.ObjectType,
+			fmt.Sprintf("The %s with the ID %q has the type %q, while a %q certificate is expected.", reference.ObjectType, id, sslType, reference.SSLType),
+		)
+	}
+}
+
+// isNotFoundError reports whether the Admin API responded with 404 Not Found.
+func isNotFoundError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), fmt.Sprintf("status: %d", http.StatusNotFound))
+}
@@ -0,0 +1,57 @@
+package apisix
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestCheckObjectReference(t *testing.T) {
+	server := newFakeAdminServer(t)
+	server.setObject("upstreams/1", `{"id":"1"}`)
+	server.setObject("ssls/client", `{"id":"client","type":"client"}`)
+	server.setObject("ssls/server", `{"id":"server"}`)
+	server.failedReads = map[string]int{"upstreams/broken": http.StatusInternalServerError}
+
+	client := server.client()
+	client.checkReferences = true
+	client.planned.add("upstreams/planned")
+
+	upstream := objectReference{Path: path.Root("upstream_id"), ObjectType: "Upstream", Collection: "upstreams"}
+	clientCert := objectReference{Path: path.Root("tls").AtName("client_cert_id"), ObjectType: "SSL Certificate", Collection: "ssls", SSLType: "client"}
+
+	testCases := map[string]struct {
+		reference objectReference
+		id        string
+		errors    int
+		warnings  int
+	}{
+		"existing":            {reference: upstream, id: "1"},
+		"planned":             {reference: upstream, id: "planned"},
+		"missing":             {reference: upstream, id: "2", errors: 1},
+		"unavailable":         {reference: upstream, id: "broken", warnings: 1},
+		"client certificate":  {reference: clientCert, id: "client"},
+		"server certificate":  {reference: clientCert, id: "server", errors: 1},
+		"missing certificate": {reference: clientCert, id: "missing", errors: 1},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			client.checkObjectReference(context.Background(), testCase.reference, testCase.id, &diags)
+
+			if diags.ErrorsCount() != testCase.errors || diags.WarningsCount() != testCase.warnings {
+				t.Fatalf("expected %d errors and %d warnings, got %v", testCase.errors, testCase.warnings, diags)
+			}
+			for _, d := range diags {
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(testCase.reference.Path) {
+					t.Errorf("expected the diagnostic on %s, got %v", testCase.reference.Path, d)
+				}
+			}
+		})
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
@@ -13,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/holubovskyi/apisix-client-go"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -20,7 +19,12 @@ var (
 	_ resource.Resource                     = &routeResource{}
 	_ resource.ResourceWithConfigure        = &routeResource{}
 	_ resource.ResourceWithImportState      = &routeResource{}
+	_ resource.ResourceWithIdentity         = &routeResource{}
+	_ resource.ResourceWithUpgradeState     = &routeResource{}
+	_ resource.ResourceWithMoveState        = &routeResource{}
 	_ resource.ResourceWithConfigValidators = &routeResource{}
+	_ resource.ResourceWithValidateConfig   = &routeResource{}
+	_ resource.ResourceWithModifyPlan       = &routeResource{}
 )
 
 // NewRouteResource is a helper function to simplify the provider implementation.
@@ -30,7 +34,7 @@ func NewRouteResource() resource.Resource {
 
 // routeResource is the resource implementation.
 type routeResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -43,6 +47,21 @@ func (r *routeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	resp.Schema = model.RouteSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *routeResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.ObjectIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *routeResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.RouteStateUpgrades)
+}
+
+// MoveState moves the state of the routes of the other APISIX providers.
+func (r *routeResource) MoveState(_ context.Context) []resource.StateMover {
+	return moveStateFrom(model.RouteSchema, []string{"route"}, moveJSONStrings("plugins", "vars"), moveStatus, moveWithoutInlineUpstream)
+}
+
 // Validate Config
 func (r *routeResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
@@ -65,18 +84,45 @@ func (r *routeResource) ConfigValidators(ctx context.Context) []resource.ConfigV
 	}
 }
 
+// Implement config validation
+func (r *routeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Reject the unknown plugins and the plugin configurations not matching their JSON schema
+	r.client.checkPluginSchemas(ctx, req.Config, "", &resp.Diagnostics)
+}
+
+// Implement plan modification
+func (r *routeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_route", req.Plan, &resp.Diagnostics)
+
+	// Expect a new update time for the routes about to be updated
+	planUpdateTime(ctx, req, resp)
+
+	// Derive the identifier of the new routes configured without it, if enabled
+	r.client.planObjectID(ctx, "apisix_route", req, resp, path.Root("name"))
+
+	// Make sure the referenced objects exist before anything is applied
+	r.client.checkObjectReferences(ctx, "apisix_route", resp.Plan, &resp.Diagnostics)
+
+	// Make sure the objects referenced in the plugins exist before anything is applied
+	r.client.checkPluginReferences(ctx, resp.Plan, &resp.Diagnostics)
+
+	// Let the references to the new routes pass before they are created
+	r.client.registerPlannedObject(ctx, "routes", req, resp, path.Root("id"))
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *routeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -95,13 +141,58 @@ func (r *routeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Route", plan.ID, createTimeout)
+
 	// Generate API request body from plan
 	newRouteRequest := model.RouteFromTerraformToApi(ctx, &plan)
 
-	// Create a new route
-	newRouteResponse, err := r.client.CreateRoute(newRouteRequest)
+	// Use the planned identifier or generate a new one
+	routeID, err := newObjectID(plan.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
+			"Error creating Route",
+			"Could not generate Route ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Keep the other resources writing the route waiting until it's created
+	unlock := client.lockObject(ctx, "Route", "routes/"+routeID, &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing route when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetRoute(routeID)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Route",
+			"Could not read APISIX Route by ID "+routeID+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Route", routeID, existing, newRouteRequest)
+		} else if !sameObject(existing, newRouteRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "Route", routeID)
+			return
+		}
+	}
+
+	// Create new route
+	newRouteResponse, err := client.UpdateRoute(routeID, newRouteRequest)
+	if err != nil {
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Route",
 			"Could not create Route, unexpected error: "+err.Error(),
 		)
@@ -110,10 +201,22 @@ func (r *routeResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Map response body to schema and populate Computed attribute values
 	newState := model.RouteFromApiToTerraform(ctx, newRouteResponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("routes/" + routeID)
+	newState.UpdateStrategy = plan.UpdateStrategy
+	newState.PluginsTyped = plan.PluginsTyped
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins, plan.PluginsTyped)
 	}
 
+	// Remember the version of the route to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "routes/"+routeID, resp.Private, &resp.Diagnostics)
+
+	// Identify the route by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -134,7 +237,7 @@ func (r *routeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get refreshed route from the APISIX
-	routeStateResponse, err := r.client.GetRoute(state.ID.ValueString())
+	routeStateResponse, err := r.client.readClient(ctx, req.Private, &resp.Diagnostics).GetRoute(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Route",
@@ -145,10 +248,22 @@ func (r *routeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	// Overwrite with refreshed state
 	newState := model.RouteFromApiToTerraform(ctx, routeStateResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.CreateTime, newState.UpdateTime = r.client.objectTimestamps("routes/" + state.ID.ValueString())
+	newState.UpdateStrategy = state.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+		// Compare with the plugins of APISIX without the defaults it sets
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins, newState.PluginsTyped = r.client.readPlugins(ctx, "", newState.Plugins, state.Plugins, state.Plugin, state.SensitivePlugins, state.PluginsTyped)
 	}
 
+	// Remember the version of the route to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "routes/"+state.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the route by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -168,34 +283,74 @@ func (r *routeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Route", plan.ID, updateTimeout)
+
+	// Keep the other resources writing the route waiting until it's updated
+	unlock := client.lockObject(ctx, "Route", "routes/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the route wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Route", "routes/"+plan.ID.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	updateRouteRequest := model.RouteFromTerraformToApi(ctx, &plan)
 
-	// Update existing route
-	_, err := r.client.UpdateRoute(plan.ID.ValueString(), updateRouteRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating APISIX Route",
-			"Could not update Route, unexpected error: "+err.Error(),
-		)
+	// Retrieve values from state, the patch update strategy sends only the changes
+	var state model.RouteResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Fetch updated route
-	updatedRoute, err := r.client.GetRoute(plan.ID.ValueString())
+	// Generate API request body from state
+	priorRouteRequest := model.RouteFromTerraformToApi(ctx, &state)
+
+	// Update existing route, the response holds the updated route
+	updatedRoute, err := updateObject(ctx, client, plan.UpdateStrategy, "routes/"+plan.ID.ValueString(), priorRouteRequest, updateRouteRequest, func() (*api_client.Route, error) {
+		return client.UpdateRoute(plan.ID.ValueString(), updateRouteRequest)
+	}, func() (*api_client.Route, error) {
+		return client.GetRoute(plan.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Route",
-			"Could not read APISIX Route by ID "+plan.ID.ValueString()+": "+err.Error(),
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
+			"Error Updating APISIX Route",
+			"Could not update Route, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
 	newState := model.RouteFromApiToTerraform(ctx, updatedRoute)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("routes/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
+	newState.PluginsTyped = plan.PluginsTyped
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins, plan.PluginsTyped)
 	}
 
+	// Remember the version of the route to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "routes/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the route by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -215,8 +370,31 @@ func (r *routeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Route", state.ID, deleteTimeout)
+
+	// Keep the other resources writing the route waiting until it's deleted
+	unlock := client.lockObject(ctx, "Route", "routes/"+state.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the route wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Route", "routes/"+state.ID.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the route
-	err := r.client.DeleteRoute(state.ID.ValueString())
+	err := client.DeleteRoute(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Route",
@@ -229,6 +407,15 @@ func (r *routeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 // Import resource into state
 func (r *routeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the route importing")
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Resolve the import ID, identity or name or labels selector into the ID, and save it to the id attribute
+	id, err := r.client.resolveImportID(ctx, "Route", "routes", importIdentifier(ctx, req, path.Root("id"), &resp.Diagnostics), false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing APISIX Route",
+			"Could not import Route: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: types.StringValue(id)}, &resp.Diagnostics)
 }
@@ -1,9 +1,15 @@
 package apisix
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestRouteResource(t *testing.T) {
@@ -51,6 +57,8 @@ resource "apisix_route" "test" {
 					resource.TestCheckResourceAttr("apisix_route.test", "priority", "0"),
 					resource.TestCheckResourceAttr("apisix_route.test", "enable_websocket", "false"),
 					resource.TestCheckResourceAttr("apisix_route.test", "status", "1"),
+					resource.TestCheckResourceAttrSet("apisix_route.test", "create_time"),
+					resource.TestCheckResourceAttrSet("apisix_route.test", "update_time"),
 				),
 			},
 			// ImportState testing
@@ -61,6 +69,14 @@ resource "apisix_route" "test" {
 				// Ignore plugins value during import
 				ImportStateVerifyIgnore: []string{"plugins"},
 			},
+			// ImportState by name testing
+			{
+				ResourceName:            "apisix_route.test",
+				ImportState:             true,
+				ImportStateId:           "name=Example",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"plugins"},
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + `
@@ -105,3 +121,321 @@ resource "apisix_route" "test" {
 		},
 	})
 }
+
+func TestRouteResourceWithID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	id   = "tf-acc-route"
+	name = "Example"
+	uri  = "/api/v1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "id", "tf-acc-route"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "apisix_route.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestRouteResourceIdentity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	id   = "tf-acc-route-identity"
+	name = "Example"
+	uri  = "/api/v1"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("apisix_route.test", map[string]knownvalue.Check{
+						"id": knownvalue.StringExact("tf-acc-route-identity"),
+					}),
+				},
+			},
+			// ImportState with the resource identity testing
+			{
+				ResourceName:    "apisix_route.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestRouteResourceUnsetOptionalAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with all the optional attributes set
+			{
+				Config: providerConfig + testAccRouteUnsetConfig("put", `
+	desc = "Example of the route configuration"
+	timeout = {
+		connect = 3
+		send    = 3
+		read    = 3
+	}
+	labels = {
+		version = "v1"
+		team    = "payments"
+	}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "labels.%", "2"),
+					resource.TestCheckResourceAttr("apisix_route.test", "timeout.read", "3"),
+				),
+			},
+			// Remove a single label and the optional attributes with a PUT
+			{
+				Config: providerConfig + testAccRouteUnsetConfig("put", `
+	labels = {
+		version = "v1"
+	}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "labels.%", "1"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "labels.team"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "desc"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "timeout"),
+				),
+			},
+			// Verify the attributes are removed in APISIX too
+			{
+				ResourceName:            "apisix_route.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"update_strategy"},
+			},
+			// Set the optional attributes again
+			{
+				Config: providerConfig + testAccRouteUnsetConfig("patch", `
+	desc = "Example of the route configuration"
+	timeout = {
+		connect = 3
+		send    = 3
+		read    = 3
+	}
+	labels = {
+		version = "v1"
+		team    = "payments"
+	}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "labels.%", "2"),
+					resource.TestCheckResourceAttr("apisix_route.test", "desc", "Example of the route configuration"),
+				),
+			},
+			// Remove a single label and the optional attributes with a PATCH
+			{
+				Config: providerConfig + testAccRouteUnsetConfig("patch", `
+	labels = {
+		version = "v1"
+	}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "labels.%", "1"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "labels.team"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "desc"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "timeout"),
+				),
+			},
+			// Remove all the labels
+			{
+				Config: providerConfig + testAccRouteUnsetConfig("patch", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("apisix_route.test", "labels.%"),
+				),
+			},
+			// Verify the attributes are removed in APISIX too
+			{
+				ResourceName:            "apisix_route.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"update_strategy"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccRouteUnsetConfig returns a route configuration with the given
+// update strategy and optional attributes.
+func testAccRouteUnsetConfig(updateStrategy string, optionalAttributes string) string {
+	return fmt.Sprintf(`
+resource "apisix_route" "test" {
+	name            = "Unset"
+	uri             = "/unset"
+	update_strategy = %q
+%s}
+`, updateStrategy, optionalAttributes)
+}
+
+func TestRouteResourcePlugin(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with the plugins as an object
+			{
+				Config: providerConfig + testAccRoutePluginConfig(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "plugin.limit-count.count", "2"),
+					resource.TestCheckResourceAttr("apisix_route.test", "plugin.limit-count.time_window", "60"),
+					resource.TestCheckResourceAttr("apisix_route.test", "plugin.ip-restriction.blacklist.0", "10.10.10.0/24"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "plugins"),
+				),
+			},
+			// Update a single plugin field
+			{
+				Config: providerConfig + testAccRoutePluginConfig(3),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("apisix_route.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "plugin.limit-count.count", "3"),
+				),
+			},
+			// The plugins can't be set both as an object and as a JSON string
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri     = "/plugin"
+	plugin  = { cors = {} }
+	plugins = jsonencode({ cors = {} })
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoutePluginConfig(count int) string {
+	return fmt.Sprintf(`
+resource "apisix_route" "test" {
+	uri = "/plugin"
+	plugin = {
+		limit-count = {
+			count         = %d
+			time_window   = 60
+			rejected_code = 503
+			key           = "remote_addr"
+		}
+		ip-restriction = {
+			blacklist = ["10.10.10.0/24"]
+		}
+	}
+}
+`, count)
+}
+
+func TestRouteResourcePluginsTyped(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with typed plugins, the defaults of the schema are planned
+			{
+				Config: providerConfig + testAccRoutePluginsTypedConfig(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "plugins_typed.limit_count.count", "2"),
+					resource.TestCheckResourceAttr("apisix_route.test", "plugins_typed.limit_count.rejected_code", "503"),
+					resource.TestCheckResourceAttr("apisix_route.test", "plugins_typed.limit_count.policy", "local"),
+					resource.TestCheckResourceAttr("apisix_route.test", "plugins_typed.proxy_rewrite.headers.set.X-Api-Version", "v1"),
+					resource.TestCheckResourceAttr("apisix_route.test", "plugin.cors.allow_origins", "*"),
+					resource.TestCheckNoResourceAttr("apisix_route.test", "plugins"),
+				),
+			},
+			// Update a single typed plugin field
+			{
+				Config: providerConfig + testAccRoutePluginsTypedConfig(3),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("apisix_route.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_route.test", "plugins_typed.limit_count.count", "3"),
+				),
+			},
+			// The typed plugins are validated when planning
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri = "/plugins_typed"
+	plugins_typed = {
+		limit_count = {
+			count       = 0
+			time_window = 60
+		}
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+			// A plugin can't be both typed and in the plugins
+			{
+				Config: providerConfig + `
+resource "apisix_route" "test" {
+	uri     = "/plugins_typed"
+	plugins = jsonencode({ cors = {} })
+	plugins_typed = {
+		cors = {}
+	}
+}
+`,
+				ExpectError: regexp.MustCompile(`Duplicate Plugin Configuration`),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoutePluginsTypedConfig(count int) string {
+	return fmt.Sprintf(`
+resource "apisix_route" "test" {
+	uri = "/plugins_typed"
+	plugin = {
+		cors = {
+			allow_origins = "*"
+		}
+	}
+	plugins_typed = {
+		limit_count = {
+			count         = %d
+			time_window   = 60
+			rejected_code = 503
+		}
+		proxy_rewrite = {
+			headers = {
+				set = {
+					X-Api-Version = "v1"
+				}
+			}
+		}
+	}
+}
+`, count)
+}
@@ -0,0 +1,54 @@
+package apisix
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-apisix/apisix/model"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestResourceModelsMatchSchemas makes sure every model field has a schema
+// attribute and the other way around, which is otherwise only detected at runtime.
+func TestResourceModelsMatchSchemas(t *testing.T) {
+	testCases := map[string]struct {
+		schema schema.Schema
+		model  any
+	}{
+		"apisix_consumer":        {schema: model.ConsumerSchema, model: &model.ConsumerResourceModel{}},
+		"apisix_consumer_group":  {schema: model.ConsumerGroupSchema, model: &model.ConsumerGroupResourceModel{}},
+		"apisix_global_rule":     {schema: model.GlobalRuleSchema, model: &model.GlobalRuleResourceModel{}},
+		"apisix_plugin_config":   {schema: model.PluginConfigSchema, model: &model.PluginConfigResourceModel{}},
+		"apisix_plugin_metadata": {schema: model.PluginMetadataSchema, model: &model.PluginMetadataResourceModel{}},
+		"apisix_route":           {schema: model.RouteSchema, model: &model.RouteResourceModel{}},
+		"apisix_secret":          {schema: model.SecretSchema, model: &model.SecretResourceModel{}},
+		"apisix_service":         {schema: model.ServiceSchema, model: &model.ServiceResourceModel{}},
+		"apisix_ssl_certificate": {schema: model.SSLCertificateSchema, model: &model.SSLCertificateResourceModel{}},
+		"apisix_stream_route":    {schema: model.StreamRouteSchema, model: &model.StreamRouteModel{}},
+		"apisix_upstream":        {schema: model.UpstreamSchema, model: &model.UpstreamResourceModel{}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Read an object with all the attributes null into the model
+			objectType := testCase.schema.Type().TerraformType(ctx).(tftypes.Object)
+			attributes := map[string]tftypes.Value{}
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+			state := tfsdk.State{
+				Schema: testCase.schema,
+				Raw:    tftypes.NewValue(objectType, attributes),
+			}
+
+			if diags := state.Get(ctx, testCase.model); diags.HasError() {
+				t.Fatalf("the model doesn't match the schema: %v", diags)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -20,7 +21,10 @@ var (
 	_ resource.Resource                     = &secretResource{}
 	_ resource.ResourceWithConfigure        = &secretResource{}
 	_ resource.ResourceWithImportState      = &secretResource{}
+	_ resource.ResourceWithIdentity         = &secretResource{}
+	_ resource.ResourceWithUpgradeState     = &secretResource{}
 	_ resource.ResourceWithConfigValidators = &secretResource{}
+	_ resource.ResourceWithModifyPlan       = &secretResource{}
 )
 
 // NewSecretResource is a helper function to simplify the provider implementation.
@@ -30,7 +34,7 @@ func NewSecretResource() resource.Resource {
 
 // secretResource is the resource implementation.
 type secretResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -43,6 +47,16 @@ func (r *secretResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	resp.Schema = model.SecretSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *secretResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.SecretIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *secretResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.SecretStateUpgrades)
+}
+
 // Validate Config
 func (r *secretResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
@@ -54,18 +68,38 @@ func (r *secretResource) ConfigValidators(ctx context.Context) []resource.Config
 	}
 }
 
+// Implement plan modification
+func (r *secretResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_secret", req.Plan, &resp.Diagnostics)
+
+	// The secret is planned for destruction
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Let the references to the new secrets pass before they are created
+	for _, secretManager := range []api_client.SecretManager{api_client.Vault, api_client.AWS, api_client.GCP} {
+		var config types.Object
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(string(secretManager)), &config)...)
+		if !config.IsNull() {
+			r.client.registerPlannedObject(ctx, "secrets/"+string(secretManager), req, resp, path.Root("id"))
+		}
+	}
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *secretResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -84,13 +118,57 @@ func (r *secretResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	// Send the write-only secrets, only available in the configuration
+	var config model.SecretResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SetWriteOnly(config)
+
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Secret", plan.ID, createTimeout)
+
 	// Generate API request body from plan
 	secretManager, newSecretRequest := model.SecretFromTerraformToApi(ctx, &plan)
 
+	// Keep the other resources writing the secret waiting until it's created
+	unlock := client.lockObject(ctx, "Secret", fmt.Sprintf("secrets/%s/%s", secretManager, plan.ID.ValueString()), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing secret when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetSecret(secretManager, plan.ID.ValueString())
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Secret",
+			"Could not read APISIX Secret by ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Secret", plan.ID.ValueString(), existing, newSecretRequest)
+		} else if !sameObject(existing, newSecretRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "Secret", plan.ID.ValueString())
+			return
+		}
+	}
+
 	// Create new secret
-	newSecretReponse, err := r.client.CreateSecret(secretManager, plan.ID.ValueString(), newSecretRequest)
+	newSecretReponse, err := client.CreateSecret(secretManager, plan.ID.ValueString(), newSecretRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Secret",
 			"Could not create Secret, unexpected error: "+err.Error(),
 		)
@@ -99,6 +177,17 @@ func (r *secretResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Map response body to schema and populate Computed attribute values
 	newState := model.SecretFromApiToTerraform(ctx, newSecretReponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.KeepWriteOnly(plan)
+
+	// Remember the version of the secret to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, fmt.Sprintf("secrets/%s/%s", secretManager, plan.ID.ValueString()), resp.Private, &resp.Diagnostics)
+
+	// Identify the secret by its secret manager and ID
+	setIdentity(ctx, resp.Identity, model.SecretIdentityModel{Manager: types.StringValue(string(secretManager)), ID: newState.ID}, &resp.Diagnostics)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -137,6 +226,17 @@ func (r *secretResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Overwrite with refreshed state
 	newState := model.SecretFromApiToTerraform(ctx, secretStateResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.ForceDelete = state.ForceDelete
+	newState.KeepWriteOnly(state)
+
+	// Remember the version of the secret to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, fmt.Sprintf("secrets/%s/%s", secretManager, state.ID.ValueString()), resp.Private, &resp.Diagnostics)
+
+	// Identify the secret by its secret manager and ID
+	setIdentity(ctx, resp.Identity, model.SecretIdentityModel{Manager: types.StringValue(string(secretManager)), ID: newState.ID}, &resp.Diagnostics)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
@@ -157,30 +257,63 @@ func (r *secretResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	// Send the write-only secrets, only available in the configuration
+	var config model.SecretResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SetWriteOnly(config)
+
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Secret", plan.ID, updateTimeout)
+
 	// Generate API request body from plan
 	secretManager, updateSecretRequest := model.SecretFromTerraformToApi(ctx, &plan)
 
-	// Update existing rule
-	_, err := r.client.UpdateSecret(secretManager, plan.ID.ValueString(), updateSecretRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating APISIX Secret",
-			"Could not update Secret, unexpected error: "+err.Error(),
-		)
+	// Keep the other resources writing the secret waiting until it's updated
+	unlock := client.lockObject(ctx, "Secret", fmt.Sprintf("secrets/%s/%s", secretManager, plan.ID.ValueString()), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the secret wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Secret", fmt.Sprintf("secrets/%s/%s", secretManager, plan.ID.ValueString()), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Fetch updated rule
-	updatedSecret, err := r.client.GetSecret(secretManager, plan.ID.ValueString())
+	// Update existing secret, the response holds the updated secret
+	updatedSecret, err := client.UpdateSecret(secretManager, plan.ID.ValueString(), updateSecretRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Secret",
-			"Could not read APISIX Secret by ID "+plan.ID.ValueString()+": "+err.Error(),
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
+			"Error Updating APISIX Secret",
+			"Could not update Secret, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
 	newState := model.SecretFromApiToTerraform(ctx, updatedSecret)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.KeepWriteOnly(plan)
+
+	// Remember the version of the secret to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, fmt.Sprintf("secrets/%s/%s", secretManager, plan.ID.ValueString()), resp.Private, &resp.Diagnostics)
+
+	// Identify the secret by its secret manager and ID
+	setIdentity(ctx, resp.Identity, model.SecretIdentityModel{Manager: types.StringValue(string(secretManager)), ID: newState.ID}, &resp.Diagnostics)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -201,16 +334,43 @@ func (r *secretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Secret", state.ID, deleteTimeout)
+
 	secretManager, secretManagerError := GetSecretManagerFromState(state)
 	if secretManagerError != nil {
 		resp.Diagnostics.AddError("Provider Selection Error", secretManagerError.Error())
 		return
 	}
 
+	objectPath := fmt.Sprintf("secrets/%s/%s", secretManager, state.ID.ValueString())
+
+	// Keep the other resources writing the secret waiting until it's deleted
+	unlock := client.lockObject(ctx, "Secret", objectPath, &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the secret wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Secret", objectPath, state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the secret
-	err := r.client.DeleteSecret(secretManager, state.ID.ValueString())
+	err := client.deleteObject(ctx, objectPath, state.ForceDelete, func() error {
+		return client.DeleteSecret(secretManager, state.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		client.addDeleteError(ctx, &resp.Diagnostics, objectPath, err,
 			"Error Deleting APISIX Secret",
 			"Could not delete Secret, unexpected error: "+err.Error(),
 		)
@@ -221,13 +381,19 @@ func (r *secretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 // Import resource into state
 func (r *secretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the rule importing")
-	// Retrieve import ID and save to id attribute
+	// Retrieve import ID or identity and save to id attribute
+	importID := req.ID
+	if importID == "" && req.Identity != nil {
+		var identity model.SecretIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		importID = identity.Manager.ValueString() + "/" + identity.ID.ValueString()
+	}
 
-	parts := strings.Split(req.ID, "/")
+	parts := strings.Split(importID, "/")
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			fmt.Sprintf("Import ID must be in the format <manager>/<id>, got: %s", req.ID),
+			fmt.Sprintf("Import ID must be in the format <manager>/<id>, got: %s", importID),
 		)
 		return
 	}
@@ -245,6 +411,7 @@ func (r *secretResource) ImportState(ctx context.Context, req resource.ImportSta
 	case api_client.GCP:
 		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("gcp"), &model.SecretGCPType{})...)
 	}
+	setIdentity(ctx, resp.Identity, model.SecretIdentityModel{Manager: types.StringValue(string(manager)), ID: types.StringValue(terraformID)}, &resp.Diagnostics)
 }
 
 func GetSecretManagerFromState(state model.SecretResourceModel) (secretManager api_client.SecretManager, err error) {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestSecretResource(t *testing.T) {
@@ -57,3 +58,52 @@ resource "apisix_secret" "test" {
 		},
 	})
 }
+
+func TestSecretResourceWriteOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "apisix_secret" "test" {
+	id = "tf-acc-secret-wo"
+	vault = {
+		uri              = "http://127.0.0.1:8200"
+		prefix           = "kv/apisix"
+		token_wo         = "root"
+		token_wo_version = 1
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_secret.test", "vault.token_wo_version", "1"),
+					resource.TestCheckNoResourceAttr("apisix_secret.test", "vault.token"),
+					resource.TestCheckNoResourceAttr("apisix_secret.test", "vault.token_wo"),
+				),
+			},
+			// Rotate the token by bumping its version
+			{
+				Config: providerConfig + `
+resource "apisix_secret" "test" {
+	id = "tf-acc-secret-wo"
+	vault = {
+		uri              = "http://127.0.0.1:8200"
+		prefix           = "kv/apisix"
+		token_wo         = "rotated"
+		token_wo_version = 2
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_secret.test", "vault.token_wo_version", "2"),
+					resource.TestCheckNoResourceAttr("apisix_secret.test", "vault.token"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -4,21 +4,25 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/holubovskyi/apisix-client-go"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &serviceResource{}
-	_ resource.ResourceWithConfigure   = &serviceResource{}
-	_ resource.ResourceWithImportState = &serviceResource{}
+	_ resource.Resource                   = &serviceResource{}
+	_ resource.ResourceWithConfigure      = &serviceResource{}
+	_ resource.ResourceWithImportState    = &serviceResource{}
+	_ resource.ResourceWithIdentity       = &serviceResource{}
+	_ resource.ResourceWithUpgradeState   = &serviceResource{}
+	_ resource.ResourceWithMoveState      = &serviceResource{}
+	_ resource.ResourceWithValidateConfig = &serviceResource{}
+	_ resource.ResourceWithModifyPlan     = &serviceResource{}
 )
 
 // NewServiceResource is a helper function to simplify the provider implementation.
@@ -28,7 +32,7 @@ func NewServiceResource() resource.Resource {
 
 // serviceResource is the resource implementation.
 type serviceResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -41,18 +45,60 @@ func (r *serviceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 	resp.Schema = model.ServiceSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *serviceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.ObjectIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *serviceResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.ServiceStateUpgrades)
+}
+
+// MoveState moves the state of the services of the other APISIX providers.
+func (r *serviceResource) MoveState(_ context.Context) []resource.StateMover {
+	return moveStateFrom(model.ServiceSchema, []string{"service"}, moveJSONStrings("plugins"), moveWithoutInlineUpstream)
+}
+
+// Implement config validation
+func (r *serviceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Reject the unknown plugins and the plugin configurations not matching their JSON schema
+	r.client.checkPluginSchemas(ctx, req.Config, "", &resp.Diagnostics)
+}
+
+// Implement plan modification
+func (r *serviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_service", req.Plan, &resp.Diagnostics)
+
+	// Expect a new update time for the services about to be updated
+	planUpdateTime(ctx, req, resp)
+
+	// Derive the identifier of the new services configured without it, if enabled
+	r.client.planObjectID(ctx, "apisix_service", req, resp, path.Root("name"))
+
+	// Make sure the referenced objects exist before anything is applied
+	r.client.checkObjectReferences(ctx, "apisix_service", resp.Plan, &resp.Diagnostics)
+
+	// Make sure the objects referenced in the plugins exist before anything is applied
+	r.client.checkPluginReferences(ctx, resp.Plan, &resp.Diagnostics)
+
+	// Let the references to the new services pass before they are created
+	r.client.registerPlannedObject(ctx, "services", req, resp, path.Root("id"))
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *serviceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -71,13 +117,58 @@ func (r *serviceResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Service", plan.ID, createTimeout)
+
 	// Generate API request body from plan
 	newServiceRequest := model.ServiceFromTerraformToApi(ctx, &plan)
 
-	// Create new service
-	newServiceReponse, err := r.client.CreateService(newServiceRequest)
+	// Use the planned identifier or generate a new one
+	serviceID, err := newObjectID(plan.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
+			"Error creating Service",
+			"Could not generate Service ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Keep the other resources writing the service waiting until it's created
+	unlock := client.lockObject(ctx, "Service", "services/"+serviceID, &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing service when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetService(serviceID)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Service",
+			"Could not read APISIX Service by ID "+serviceID+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Service", serviceID, existing, newServiceRequest)
+		} else if !sameObject(existing, newServiceRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "Service", serviceID)
+			return
+		}
+	}
+
+	// Create new service
+	newServiceReponse, err := client.UpdateService(serviceID, newServiceRequest)
+	if err != nil {
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Service",
 			"Could not create Service, unexpected error: "+err.Error(),
 		)
@@ -86,10 +177,23 @@ func (r *serviceResource) Create(ctx context.Context, req resource.CreateRequest
 
 	// Map response body to schema and populate Computed attribute values
 	newState := model.ServiceFromApiToTerraform(ctx, newServiceReponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("services/" + serviceID)
+	newState.UpdateStrategy = plan.UpdateStrategy
+	newState.PluginsTyped = plan.PluginsTyped
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins, plan.PluginsTyped)
 	}
 
+	// Remember the version of the service to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "services/"+serviceID, resp.Private, &resp.Diagnostics)
+
+	// Identify the service by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -110,7 +214,7 @@ func (r *serviceResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Get refreshed service from the APISIX
-	serviceStateResponse, err := r.client.GetService(state.ID.ValueString())
+	serviceStateResponse, err := r.client.readClient(ctx, req.Private, &resp.Diagnostics).GetService(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Service",
@@ -121,10 +225,23 @@ func (r *serviceResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	// Overwrite with refreshed state
 	newState := model.ServiceFromApiToTerraform(ctx, serviceStateResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.ForceDelete = state.ForceDelete
+	newState.CreateTime, newState.UpdateTime = r.client.objectTimestamps("services/" + state.ID.ValueString())
+	newState.UpdateStrategy = state.UpdateStrategy
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(state.Plugins.ValueString())
+		// Compare with the plugins of APISIX without the defaults it sets
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins, newState.PluginsTyped = r.client.readPlugins(ctx, "", newState.Plugins, state.Plugins, state.Plugin, state.SensitivePlugins, state.PluginsTyped)
 	}
 
+	// Remember the version of the service to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "services/"+state.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the service by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -144,34 +261,75 @@ func (r *serviceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Service", plan.ID, updateTimeout)
+
+	// Keep the other resources writing the service waiting until it's updated
+	unlock := client.lockObject(ctx, "Service", "services/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the service wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Service", "services/"+plan.ID.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	updateServiceRequest := model.ServiceFromTerraformToApi(ctx, &plan)
 
-	// Update existing service
-	_, err := r.client.UpdateService(plan.ID.ValueString(), updateServiceRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating APISIX Service",
-			"Could not update Service, unexpected error: "+err.Error(),
-		)
+	// Retrieve values from state, the patch update strategy sends only the changes
+	var state model.ServiceResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Fetch updated service
-	updatedService, err := r.client.GetService(plan.ID.ValueString())
+	// Generate API request body from state
+	priorServiceRequest := model.ServiceFromTerraformToApi(ctx, &state)
+
+	// Update existing service, the response holds the updated service
+	updatedService, err := updateObject(ctx, client, plan.UpdateStrategy, "services/"+plan.ID.ValueString(), priorServiceRequest, updateServiceRequest, func() (*api_client.Service, error) {
+		return client.UpdateService(plan.ID.ValueString(), updateServiceRequest)
+	}, func() (*api_client.Service, error) {
+		return client.GetService(plan.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Service",
-			"Could not read APISIX Service by ID "+plan.ID.ValueString()+": "+err.Error(),
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
+			"Error Updating APISIX Service",
+			"Could not update Service, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
 	newState := model.ServiceFromApiToTerraform(ctx, updatedService)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("services/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
+	newState.PluginsTyped = plan.PluginsTyped
 	if !newState.Plugins.IsNull() {
-		newState.Plugins = types.StringValue(plan.Plugins.ValueString())
+		newState.Plugins, newState.Plugin, newState.SensitivePlugins = model.PriorPlugins(plan.Plugins, plan.Plugin, plan.SensitivePlugins, plan.PluginsTyped)
 	}
 
+	// Remember the version of the service to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "services/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the service by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -191,10 +349,35 @@ func (r *serviceResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Service", state.ID, deleteTimeout)
+
+	// Keep the other resources writing the service waiting until it's deleted
+	unlock := client.lockObject(ctx, "Service", "services/"+state.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the service wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Service", "services/"+state.ID.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the service
-	err := r.client.DeleteService(state.ID.ValueString())
+	err := client.deleteObject(ctx, "services/"+state.ID.ValueString(), state.ForceDelete, func() error {
+		return client.DeleteService(state.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		client.addDeleteError(ctx, &resp.Diagnostics, "services/"+state.ID.ValueString(), err,
 			"Error Deleting APISIX Service",
 			"Could not delete service, unexpected error: "+err.Error(),
 		)
@@ -205,6 +388,15 @@ func (r *serviceResource) Delete(ctx context.Context, req resource.DeleteRequest
 // Import resource into state
 func (r *serviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the service importing")
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Resolve the import ID, identity or name or labels selector into the ID, and save it to the id attribute
+	id, err := r.client.resolveImportID(ctx, "Service", "services", importIdentifier(ctx, req, path.Root("id"), &resp.Diagnostics), false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing APISIX Service",
+			"Could not import Service: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: types.StringValue(id)}, &resp.Diagnostics)
 }
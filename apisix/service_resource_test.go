@@ -59,3 +59,50 @@ resource "apisix_service" "test" {
 		},
 	})
 }
+
+func TestServiceResourceUnsetOptionalAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with all the optional attributes set
+			{
+				Config: providerConfig + `
+resource "apisix_service" "test" {
+	name = "unset"
+	desc = "Example of the service resource usage"
+	labels = {
+		version = "v1"
+		team    = "payments"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_service.test", "labels.%", "2"),
+				),
+			},
+			// Remove a single label and the description
+			{
+				Config: providerConfig + `
+resource "apisix_service" "test" {
+	name = "unset"
+	labels = {
+		version = "v1"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_service.test", "labels.%", "1"),
+					resource.TestCheckNoResourceAttr("apisix_service.test", "labels.team"),
+					resource.TestCheckNoResourceAttr("apisix_service.test", "desc"),
+				),
+			},
+			// Verify the attributes are removed in APISIX too
+			{
+				ResourceName:      "apisix_service.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
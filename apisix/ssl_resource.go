@@ -4,22 +4,24 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/holubovskyi/apisix-client-go"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &sslCertificateResource{}
-	_ resource.ResourceWithConfigure   = &sslCertificateResource{}
-	_ resource.ResourceWithImportState = &sslCertificateResource{}
-	_ resource.ResourceWithModifyPlan  = &sslCertificateResource{}
+	_ resource.Resource                 = &sslCertificateResource{}
+	_ resource.ResourceWithConfigure    = &sslCertificateResource{}
+	_ resource.ResourceWithImportState  = &sslCertificateResource{}
+	_ resource.ResourceWithIdentity     = &sslCertificateResource{}
+	_ resource.ResourceWithUpgradeState = &sslCertificateResource{}
+	_ resource.ResourceWithMoveState    = &sslCertificateResource{}
+	_ resource.ResourceWithModifyPlan   = &sslCertificateResource{}
 )
 
 // NewSSLCertificateResource is a helper function to simplify the provider implementation.
@@ -29,7 +31,7 @@ func NewSSLCertificateResource() resource.Resource {
 
 // sslCertificateResource is the resource implementation.
 type sslCertificateResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -42,8 +44,35 @@ func (r *sslCertificateResource) Schema(_ context.Context, _ resource.SchemaRequ
 	resp.Schema = model.SSLCertificateSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *sslCertificateResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.ObjectIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *sslCertificateResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.SSLCertificateStateUpgrades)
+}
+
+// MoveState moves the state of the SSL certificates of the other APISIX providers.
+func (r *sslCertificateResource) MoveState(_ context.Context) []resource.StateMover {
+	return moveStateFrom(model.SSLCertificateSchema, []string{"ssl", "ssl_certificate"}, moveSSLCertificate, moveStatus)
+}
+
 // Implement plan modification
 func (r *sslCertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_ssl_certificate", req.Plan, &resp.Diagnostics)
+
+	// Expect a new update time for the certificates about to be updated
+	planUpdateTime(ctx, req, resp)
+
+	// Derive the identifier of the new certificates configured without it, if enabled
+	r.client.planObjectID(ctx, "apisix_ssl_certificate", req, resp, path.Root("certificate"))
+
+	// Let the references to the new certificates pass before they are created
+	r.client.registerPlannedObject(ctx, "ssls", req, resp, path.Root("id"))
+
 	// If the entire plan is null, the resource is planned for destruction.
 	if req.Plan.Raw.IsNull() {
 		// Resource modification will not be performed when the resource is deleted .
@@ -60,7 +89,7 @@ func (r *sslCertificateResource) ModifyPlan(ctx context.Context, req resource.Mo
 	}
 
 	if state.Snis.IsNull() || len(state.Snis.Elements()) == 0 {
-		snis, err := model.CertSNIS(state.Certificate.ValueString(), state.PrivateKey.ValueString())
+		snis, err := model.CertSNIS(state.Certificate.ValueString(), model.WriteOnlyValue(state.PrivateKey, state.PrivateKeyWO).ValueString())
 		if err != nil {
 			tflog.Error(ctx, "Error. SNIS can't be determined")
 		}
@@ -82,12 +111,12 @@ func (r *sslCertificateResource) Configure(_ context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -107,13 +136,67 @@ func (r *sslCertificateResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	// Send the write-only secrets, only available in the configuration
+	var config model.SSLCertificateResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SetWriteOnly(config)
+
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "SSL Certificate", plan.ID, createTimeout)
+
 	// Generate API request body from plan
 	newCertificateRequest := model.SSLCertificateFromTerraformToAPI(ctx, &plan)
 
-	// Create new certificate
-	newCertificateResponse, err := r.client.CreateSslCertificate(newCertificateRequest)
+	// Use the planned identifier or generate a new one
+	certificateID, err := newObjectID(plan.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
+			"Error creating SSL certificate",
+			"Could not generate SSL certificate ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Keep the other resources writing the certificate waiting until it's created
+	unlock := client.lockObject(ctx, "SSL Certificate", "ssls/"+certificateID, &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing certificate when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetSslCertificate(certificateID)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating SSL certificate",
+			"Could not read APISIX SSL Certificate ID "+certificateID+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "SSL certificate", certificateID, existing, newCertificateRequest)
+		} else if !sameObject(existing, newCertificateRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "SSL certificate", certificateID)
+			return
+		}
+	}
+
+	// Create new certificate
+	newCertificateResponse, err := client.UpdateSslCertificate(certificateID, newCertificateRequest)
+	if err != nil {
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating SSL certificate",
 			"Could not create SSL certificate, unexpected error: "+err.Error(),
 		)
@@ -122,7 +205,20 @@ func (r *sslCertificateResource) Create(ctx context.Context, req resource.Create
 
 	// Map response body to schema and populate Computed attribute values
 	newState := model.SSLCertificateFromAPIToTerraform(ctx, newCertificateResponse)
-	newState.PrivateKey = types.StringValue(plan.PrivateKey.ValueString())
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("ssls/" + certificateID)
+	newState.UpdateStrategy = plan.UpdateStrategy
+	newState.PrivateKey = plan.PrivateKey
+	newState.KeepWriteOnly(plan)
+
+	// Remember the version of the certificate to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "ssls/"+certificateID, resp.Private, &resp.Diagnostics)
+
+	// Identify the certificate by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -144,7 +240,7 @@ func (r *sslCertificateResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	// Get refreshed certificate from the APISIX
-	certificateStatusResponse, err := r.client.GetSslCertificate(state.ID.ValueString())
+	certificateStatusResponse, err := r.client.readClient(ctx, req.Private, &resp.Diagnostics).GetSslCertificate(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX SSL Certificate",
@@ -155,7 +251,20 @@ func (r *sslCertificateResource) Read(ctx context.Context, req resource.ReadRequ
 
 	// Overwrite with refreshed state
 	newState := model.SSLCertificateFromAPIToTerraform(ctx, certificateStatusResponse)
-	newState.PrivateKey = types.StringValue(state.PrivateKey.ValueString())
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.ForceDelete = state.ForceDelete
+	newState.CreateTime, newState.UpdateTime = r.client.objectTimestamps("ssls/" + state.ID.ValueString())
+	newState.UpdateStrategy = state.UpdateStrategy
+	newState.PrivateKey = state.PrivateKey
+	newState.KeepWriteOnly(state)
+
+	// Remember the version of the certificate to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "ssls/"+state.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the certificate by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
@@ -177,31 +286,81 @@ func (r *sslCertificateResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	// Send the write-only secrets, only available in the configuration
+	var config model.SSLCertificateResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SetWriteOnly(config)
+
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "SSL Certificate", plan.ID, updateTimeout)
+
+	// Keep the other resources writing the certificate waiting until it's updated
+	unlock := client.lockObject(ctx, "SSL Certificate", "ssls/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the certificate wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "SSL Certificate", "ssls/"+plan.ID.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	updateCertificateRequest := model.SSLCertificateFromTerraformToAPI(ctx, &plan)
 
-	// Update existing certificate
-	_, err := r.client.UpdateSslCertificate(plan.ID.ValueString(), updateCertificateRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating APISIX SSL Certificate",
-			"Could not update SSL certificate, unexpected error: "+err.Error(),
-		)
+	// Retrieve values from state, the patch update strategy sends only the changes
+	var state model.SSLCertificateResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Fetch updated certificate
-	updatedCertificate, err := r.client.GetSslCertificate(plan.ID.ValueString())
+	// Generate API request body from state
+	priorCertificateRequest := model.SSLCertificateFromTerraformToAPI(ctx, &state)
+
+	// Update existing certificate, the response holds the updated certificate
+	updatedCertificate, err := updateObject(ctx, client, plan.UpdateStrategy, "ssls/"+plan.ID.ValueString(), priorCertificateRequest, updateCertificateRequest, func() (*api_client.SSLCertificate, error) {
+		return client.UpdateSslCertificate(plan.ID.ValueString(), updateCertificateRequest)
+	}, func() (*api_client.SSLCertificate, error) {
+		return client.GetSslCertificate(plan.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX SSL Certificate",
-			"Could not read APISIX SSL Certificate ID "+plan.ID.ValueString()+": "+err.Error(),
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
+			"Error Updating APISIX SSL Certificate",
+			"Could not update SSL certificate, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
 	newState := model.SSLCertificateFromAPIToTerraform(ctx, updatedCertificate)
-	newState.PrivateKey = types.StringValue(plan.PrivateKey.ValueString())
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("ssls/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
+	newState.PrivateKey = plan.PrivateKey
+	newState.KeepWriteOnly(plan)
+
+	// Remember the version of the certificate to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "ssls/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the certificate by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -222,10 +381,35 @@ func (r *sslCertificateResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "SSL Certificate", state.ID, deleteTimeout)
+
+	// Keep the other resources writing the certificate waiting until it's deleted
+	unlock := client.lockObject(ctx, "SSL Certificate", "ssls/"+state.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the certificate wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "SSL Certificate", "ssls/"+state.ID.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete existing certificate
-	err := r.client.DeleteSslCertificate(state.ID.ValueString())
+	err := client.deleteObject(ctx, "ssls/"+state.ID.ValueString(), state.ForceDelete, func() error {
+		return client.DeleteSslCertificate(state.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		client.addDeleteError(ctx, &resp.Diagnostics, "ssls/"+state.ID.ValueString(), err,
 			"Error Deleting APISIX SSL Certificate",
 			"Could not delete certificate, unexpected error: "+err.Error(),
 		)
@@ -235,6 +419,15 @@ func (r *sslCertificateResource) Delete(ctx context.Context, req resource.Delete
 
 // Import resource into state
 func (r *sslCertificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Resolve the import ID, identity or SNI, name or labels selector into the ID, and save it to the id attribute
+	id, err := r.client.resolveImportID(ctx, "SSL Certificate", "ssls", importIdentifier(ctx, req, path.Root("id"), &resp.Diagnostics), true)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing APISIX SSL Certificate",
+			"Could not import SSL Certificate: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: types.StringValue(id)}, &resp.Diagnostics)
 }
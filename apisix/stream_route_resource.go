@@ -4,20 +4,22 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &streamRouteResource{}
-	_ resource.ResourceWithConfigure   = &streamRouteResource{}
-	_ resource.ResourceWithImportState = &streamRouteResource{}
+	_ resource.Resource                 = &streamRouteResource{}
+	_ resource.ResourceWithConfigure    = &streamRouteResource{}
+	_ resource.ResourceWithImportState  = &streamRouteResource{}
+	_ resource.ResourceWithIdentity     = &streamRouteResource{}
+	_ resource.ResourceWithUpgradeState = &streamRouteResource{}
+	_ resource.ResourceWithModifyPlan   = &streamRouteResource{}
 )
 
 // NewStreamRouteResource is a helper function to simplify the provider implementation.
@@ -27,7 +29,7 @@ func NewStreamRouteResource() resource.Resource {
 
 // streamRouteResource is the resource implementation.
 type streamRouteResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -40,18 +42,43 @@ func (r *streamRouteResource) Schema(_ context.Context, _ resource.SchemaRequest
 	resp.Schema = model.StreamRouteSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *streamRouteResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.ObjectIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *streamRouteResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.StreamRouteStateUpgrades)
+}
+
+// Implement plan modification
+func (r *streamRouteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_stream_route", req.Plan, &resp.Diagnostics)
+
+	// Expect a new update time for the stream routes about to be updated
+	planUpdateTime(ctx, req, resp)
+
+	// Derive the identifier of the new stream routes configured without it, if enabled
+	r.client.planObjectID(ctx, "apisix_stream_route", req, resp, path.Root("remote_addr"), path.Root("server_addr"), path.Root("server_port"), path.Root("sni"))
+
+	// Make sure the referenced objects exist before anything is applied
+	r.client.checkObjectReferences(ctx, "apisix_stream_route", resp.Plan, &resp.Diagnostics)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *streamRouteResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -70,13 +97,58 @@ func (r *streamRouteResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Stream Route", plan.ID, createTimeout)
+
 	// Generate API request body from plan
 	newStreamRouteRequest := model.StreamRouteFromTerraformToApi(ctx, &plan)
 
-	// Create new stream route
-	newStreamRouteReponse, err := r.client.CreateStreamRoute(newStreamRouteRequest)
+	// Use the planned identifier or generate a new one
+	streamRouteID, err := newObjectID(plan.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
+			"Error creating Stream Route",
+			"Could not generate Stream Route ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Keep the other resources writing the stream route waiting until it's created
+	unlock := client.lockObject(ctx, "Stream Route", "stream_routes/"+streamRouteID, &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing stream route when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetStreamRoute(streamRouteID)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Stream Route",
+			"Could not read APISIX Stream Route by ID "+streamRouteID+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Stream Route", streamRouteID, existing, newStreamRouteRequest)
+		} else if !sameObject(existing, newStreamRouteRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "Stream Route", streamRouteID)
+			return
+		}
+	}
+
+	// Create new stream route
+	newStreamRouteReponse, err := client.UpdateStreamRoute(streamRouteID, newStreamRouteRequest)
+	if err != nil {
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Stream Route",
 			"Could not create Stream Route, unexpected error: "+err.Error(),
 		)
@@ -85,6 +157,16 @@ func (r *streamRouteResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Map response body to schema and populate Computed attribute values
 	newState := model.StreamRouteFromApiToTerraform(ctx, newStreamRouteReponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("stream_routes/" + streamRouteID)
+
+	// Remember the version of the stream route to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "stream_routes/"+streamRouteID, resp.Private, &resp.Diagnostics)
+
+	// Identify the stream route by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -106,7 +188,7 @@ func (r *streamRouteResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	// Get refreshed stream route from the APISIX
-	streamRouteStateResponse, err := r.client.GetStreamRoute(state.ID.ValueString())
+	streamRouteStateResponse, err := r.client.readClient(ctx, req.Private, &resp.Diagnostics).GetStreamRoute(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Stream Route",
@@ -117,6 +199,16 @@ func (r *streamRouteResource) Read(ctx context.Context, req resource.ReadRequest
 
 	// Overwrite with refreshed state
 	newState := model.StreamRouteFromApiToTerraform(ctx, streamRouteStateResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.CreateTime, newState.UpdateTime = r.client.objectTimestamps("stream_routes/" + state.ID.ValueString())
+
+	// Remember the version of the stream route to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "stream_routes/"+state.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the stream route by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
@@ -137,30 +229,53 @@ func (r *streamRouteResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Stream Route", plan.ID, updateTimeout)
+
+	// Keep the other resources writing the stream route waiting until it's updated
+	unlock := client.lockObject(ctx, "Stream Route", "stream_routes/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the stream route wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Stream Route", "stream_routes/"+plan.ID.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	updateStreamRouteRequest := model.StreamRouteFromTerraformToApi(ctx, &plan)
 
-	// Update existing stream route
-	_, err := r.client.UpdateStreamRoute(plan.ID.ValueString(), updateStreamRouteRequest)
+	// Update existing stream route, the response holds the updated stream route
+	updatedStreamRoute, err := client.UpdateStreamRoute(plan.ID.ValueString(), updateStreamRouteRequest)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error Updating APISIX Stream Route",
 			"Could not update Stream Route, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
-	// Fetch updated stream route
-	updatedStreamRoute, err := r.client.GetStreamRoute(plan.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Stream Route",
-			"Could not read APISIX Stream Route by ID "+plan.ID.ValueString()+": "+err.Error(),
-		)
-		return
-	}
-
 	newState := model.StreamRouteFromApiToTerraform(ctx, updatedStreamRoute)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("stream_routes/" + plan.ID.ValueString())
+
+	// Remember the version of the stream route to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "stream_routes/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the stream route by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
@@ -181,8 +296,31 @@ func (r *streamRouteResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Stream Route", state.ID, deleteTimeout)
+
+	// Keep the other resources writing the stream route waiting until it's deleted
+	unlock := client.lockObject(ctx, "Stream Route", "stream_routes/"+state.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the stream route wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Stream Route", "stream_routes/"+state.ID.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the Stream Route
-	err := r.client.DeleteStreamRoute(state.ID.ValueString())
+	err := client.DeleteStreamRoute(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting APISIX Stream Route",
@@ -195,6 +333,15 @@ func (r *streamRouteResource) Delete(ctx context.Context, req resource.DeleteReq
 // Import resource into state
 func (r *streamRouteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the stream route importing")
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Resolve the import ID, identity or name or labels selector into the ID, and save it to the id attribute
+	id, err := r.client.resolveImportID(ctx, "Stream Route", "stream_routes", importIdentifier(ctx, req, path.Root("id"), &resp.Diagnostics), false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing APISIX Stream Route",
+			"Could not import Stream Route: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: types.StringValue(id)}, &resp.Diagnostics)
 }
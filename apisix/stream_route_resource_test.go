@@ -15,13 +15,9 @@ func TestStreamRouteResource(t *testing.T) {
 				Config: providerConfig + `
 resource "apisix_upstream" "test" {
 	type = "roundrobin"
-	nodes = [
-		{
-			host   = "127.0.0.1"
-			port   = 8080
-			weight = 1
-		}
-	]
+	nodes = {
+		"127.0.0.1:8080" = { weight = 1 }
+	}
 }
 
 resource "apisix_stream_route" "test" {
@@ -51,13 +47,9 @@ resource "apisix_stream_route" "test" {
 				Config: providerConfig + `
 resource "apisix_upstream" "test" {
 	type = "roundrobin"
-	nodes = [
-		{
-			host   = "127.0.0.1"
-			port   = 8080
-			weight = 1
-		}
-	]
+	nodes = {
+		"127.0.0.1:8080" = { weight = 1 }
+	}
 }
 
 resource "apisix_stream_route" "test" {
@@ -0,0 +1,73 @@
+package apisix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default timeouts of the operations the timeouts block doesn't set.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// contextTransport sends the requests with the context of the operation, as
+// the api_client package doesn't take one, so they are aborted on its expiry.
+type contextTransport struct {
+	Nested http.RoundTripper
+	ctx    context.Context
+}
+
+func (t contextTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return t.Nested.RoundTrip(r.WithContext(t.ctx))
+}
+
+// withTimeout bounds an operation with its timeout. It returns the bounded
+// context and a copy of the client sending all its requests with it.
+func (c *apisixClient) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, *apisixClient, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	transport := c.HTTPClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	apiClient := *c.ApiClient
+	apiClient.HTTPClient = &http.Client{Transport: contextTransport{Nested: transport, ctx: ctx}}
+
+	client := *c
+	client.ApiClient = &apiClient
+
+	return ctx, &client, cancel
+}
+
+// reportTimeout adds an error naming the object when the operation failed
+// because its timeout expired, with the last error in the detail. The id is
+// unknown for the objects the identifier isn't assigned to yet.
+func reportTimeout(ctx context.Context, diags *diag.Diagnostics, operation string, objectType string, id types.String, timeout time.Duration) {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) || !diags.HasError() {
+		return
+	}
+
+	object := "The new " + strings.ToLower(objectType)
+	if !id.IsNull() && !id.IsUnknown() {
+		object = fmt.Sprintf("The %s %q", strings.ToLower(objectType), id.ValueString())
+	}
+
+	errs := diags.Errors()
+	lastErr := errs[len(errs)-1]
+
+	diags.AddError(
+		fmt.Sprintf("APISIX %s %s Timed Out", objectType, strings.ToUpper(operation[:1])+operation[1:]),
+		fmt.Sprintf("%s could not be %sd within %s. Increase the %s timeout in the timeouts block if APISIX needs longer. "+
+			"The last error was:\n\n%s: %s", object, operation, timeout, operation, lastErr.Summary(), lastErr.Detail()),
+	)
+}
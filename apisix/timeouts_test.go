@@ -0,0 +1,58 @@
+package apisix
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/holubovskyi/apisix-client-go"
+)
+
+func TestWithTimeout(t *testing.T) {
+	// A degraded control plane answering after the timeout
+	server := newFakeAdminServer(t)
+	server.setObject("routes/1", `{"id":"1"}`)
+	server.readDelay = time.Second
+	client := &apisixClient{ApiClient: &api_client.ApiClient{Endpoint: server.URL, HTTPClient: server.Client()}}
+
+	ctx, timeoutClient, cancel := client.withTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var diags diag.Diagnostics
+	start := time.Now()
+	if _, err := timeoutClient.GetRoute("1"); err != nil {
+		diags.AddError("Error Reading APISIX Route", err.Error())
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the request to be aborted on the timeout, took %s", elapsed)
+	}
+	if client.HTTPClient != server.Client() {
+		t.Error("expected the provider client to be left unchanged")
+	}
+
+	reportTimeout(ctx, &diags, "update", "Route", types.StringValue("1"), 50*time.Millisecond)
+
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected the request error and the timeout error, got %v", diags)
+	}
+	timeoutErr := diags.Errors()[1]
+	if timeoutErr.Summary() != "APISIX Route Update Timed Out" ||
+		!strings.Contains(timeoutErr.Detail(), `The route "1" could not be updated within 50ms.`) ||
+		!strings.Contains(timeoutErr.Detail(), "Error Reading APISIX Route: ") {
+		t.Errorf("unexpected timeout error %q: %q", timeoutErr.Summary(), timeoutErr.Detail())
+	}
+}
+
+func TestReportTimeoutNotExpired(t *testing.T) {
+	var diags diag.Diagnostics
+	diags.AddError("Error creating Route", "Could not create Route")
+
+	reportTimeout(context.Background(), &diags, "create", "Route", types.StringUnknown(), time.Minute)
+
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected only the original error, got %v", diags)
+	}
+}
@@ -0,0 +1,41 @@
+package apisix
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// objectTimestamps returns the create_time and update_time of the object last
+// returned by the Admin API, or null values when APISIX didn't report them.
+func (c *apisixClient) objectTimestamps(objectPath string) (createTime types.Int64, updateTime types.Int64) {
+	createTime, updateTime = types.Int64Null(), types.Int64Null()
+
+	version, ok := c.versions.get(objectPath)
+	if !ok {
+		return
+	}
+
+	if version.CreateTime != 0 {
+		createTime = types.Int64Value(version.CreateTime)
+	}
+	if version.UpdateTime != 0 {
+		updateTime = types.Int64Value(version.UpdateTime)
+	}
+
+	return
+}
+
+// planUpdateTime marks the update_time unknown when the object is about to be
+// updated. Otherwise it keeps the value from the state, so the timestamps don't
+// show up in the plans without changes.
+func planUpdateTime(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only the objects to be updated get a new update time
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("update_time"), types.Int64Unknown())...)
+}
@@ -4,14 +4,14 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/holubovskyi/apisix-client-go"
-
 	"terraform-provider-apisix/apisix/model"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/holubovskyi/apisix-client-go"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -19,7 +19,11 @@ var (
 	_ resource.Resource                     = &upstreamResource{}
 	_ resource.ResourceWithConfigure        = &upstreamResource{}
 	_ resource.ResourceWithImportState      = &upstreamResource{}
+	_ resource.ResourceWithIdentity         = &upstreamResource{}
+	_ resource.ResourceWithUpgradeState     = &upstreamResource{}
+	_ resource.ResourceWithMoveState        = &upstreamResource{}
 	_ resource.ResourceWithConfigValidators = &upstreamResource{}
+	_ resource.ResourceWithModifyPlan       = &upstreamResource{}
 )
 
 // NewUpstreamResource is a helper function to simplify the provider implementation.
@@ -29,7 +33,7 @@ func NewUpstreamResource() resource.Resource {
 
 // upstreamResource is the resource implementation.
 type upstreamResource struct {
-	client *api_client.ApiClient
+	client *apisixClient
 }
 
 // Metadata returns the resource type name.
@@ -42,6 +46,21 @@ func (r *upstreamResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	resp.Schema = model.UpstreamSchema
 }
 
+// IdentitySchema defines the identity of the resource.
+func (r *upstreamResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = model.ObjectIdentitySchema
+}
+
+// UpgradeState upgrades the states of the prior schema versions.
+func (r *upstreamResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return model.StateUpgraders(model.UpstreamStateUpgrades)
+}
+
+// MoveState moves the state of the upstreams of the other APISIX providers.
+func (r *upstreamResource) MoveState(_ context.Context) []resource.StateMover {
+	return moveStateFrom(model.UpstreamSchema, []string{"upstream"}, moveUpstreamNodes)
+}
+
 // Validate Config
 func (r *upstreamResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
@@ -68,18 +87,36 @@ func (r *upstreamResource) ConfigValidators(ctx context.Context) []resource.Conf
 	}
 }
 
+// Implement plan modification
+func (r *upstreamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Reject the values the configured APISIX Admin API can't store
+	r.client.checkAPIVersionSupport(ctx, "apisix_upstream", req.Plan, &resp.Diagnostics)
+
+	// Expect a new update time for the upstreams about to be updated
+	planUpdateTime(ctx, req, resp)
+
+	// Derive the identifier of the new upstreams configured without it, if enabled
+	r.client.planObjectID(ctx, "apisix_upstream", req, resp, path.Root("name"))
+
+	// Make sure the referenced objects exist before anything is applied
+	r.client.checkObjectReferences(ctx, "apisix_upstream", resp.Plan, &resp.Diagnostics)
+
+	// Let the references to the new upstreams pass before they are created
+	r.client.registerPlannedObject(ctx, "upstreams", req, resp, path.Root("id"))
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *upstreamResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api_client.ApiClient)
+	client, ok := req.ProviderData.(*apisixClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *api_client.ApiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *apisixClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -99,6 +136,25 @@ func (r *upstreamResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// Send the write-only secrets, only available in the configuration
+	var config model.UpstreamResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SetWriteOnly(config)
+
+	// Bound the whole creation, including the retries and the read back, with the create timeout
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, createTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "create", "Upstream", plan.ID, createTimeout)
+
 	// Generate API request body from plan
 	newUpstreamRequest, labelsDiag := model.UpstreamFromTerraformToAPI(ctx, &plan)
 
@@ -107,10 +163,45 @@ func (r *upstreamResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Create new upstream
-	newUpstreamResponse, err := r.client.CreateUpstream(newUpstreamRequest)
+	// Use the planned identifier or generate a new one
+	upstreamID, err := newObjectID(plan.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
+			"Error creating Upstream",
+			"Could not generate Upstream ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Keep the other resources writing the upstream waiting until it's created
+	unlock := client.lockObject(ctx, "Upstream", "upstreams/"+upstreamID, &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Take over the existing upstream when requested, or make sure the identifier isn't used by another one
+	existing, err := client.withFreshReads(ctx).GetUpstream(upstreamID)
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Error creating Upstream",
+			"Could not read APISIX Upstream by ID "+upstreamID+": "+err.Error(),
+		)
+		return
+	}
+	if err == nil {
+		if plan.AdoptExisting.ValueBool() {
+			addAdoptionWarning(&resp.Diagnostics, "Upstream", upstreamID, existing, newUpstreamRequest)
+		} else if !sameObject(existing, newUpstreamRequest) {
+			addObjectIDTakenError(&resp.Diagnostics, path.Root("id"), "Upstream", upstreamID)
+			return
+		}
+	}
+
+	// Create new upstream
+	newUpstreamResponse, err := client.UpdateUpstream(upstreamID, newUpstreamRequest)
+	if err != nil {
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
 			"Error creating Upstream",
 			"Could not create Upstream, unexpected error: "+err.Error(),
 		)
@@ -119,15 +210,28 @@ func (r *upstreamResource) Create(ctx context.Context, req resource.CreateReques
 
 	// Map response body to schema and populate Computed attribute values
 	newState, labelsDiag := model.UpstreamFromApiToTerraform(ctx, newUpstreamResponse)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("upstreams/" + upstreamID)
+	newState.UpdateStrategy = plan.UpdateStrategy
 	if plan.TLS != nil && newState.TLS != nil {
 		newState.TLS.ClientKey = plan.TLS.ClientKey
 	}
+	newState.KeepWriteOnly(plan)
 
 	resp.Diagnostics.Append(labelsDiag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Remember the version of the upstream to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "upstreams/"+upstreamID, resp.Private, &resp.Diagnostics)
+
+	// Identify the upstream by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -148,7 +252,7 @@ func (r *upstreamResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	// Get refreshed upstream from the APISIX
-	upsreamResponse, err := r.client.GetUpstream(state.ID.ValueString())
+	upsreamResponse, err := r.client.readClient(ctx, req.Private, &resp.Diagnostics).GetUpstream(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading APISIX Upstream",
@@ -159,15 +263,28 @@ func (r *upstreamResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	// Overwrite with refreshed state
 	newState, labelsDiag := model.UpstreamFromApiToTerraform(ctx, upsreamResponse)
+	newState.AdoptExisting = state.AdoptExisting
+	newState.IgnoreConcurrentChanges = state.IgnoreConcurrentChanges
+	newState.Timeouts = state.Timeouts
+	newState.ForceDelete = state.ForceDelete
+	newState.CreateTime, newState.UpdateTime = r.client.objectTimestamps("upstreams/" + state.ID.ValueString())
+	newState.UpdateStrategy = state.UpdateStrategy
 	if state.TLS != nil && newState.TLS != nil {
 		newState.TLS.ClientKey = state.TLS.ClientKey
 	}
+	newState.KeepWriteOnly(state)
 
 	resp.Diagnostics.Append(labelsDiag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Remember the version of the upstream to detect the changes made outside Terraform
+	r.client.recordObjectVersion(ctx, "upstreams/"+state.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the upstream by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -188,6 +305,38 @@ func (r *upstreamResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	// Send the write-only secrets, only available in the configuration
+	var config model.UpstreamResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SetWriteOnly(config)
+
+	// Bound the whole update, including the retries and the read back, with the update timeout
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "update", "Upstream", plan.ID, updateTimeout)
+
+	// Keep the other resources writing the upstream waiting until it's updated
+	unlock := client.lockObject(ctx, "Upstream", "upstreams/"+plan.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the upstream wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Upstream", "upstreams/"+plan.ID.ValueString(), plan.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	updateUpstreamRequest, labelsDiag := model.UpstreamFromTerraformToAPI(ctx, &plan)
 
@@ -196,35 +345,58 @@ func (r *upstreamResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	// Update existing upstream
-	_, err := r.client.UpdateUpstream(plan.ID.ValueString(), updateUpstreamRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating APISIX Upstream",
-			"Could not update upstream, unexpected error: "+err.Error(),
-		)
+	// Retrieve values from state, the patch update strategy sends only the changes
+	var state model.UpstreamResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from state
+	priorUpstreamRequest, labelsDiag := model.UpstreamFromTerraformToAPI(ctx, &state)
+
+	resp.Diagnostics.Append(labelsDiag...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Fetch updated upstream from APISIX
-	updatedUpstream, err := r.client.GetUpstream(plan.ID.ValueString())
+	// Update existing upstream, the response holds the updated upstream
+	updatedUpstream, err := updateObject(ctx, client, plan.UpdateStrategy, "upstreams/"+plan.ID.ValueString(), priorUpstreamRequest, updateUpstreamRequest, func() (*api_client.Upstream, error) {
+		return client.UpdateUpstream(plan.ID.ValueString(), updateUpstreamRequest)
+	}, func() (*api_client.Upstream, error) {
+		return client.GetUpstream(plan.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading APISIX Upstream",
-			"Could not read APISIX Upstream by ID "+plan.ID.ValueString()+": "+err.Error(),
+		addAdminAPIError(ctx, &resp.Diagnostics, req.Plan, err,
+			"Error Updating APISIX Upstream",
+			"Could not update upstream, unexpected error: "+err.Error(),
 		)
 		return
 	}
 
 	newState, labelsDiag := model.UpstreamFromApiToTerraform(ctx, updatedUpstream)
+	newState.AdoptExisting = plan.AdoptExisting
+	newState.IgnoreConcurrentChanges = plan.IgnoreConcurrentChanges
+	newState.Timeouts = plan.Timeouts
+	newState.ForceDelete = plan.ForceDelete
+	newState.CreateTime, newState.UpdateTime = client.objectTimestamps("upstreams/" + plan.ID.ValueString())
+	newState.UpdateStrategy = plan.UpdateStrategy
 	if plan.TLS != nil && newState.TLS != nil {
 		newState.TLS.ClientKey = plan.TLS.ClientKey
 	}
+	newState.KeepWriteOnly(plan)
 	resp.Diagnostics.Append(labelsDiag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Remember the version of the upstream to detect the changes made outside Terraform
+	client.recordObjectVersion(ctx, "upstreams/"+plan.ID.ValueString(), resp.Private, &resp.Diagnostics)
+
+	// Identify the upstream by its ID
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: newState.ID}, &resp.Diagnostics)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -244,10 +416,35 @@ func (r *upstreamResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	// Bound the whole delete, including the retries, with the delete timeout
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, client, cancel := r.client.withTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer reportTimeout(ctx, &resp.Diagnostics, "delete", "Upstream", state.ID, deleteTimeout)
+
+	// Keep the other resources writing the upstream waiting until it's deleted
+	unlock := client.lockObject(ctx, "Upstream", "upstreams/"+state.ID.ValueString(), &resp.Diagnostics)
+	defer unlock()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the upstream wasn't modified outside Terraform since the last refresh
+	client.checkConcurrentChanges(ctx, "Upstream", "upstreams/"+state.ID.ValueString(), state.IgnoreConcurrentChanges, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete existing certificate
-	err := r.client.DeleteUpstream(state.ID.ValueString())
+	err := client.deleteObject(ctx, "upstreams/"+state.ID.ValueString(), state.ForceDelete, func() error {
+		return client.DeleteUpstream(state.ID.ValueString())
+	})
 	if err != nil {
-		resp.Diagnostics.AddError(
+		client.addDeleteError(ctx, &resp.Diagnostics, "upstreams/"+state.ID.ValueString(), err,
 			"Error Deleting APISIX Upstream",
 			"Could not delete upstream by ID "+state.ID.ValueString()+" unexpected error: "+err.Error(),
 		)
@@ -258,6 +455,15 @@ func (r *upstreamResource) Delete(ctx context.Context, req resource.DeleteReques
 // Import resource into state
 func (r *upstreamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Debug(ctx, "Start of the upstream importing")
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Resolve the import ID, identity or name or labels selector into the ID, and save it to the id attribute
+	id, err := r.client.resolveImportID(ctx, "Upstream", "upstreams", importIdentifier(ctx, req, path.Root("id"), &resp.Diagnostics), false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing APISIX Upstream",
+			"Could not import Upstream: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	setIdentity(ctx, resp.Identity, model.ObjectIdentityModel{ID: types.StringValue(id)}, &resp.Diagnostics)
 }
@@ -1,6 +1,7 @@
 package apisix
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -20,18 +21,10 @@ resource "apisix_upstream" "test" {
 	labels = {
 		version = "v1"
 	}
-	nodes = [
-		{
-			host   = "127.0.0.1"
-			port   = 1980
-			weight = 1
-		},
-		{
-			host   = "127.0.0.1"
-			port   = 1970
-			weight = 1
-		},
-	]
+	nodes = {
+		"127.0.0.1:1980" = { weight = 1 }
+		"127.0.0.1:1970" = { weight = 1 }
+	}
 	keepalive_pool = {
 		idle_timeout = 5
 		requests     = 10
@@ -79,6 +72,8 @@ resource "apisix_upstream" "test" {
 					resource.TestCheckResourceAttr("apisix_upstream.test", "pass_host", "pass"),
 					resource.TestCheckResourceAttr("apisix_upstream.test", "scheme", "http"),
 					resource.TestCheckResourceAttr("apisix_upstream.test", "hash_on", "vars"),
+					resource.TestCheckResourceAttrSet("apisix_upstream.test", "create_time"),
+					resource.TestCheckResourceAttrSet("apisix_upstream.test", "update_time"),
 				),
 			},
 			// ImportState testing
@@ -99,18 +94,10 @@ resource "apisix_upstream" "test" {
 	labels = {
 		version = "v2"
 	}
-	nodes = [
-		{
-			host   = "127.0.0.1"
-			port   = 1980
-			weight = 1
-		},
-		{
-			host   = "127.0.0.1"
-			port   = 1970
-			weight = 5
-		},
-	]
+	nodes = {
+		"127.0.0.1:1980" = { weight = 1 }
+		"127.0.0.1:1970" = { weight = 5 }
+	}
 	keepalive_pool = {
 		idle_timeout = 10
 		requests     = 10
@@ -150,3 +137,90 @@ resource "apisix_upstream" "test" {
 		},
 	})
 }
+
+func TestUpstreamResourceUnsetOptionalAttributes(t *testing.T) {
+	optionalAttributes := `
+	desc = "Example of the upstream resource usage"
+	timeout = {
+		connect = 3
+		send    = 3
+		read    = 3
+	}
+	keepalive_pool = {
+		idle_timeout = 5
+		requests     = 10
+		size         = 15
+	}
+	labels = {
+		version = "v1"
+		team    = "payments"
+	}
+`
+	singleLabel := `
+	labels = {
+		version = "v1"
+	}
+`
+	unsetChecks := resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttr("apisix_upstream.test", "labels.%", "1"),
+		resource.TestCheckNoResourceAttr("apisix_upstream.test", "labels.team"),
+		resource.TestCheckNoResourceAttr("apisix_upstream.test", "desc"),
+		resource.TestCheckNoResourceAttr("apisix_upstream.test", "timeout"),
+		resource.TestCheckNoResourceAttr("apisix_upstream.test", "keepalive_pool"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with all the optional attributes set
+			{
+				Config: providerConfig + testAccUpstreamUnsetConfig("put", optionalAttributes),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_upstream.test", "labels.%", "2"),
+					resource.TestCheckResourceAttr("apisix_upstream.test", "keepalive_pool.size", "15"),
+				),
+			},
+			// Remove a single label and the optional attributes with a PUT
+			{
+				Config: providerConfig + testAccUpstreamUnsetConfig("put", singleLabel),
+				Check:  unsetChecks,
+			},
+			// Set the optional attributes again
+			{
+				Config: providerConfig + testAccUpstreamUnsetConfig("patch", optionalAttributes),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("apisix_upstream.test", "labels.%", "2"),
+					resource.TestCheckResourceAttr("apisix_upstream.test", "keepalive_pool.size", "15"),
+				),
+			},
+			// Remove a single label and the optional attributes with a PATCH
+			{
+				Config: providerConfig + testAccUpstreamUnsetConfig("patch", singleLabel),
+				Check:  unsetChecks,
+			},
+			// Verify the attributes are removed in APISIX too
+			{
+				ResourceName:            "apisix_upstream.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"update_strategy"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccUpstreamUnsetConfig returns an upstream configuration with the given
+// update strategy and optional attributes.
+func testAccUpstreamUnsetConfig(updateStrategy string, optionalAttributes string) string {
+	return fmt.Sprintf(`
+resource "apisix_upstream" "test" {
+	name            = "Unset"
+	type            = "roundrobin"
+	update_strategy = %q
+	nodes = {
+		"127.0.0.1:1980" = { weight = 1 }
+	}
+%s}
+`, updateStrategy, optionalAttributes)
+}
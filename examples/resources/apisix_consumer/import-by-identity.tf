@@ -0,0 +1,6 @@
+import {
+  to = apisix_consumer.example
+  identity = {
+    username = "example"
+  }
+}
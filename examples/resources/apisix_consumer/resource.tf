@@ -8,6 +8,13 @@ resource "apisix_consumer" "example" {
     {
       basic-auth = {
         username = "example"
+      }
+    }
+  )
+  # Masked in the plans and merged into the plugins
+  sensitive_plugins = jsonencode(
+    {
+      basic-auth = {
         password = "changeme2"
       }
     }
@@ -0,0 +1,6 @@
+import {
+  to = apisix_consumer_group.example
+  identity = {
+    id = "123"
+  }
+}
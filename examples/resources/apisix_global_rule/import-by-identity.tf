@@ -0,0 +1,6 @@
+import {
+  to = apisix_global_rule.example
+  identity = {
+    id = "123"
+  }
+}
@@ -7,4 +7,17 @@ resource "apisix_global_rule" "example" {
       }
     }
   )
-}
\ No newline at end of file
+}
+
+# The plugins as an object, the plans show the changes of each plugin field
+resource "apisix_global_rule" "object" {
+  id = "124"
+  plugin = {
+    limit-count = {
+      count         = 1000
+      time_window   = 60
+      rejected_code = 429
+      key           = "remote_addr"
+    }
+  }
+}
@@ -0,0 +1,6 @@
+import {
+  to = apisix_plugin_config.example
+  identity = {
+    id = "123"
+  }
+}
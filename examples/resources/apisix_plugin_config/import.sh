@@ -1,2 +1,6 @@
 # Plugin config can be imported by specifying the numeric identifier.
-terraform import apisix_plugin_config.example 123
\ No newline at end of file
+terraform import apisix_plugin_config.example 123
+
+# Plugin config can also be imported by its name, or by a set of its labels. The selector must match exactly one object.
+terraform import apisix_plugin_config.example name=checkout-auth
+terraform import apisix_plugin_config.example labels:team=payments,app=checkout
\ No newline at end of file
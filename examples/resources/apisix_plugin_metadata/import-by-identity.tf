@@ -0,0 +1,6 @@
+import {
+  to = apisix_plugin_metadata.syslog
+  identity = {
+    plugin_name = "syslog"
+  }
+}
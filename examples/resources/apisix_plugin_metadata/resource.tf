@@ -9,4 +9,16 @@ resource "apisix_plugin_metadata" "syslog" {
       }
     }
   )
-}
\ No newline at end of file
+}
+
+# The metadata as an object, the plans show the changes of each metadata field
+resource "apisix_plugin_metadata" "http_logger" {
+  id = "http-logger"
+  metadata_object = {
+    log_format = {
+      "@timestamp" = "$time_iso8601"
+      "client_ip"  = "$remote_addr"
+      "host"       = "$host"
+    }
+  }
+}
@@ -0,0 +1,6 @@
+import {
+  to = apisix_route.example
+  identity = {
+    id = "123"
+  }
+}
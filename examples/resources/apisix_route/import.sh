@@ -1,2 +1,6 @@
 # Route can be imported by specifying the numeric identifier.
-terraform import apisix_route.example 123
\ No newline at end of file
+terraform import apisix_route.example 123
+
+# Route can also be imported by its name, or by a set of its labels. The selector must match exactly one object.
+terraform import apisix_route.example name=checkout-api
+terraform import apisix_route.example labels:team=payments,app=checkout
\ No newline at end of file
@@ -21,6 +21,20 @@ resource "apisix_route" "example" {
       }
     }
   )
+  plugins_typed = {
+    limit_count = {
+      count         = 100
+      time_window   = 60
+      rejected_code = 429
+    }
+    proxy_rewrite = {
+      headers = {
+        set = {
+          X-Api-Version = "v1"
+        }
+      }
+    }
+  }
   labels = {
     "version" = "v1"
   }
@@ -0,0 +1,7 @@
+import {
+  to = apisix_secret.example
+  identity = {
+    manager = "gcp"
+    id      = "123"
+  }
+}
@@ -0,0 +1,6 @@
+import {
+  to = apisix_service.example
+  identity = {
+    id = "123"
+  }
+}
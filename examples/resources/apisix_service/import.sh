@@ -1,2 +1,6 @@
 # Service can be imported by specifying the numeric identifier.
 terraform import apisix_service.example 123
+
+# Service can also be imported by its name, or by a set of its labels. The selector must match exactly one object.
+terraform import apisix_service.example name=checkout
+terraform import apisix_service.example labels:team=payments,app=checkout
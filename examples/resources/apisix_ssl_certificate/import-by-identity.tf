@@ -0,0 +1,6 @@
+import {
+  to = apisix_ssl_certificate.example
+  identity = {
+    id = "123"
+  }
+}
@@ -1,2 +1,6 @@
 # SSL certificate can be imported by specifying the numeric identifier.
 terraform import apisix_ssl_certificate.example 123
+
+# SSL certificate can also be imported by one of its SNIs, its name, or a set of its labels. The selector must match exactly one object.
+terraform import apisix_ssl_certificate.example sni=api.example.com
+terraform import apisix_ssl_certificate.example labels:team=payments,app=checkout
@@ -1,2 +1,6 @@
 # Stream Route can be imported by specifying the numeric identifier.
 terraform import apisix_stream_route.example 123
+
+# Stream Route can also be imported by its name, or by a set of its labels. The selector must match exactly one object.
+terraform import apisix_stream_route.example name=mqtt
+terraform import apisix_stream_route.example labels:team=payments,app=checkout
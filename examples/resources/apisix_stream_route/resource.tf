@@ -1,12 +1,8 @@
 resource "apisix_upstream" "example" {
   type = "roundrobin"
-  nodes = [
-    {
-      host   = "127.0.0.1"
-      port   = 8080
-      weight = 1
-    }
-  ]
+  nodes = {
+    "127.0.0.1:8080" = { weight = 1 }
+  }
 }
 
 resource "apisix_stream_route" "example" {
@@ -0,0 +1,6 @@
+import {
+  to = apisix_upstream.example
+  identity = {
+    id = "123"
+  }
+}
@@ -1,2 +1,6 @@
 # Upstream can be imported by specifying the numeric identifier.
 terraform import apisix_upstream.example 123
+
+# Upstream can also be imported by its name, or by a set of its labels. The selector must match exactly one object.
+terraform import apisix_upstream.example name=checkout-backend
+terraform import apisix_upstream.example labels:team=payments,app=checkout
@@ -5,18 +5,10 @@ resource "apisix_upstream" "example" {
   labels = {
     version = "v1"
   }
-  nodes = [
-    {
-      host   = "127.0.0.1"
-      port   = 1980
-      weight = 1
-    },
-    {
-      host   = "127.0.0.1"
-      port   = 1970
-      weight = 1
-    },
-  ]
+  nodes = {
+    "127.0.0.1:1980" = { weight = 1 }
+    "127.0.0.1:1970" = { weight = 1 }
+  }
   keepalive_pool = {
     idle_timeout = 5
     requests     = 10
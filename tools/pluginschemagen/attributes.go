@@ -0,0 +1,550 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// attributeKind is the kind of the Terraform attribute generated for a
+// property of a plugin JSON schema.
+type attributeKind string
+
+const (
+	kindString     attributeKind = "String"
+	kindInt64      attributeKind = "Int64"
+	kindFloat64    attributeKind = "Float64"
+	kindBool       attributeKind = "Bool"
+	kindList       attributeKind = "List"
+	kindMap        attributeKind = "Map"
+	kindSingle     attributeKind = "SingleNested"
+	kindListNested attributeKind = "ListNested"
+	// kindJSON is a string attribute holding the JSON of the properties the
+	// typed attributes can't express, like the free-form objects.
+	kindJSON attributeKind = "JSON"
+)
+
+// attribute is the Terraform attribute generated for a property.
+type attribute struct {
+	Name        string
+	Kind        attributeKind
+	ElementKind attributeKind
+	Description string
+	Required    bool
+	Sensitive   bool
+	Default     string
+	Validators  []string
+	Attributes  []*attribute
+}
+
+// attributeNamePattern matches the property names usable as Terraform
+// attribute names. The properties prefixed with `_`, injected by APISIX like
+// `_meta`, are left out.
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// attributeBuilder builds the attributes of a plugin, recording the packages
+// used by their validators and defaults.
+type attributeBuilder struct {
+	imports map[string]bool
+}
+
+// objectAttributes builds the attributes of the properties of an object. The
+// properties only allowed under conditions, like the Redis properties of the
+// redis policy, are optional and have no default.
+func (b *attributeBuilder) objectAttributes(schema *jsonSchema, sensitive map[string]bool) []*attribute {
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	properties := map[string]*jsonSchema{}
+	for name, property := range schema.Properties {
+		properties[name] = property
+	}
+	conditional := map[string]bool{}
+	for name, property := range conditionalProperties(schema) {
+		if _, ok := properties[name]; !ok {
+			properties[name] = property
+			conditional[name] = true
+		}
+	}
+
+	var attributes []*attribute
+	for _, name := range sortedKeys(properties) {
+		if !attributeNamePattern.MatchString(name) {
+			continue
+		}
+
+		property := resolveSchema(properties[name])
+		if conditional[name] {
+			withoutDefault := *property
+			withoutDefault.Default = nil
+			property = &withoutDefault
+		}
+
+		attribute := b.attribute(name, property, nestedFields(sensitive, name))
+		attribute.Required = required[name] && property.Default == nil
+		attribute.Sensitive = sensitive[name]
+		attributes = append(attributes, attribute)
+	}
+
+	return attributes
+}
+
+// nestedFields returns the fields nested in the property, from the fields
+// given by their dotted path like the `encrypt_fields` of APISIX.
+func nestedFields(fields map[string]bool, property string) map[string]bool {
+	nested := map[string]bool{}
+	for field := range fields {
+		if suffix, ok := strings.CutPrefix(field, property+"."); ok {
+			nested[suffix] = true
+		}
+	}
+
+	return nested
+}
+
+// conditionalProperties returns the properties of the conditional subschemas
+// of an object: `then`, `else`, `dependencies`, `oneOf` and `anyOf`.
+func conditionalProperties(schema *jsonSchema) map[string]*jsonSchema {
+	properties := map[string]*jsonSchema{}
+
+	var subschemas []*jsonSchema
+	subschemas = append(subschemas, schema.Then, schema.Else)
+	subschemas = append(subschemas, schema.OneOf...)
+	subschemas = append(subschemas, schema.AnyOf...)
+	for _, name := range sortedKeys(schema.Dependencies) {
+		var dependency jsonSchema
+		if err := decodeJSON(schema.Dependencies[name], &dependency); err == nil {
+			subschemas = append(subschemas, &dependency)
+		}
+	}
+
+	for _, subschema := range subschemas {
+		if subschema == nil {
+			continue
+		}
+		for name, property := range subschema.Properties {
+			// The conditions only restrict the values of the properties
+			if property.Type == "" && len(property.OneOf) == 0 && len(property.AnyOf) == 0 {
+				continue
+			}
+			if _, ok := properties[name]; !ok {
+				properties[name] = property
+			}
+		}
+		for name, property := range conditionalProperties(subschema) {
+			if _, ok := properties[name]; !ok {
+				properties[name] = property
+			}
+		}
+	}
+
+	return properties
+}
+
+// resolveSchema picks the alternative of a property allowing several schemas
+// used for its attribute: the object with properties when all the
+// alternatives are objects, the first alternative with a type otherwise. It
+// also infers the type of the enumerations.
+func resolveSchema(schema *jsonSchema) *jsonSchema {
+	resolved := *schema
+
+	alternatives := schema.OneOf
+	if len(alternatives) == 0 {
+		alternatives = schema.AnyOf
+	}
+	if resolved.Type == "" && len(alternatives) > 0 {
+		var chosen *jsonSchema
+		allObjects := true
+		for _, alternative := range alternatives {
+			alternative = resolveSchema(alternative)
+			if alternative.Type != "object" {
+				allObjects = false
+			}
+			if chosen == nil && alternative.Type != "" {
+				chosen = alternative
+			}
+		}
+		if allObjects {
+			for _, alternative := range alternatives {
+				if len(alternative.Properties) > 0 {
+					chosen = alternative
+					break
+				}
+			}
+		}
+
+		if chosen != nil {
+			description, defaultValue := resolved.Description, resolved.Default
+			resolved = *chosen
+			if description != "" {
+				resolved.Description = description
+			}
+			if defaultValue != nil {
+				resolved.Default = defaultValue
+			}
+		}
+	}
+
+	if resolved.Type == "" && len(resolved.Enum) > 0 {
+		resolved.Type = enumType(resolved.Enum)
+	}
+
+	return &resolved
+}
+
+func enumType(values []interface{}) schemaType {
+	valueType := schemaType("")
+	for _, value := range values {
+		switch value := value.(type) {
+		case string:
+			valueType = "string"
+		case json.Number:
+			if valueType == "number" {
+				continue
+			}
+			if _, err := value.Int64(); err == nil && !strings.ContainsAny(value.String(), ".eE") {
+				valueType = "integer"
+			} else {
+				valueType = "number"
+			}
+		case bool:
+			valueType = "boolean"
+		}
+	}
+
+	return valueType
+}
+
+// attribute builds the attribute of a property, a JSON string attribute when
+// the property can't be typed.
+func (b *attributeBuilder) attribute(name string, schema *jsonSchema, sensitive map[string]bool) *attribute {
+	a := &attribute{Name: name}
+
+	switch schema.Type {
+	case "string", "integer", "number", "boolean":
+		a.Kind = scalarKind(schema.Type)
+		a.Validators = b.scalarValidators(a.Kind, schema)
+		a.Default = b.scalarDefault(a.Kind, schema.Default)
+	case "array":
+		items := schema.itemsSchema()
+		if items == nil {
+			return b.jsonAttribute(name, schema)
+		}
+		items = resolveSchema(items)
+
+		switch items.Type {
+		case "string", "integer", "number", "boolean":
+			a.Kind = kindList
+			a.ElementKind = scalarKind(items.Type)
+			a.Validators = b.listValidators(schema)
+			if elementValidators := b.scalarValidators(a.ElementKind, items); len(elementValidators) > 0 {
+				b.imports["listvalidator"] = true
+				a.Validators = append(a.Validators, fmt.Sprintf("listvalidator.Value%ssAre(%s)", a.ElementKind, strings.Join(elementValidators, ", ")))
+			}
+			a.Default = b.listDefault(a.ElementKind, schema.Default)
+		case "object":
+			if len(items.Properties) == 0 {
+				return b.jsonAttribute(name, schema)
+			}
+			a.Kind = kindListNested
+			a.Validators = b.listValidators(schema)
+			a.Attributes = b.objectAttributes(items, sensitive)
+		default:
+			return b.jsonAttribute(name, schema)
+		}
+	case "object":
+		if len(schema.Properties) > 0 {
+			a.Kind = kindSingle
+			a.Attributes = b.objectAttributes(schema, sensitive)
+			break
+		}
+
+		values := schema.valuesSchema()
+		if values == nil {
+			return b.jsonAttribute(name, schema)
+		}
+		values = resolveSchema(values)
+		switch values.Type {
+		case "string", "integer", "number", "boolean":
+			a.Kind = kindMap
+			a.ElementKind = scalarKind(values.Type)
+			if schema.MinProperties != nil && *schema.MinProperties > 0 {
+				b.imports["mapvalidator"] = true
+				a.Validators = append(a.Validators, fmt.Sprintf("mapvalidator.SizeAtLeast(%d)", *schema.MinProperties))
+			}
+		default:
+			return b.jsonAttribute(name, schema)
+		}
+	default:
+		return b.jsonAttribute(name, schema)
+	}
+
+	a.Description = description(schema, a.Default != "")
+
+	return a
+}
+
+func (b *attributeBuilder) jsonAttribute(name string, schema *jsonSchema) *attribute {
+	return &attribute{
+		Name:        name,
+		Kind:        kindJSON,
+		Description: strings.TrimSpace(description(schema, false) + " As a JSON string, like `jsonencode(...)`."),
+	}
+}
+
+func scalarKind(t schemaType) attributeKind {
+	switch t {
+	case "integer":
+		return kindInt64
+	case "number":
+		return kindFloat64
+	case "boolean":
+		return kindBool
+	}
+
+	return kindString
+}
+
+// description returns the description of the property, with its default.
+func description(schema *jsonSchema, withDefault bool) string {
+	text := strings.TrimSpace(schema.Description)
+	if text != "" {
+		text = strings.ToUpper(text[:1]) + text[1:]
+		if !strings.HasSuffix(text, ".") {
+			text += "."
+		}
+	}
+
+	if withDefault {
+		defaultJSON, _ := json.Marshal(schema.Default)
+		defaultText := string(defaultJSON)
+		if value, ok := schema.Default.(string); ok {
+			defaultText = value
+		}
+		text = strings.TrimSpace(text + " Defaults to `" + defaultText + "`.")
+	}
+
+	return text
+}
+
+func (b *attributeBuilder) scalarValidators(kind attributeKind, schema *jsonSchema) []string {
+	var validators []string
+
+	switch kind {
+	case kindString:
+		if len(schema.Enum) > 0 {
+			validators = append(validators, fmt.Sprintf("stringvalidator.OneOf(%s)", goValues(kind, schema.Enum)))
+		}
+		minLength, maxLength := schema.MinLength, schema.MaxLength
+		if minLength != nil && *minLength == 0 {
+			minLength = nil
+		}
+		switch {
+		case minLength != nil && maxLength != nil:
+			validators = append(validators, fmt.Sprintf("stringvalidator.LengthBetween(%d, %d)", *minLength, *maxLength))
+		case minLength != nil:
+			validators = append(validators, fmt.Sprintf("stringvalidator.LengthAtLeast(%d)", *minLength))
+		case maxLength != nil:
+			validators = append(validators, fmt.Sprintf("stringvalidator.LengthAtMost(%d)", *maxLength))
+		}
+		// The APISIX patterns are PCRE, only the ones RE2 supports are checked
+		if schema.Pattern != "" {
+			if _, err := regexp.Compile(schema.Pattern); err == nil {
+				b.imports["regexp"] = true
+				validators = append(validators, fmt.Sprintf("stringvalidator.RegexMatches(regexp.MustCompile(%s), %s)",
+					goString(schema.Pattern), goString("must match the pattern "+schema.Pattern)))
+			}
+		}
+		if len(validators) > 0 {
+			b.imports["stringvalidator"] = true
+		}
+	case kindInt64:
+		if len(schema.Enum) > 0 {
+			validators = append(validators, fmt.Sprintf("int64validator.OneOf(%s)", goValues(kind, schema.Enum)))
+		}
+		minimum, maximum := integerBounds(schema)
+		validators = append(validators, boundValidators("int64validator", minimum, maximum)...)
+		if len(validators) > 0 {
+			b.imports["int64validator"] = true
+		}
+	case kindFloat64:
+		if len(schema.Enum) > 0 {
+			validators = append(validators, fmt.Sprintf("float64validator.OneOf(%s)", goValues(kind, schema.Enum)))
+		}
+		// The exclusive bounds of the numbers are left to APISIX
+		minimum, maximum := "", ""
+		if schema.Minimum != nil && !isTrue(schema.ExclusiveMinimum) {
+			minimum = schema.Minimum.String()
+		}
+		if schema.Maximum != nil && !isTrue(schema.ExclusiveMaximum) {
+			maximum = schema.Maximum.String()
+		}
+		validators = append(validators, boundValidators("float64validator", minimum, maximum)...)
+		if len(validators) > 0 {
+			b.imports["float64validator"] = true
+		}
+	}
+
+	return validators
+}
+
+// integerBounds returns the inclusive bounds of an integer, from the
+// inclusive and the exclusive bounds, as booleans or numbers.
+func integerBounds(schema *jsonSchema) (string, string) {
+	minimum, maximum := "", ""
+
+	if schema.Minimum != nil {
+		if value, err := schema.Minimum.Int64(); err == nil {
+			if isTrue(schema.ExclusiveMinimum) {
+				value++
+			}
+			minimum = fmt.Sprint(value)
+		}
+	}
+	if exclusive, ok := schema.ExclusiveMinimum.(json.Number); ok {
+		if value, err := exclusive.Int64(); err == nil {
+			minimum = fmt.Sprint(value + 1)
+		}
+	}
+
+	if schema.Maximum != nil {
+		if value, err := schema.Maximum.Int64(); err == nil {
+			if isTrue(schema.ExclusiveMaximum) {
+				value--
+			}
+			maximum = fmt.Sprint(value)
+		}
+	}
+	if exclusive, ok := schema.ExclusiveMaximum.(json.Number); ok {
+		if value, err := exclusive.Int64(); err == nil {
+			maximum = fmt.Sprint(value - 1)
+		}
+	}
+
+	return minimum, maximum
+}
+
+func isTrue(value interface{}) bool {
+	flag, ok := value.(bool)
+
+	return ok && flag
+}
+
+func boundValidators(validatorPackage string, minimum string, maximum string) []string {
+	switch {
+	case minimum != "" && maximum != "":
+		return []string{fmt.Sprintf("%s.Between(%s, %s)", validatorPackage, minimum, maximum)}
+	case minimum != "":
+		return []string{fmt.Sprintf("%s.AtLeast(%s)", validatorPackage, minimum)}
+	case maximum != "":
+		return []string{fmt.Sprintf("%s.AtMost(%s)", validatorPackage, maximum)}
+	}
+
+	return nil
+}
+
+func (b *attributeBuilder) listValidators(schema *jsonSchema) []string {
+	var validators []string
+
+	switch {
+	case schema.MinItems != nil && schema.MaxItems != nil:
+		validators = append(validators, fmt.Sprintf("listvalidator.SizeBetween(%d, %d)", *schema.MinItems, *schema.MaxItems))
+	case schema.MinItems != nil:
+		validators = append(validators, fmt.Sprintf("listvalidator.SizeAtLeast(%d)", *schema.MinItems))
+	case schema.MaxItems != nil:
+		validators = append(validators, fmt.Sprintf("listvalidator.SizeAtMost(%d)", *schema.MaxItems))
+	}
+	if schema.UniqueItems {
+		validators = append(validators, "listvalidator.UniqueValues()")
+	}
+	if len(validators) > 0 {
+		b.imports["listvalidator"] = true
+	}
+
+	return validators
+}
+
+func (b *attributeBuilder) scalarDefault(kind attributeKind, value interface{}) string {
+	goValue, ok := goScalar(kind, value)
+	if !ok {
+		return ""
+	}
+
+	packageName := strings.ToLower(string(kind)) + "default"
+	b.imports[packageName] = true
+
+	return fmt.Sprintf("%s.Static%s(%s)", packageName, kind, goValue)
+}
+
+func (b *attributeBuilder) listDefault(elementKind attributeKind, value interface{}) string {
+	values, ok := value.([]interface{})
+	if !ok {
+		return ""
+	}
+
+	elements := make([]string, 0, len(values))
+	for _, value := range values {
+		goValue, ok := goScalar(elementKind, value)
+		if !ok {
+			return ""
+		}
+		elements = append(elements, fmt.Sprintf("types.%sValue(%s)", elementKind, goValue))
+	}
+
+	b.imports["listdefault"] = true
+	b.imports["attr"] = true
+
+	return fmt.Sprintf("listdefault.StaticValue(types.ListValueMust(types.%sType, []attr.Value{%s}))", elementKind, strings.Join(elements, ", "))
+}
+
+// goScalar returns the Go literal of a value of the kind.
+func goScalar(kind attributeKind, value interface{}) (string, bool) {
+	switch kind {
+	case kindString:
+		if value, ok := value.(string); ok {
+			return goString(value), true
+		}
+	case kindInt64:
+		if value, ok := value.(json.Number); ok {
+			if _, err := value.Int64(); err == nil {
+				return value.String(), true
+			}
+		}
+	case kindFloat64:
+		if value, ok := value.(json.Number); ok {
+			if _, err := value.Float64(); err == nil {
+				return value.String(), true
+			}
+		}
+	case kindBool:
+		if value, ok := value.(bool); ok {
+			return fmt.Sprint(value), true
+		}
+	}
+
+	return "", false
+}
+
+func goValues(kind attributeKind, values []interface{}) string {
+	goValues := make([]string, 0, len(values))
+	for _, value := range values {
+		if goValue, ok := goScalar(kind, value); ok {
+			goValues = append(goValues, goValue)
+		}
+	}
+
+	return strings.Join(goValues, ", ")
+}
+
+// goString returns the Go literal of a string, raw when possible to keep the
+// patterns readable.
+func goString(value string) string {
+	if strings.ContainsAny(value, "\\") && !strings.ContainsAny(value, "`\n") {
+		return "`" + value + "`"
+	}
+
+	return fmt.Sprintf("%q", value)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestObjectAttributes(t *testing.T) {
+	var schema jsonSchema
+	err := decodeJSON([]byte(`{
+		"type": "object",
+		"properties": {
+			"count": {"type": "integer", "exclusiveMinimum": 0},
+			"policy": {"type": "string", "enum": ["local", "redis"], "default": "local"},
+			"headers": {"type": "object", "patternProperties": {"^[^:]+$": {"type": "string"}}},
+			"vars": {"type": "array", "items": {"type": "array"}},
+			"_meta": {"type": "object"}
+		},
+		"required": ["count", "policy"],
+		"if": {"properties": {"policy": {"enum": ["redis"]}}},
+		"then": {
+			"properties": {
+				"redis_host": {"type": "string", "minLength": 2},
+				"redis_password": {"type": "string", "default": ""}
+			},
+			"required": ["redis_host"]
+		},
+		"encrypt_fields": ["redis_password"]
+	}`), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := &attributeBuilder{imports: map[string]bool{}}
+	attributes := builder.objectAttributes(&schema, map[string]bool{"redis_password": true})
+
+	actual := map[string]*attribute{}
+	for _, attribute := range attributes {
+		actual[attribute.Name] = attribute
+	}
+	if _, ok := actual["_meta"]; ok {
+		t.Errorf("expected the _meta property to be left out")
+	}
+	if count := actual["count"]; count.Kind != kindInt64 || !count.Required || !reflect.DeepEqual(count.Validators, []string{"int64validator.AtLeast(1)"}) {
+		t.Errorf("unexpected count attribute %+v", count)
+	}
+	// The properties with a default are optional
+	if policy := actual["policy"]; policy.Required || policy.Default != `stringdefault.StaticString("local")` {
+		t.Errorf("unexpected policy attribute %+v", policy)
+	}
+	if headers := actual["headers"]; headers.Kind != kindMap || headers.ElementKind != kindString {
+		t.Errorf("unexpected headers attribute %+v", headers)
+	}
+	if vars := actual["vars"]; vars.Kind != kindJSON {
+		t.Errorf("unexpected vars attribute %+v", vars)
+	}
+	// The conditional properties are optional without a default
+	if redisHost := actual["redis_host"]; redisHost == nil || redisHost.Required {
+		t.Errorf("unexpected redis_host attribute %+v", redisHost)
+	}
+	if redisPassword := actual["redis_password"]; redisPassword == nil || !redisPassword.Sensitive || redisPassword.Default != "" {
+		t.Errorf("unexpected redis_password attribute %+v", redisPassword)
+	}
+}
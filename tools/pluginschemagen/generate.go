@@ -0,0 +1,458 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// generatedFilePrefix prefixes the files generated per plugin, the stale ones
+// are removed before generating the files.
+const generatedFilePrefix = "plugin_typed_"
+
+// importPaths are the import paths of the packages used by the generated code.
+var importPaths = map[string]string{
+	"regexp":           "regexp",
+	"attr":             "github.com/hashicorp/terraform-plugin-framework/attr",
+	"schema":           "github.com/hashicorp/terraform-plugin-framework/resource/schema",
+	"validator":        "github.com/hashicorp/terraform-plugin-framework/schema/validator",
+	"types":            "github.com/hashicorp/terraform-plugin-framework/types",
+	"stringdefault":    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault",
+	"int64default":     "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default",
+	"float64default":   "github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default",
+	"booldefault":      "github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault",
+	"listdefault":      "github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault",
+	"stringvalidator":  "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator",
+	"int64validator":   "github.com/hashicorp/terraform-plugin-framework-validators/int64validator",
+	"float64validator": "github.com/hashicorp/terraform-plugin-framework-validators/float64validator",
+	"listvalidator":    "github.com/hashicorp/terraform-plugin-framework-validators/listvalidator",
+	"mapvalidator":     "github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator",
+	"context":          "context",
+}
+
+// typedPlugin is a plugin with its generated attributes.
+type typedPlugin struct {
+	Name              string
+	AttributeName     string
+	GoName            string
+	Attribute         *attribute
+	ConsumerAttribute *attribute
+	Imports           map[string]bool
+}
+
+// generate writes the Go file of the typed schemas of each plugin with their
+// models and conversion functions, and the Go file of the `plugins_typed`
+// attributes.
+func generate(snapshot *schemaSnapshot, outputDir string) error {
+	stale, err := filepath.Glob(filepath.Join(outputDir, generatedFilePrefix+"*.go"))
+	if err != nil {
+		return err
+	}
+	for _, file := range stale {
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+
+	var plugins []typedPlugin
+	for _, pluginSchema := range snapshot.Plugins {
+		plugin := buildPlugin(pluginSchema)
+		plugins = append(plugins, plugin)
+
+		source := renderPlugin(snapshot.Version, plugin)
+		file := filepath.Join(outputDir, generatedFilePrefix+plugin.AttributeName+".go")
+		if err := writeGoFile(file, source); err != nil {
+			return err
+		}
+	}
+
+	return writeGoFile(filepath.Join(outputDir, "plugins_typed_generated.go"), renderPlugins(snapshot.Version, plugins))
+}
+
+func buildPlugin(pluginSchema pluginSchema) typedPlugin {
+	plugin := typedPlugin{
+		Name:          pluginSchema.Name,
+		AttributeName: strings.ReplaceAll(pluginSchema.Name, "-", "_"),
+		GoName:        goName(pluginSchema.Name),
+		Imports:       map[string]bool{"context": true, "schema": true},
+	}
+
+	builder := &attributeBuilder{imports: plugin.Imports}
+	plugin.Attribute = pluginAttribute(builder, pluginSchema.Name, pluginSchema.Schema,
+		"The configuration of the `"+pluginSchema.Name+"` plugin.")
+	if pluginSchema.ConsumerSchema != nil {
+		plugin.ConsumerAttribute = pluginAttribute(builder, pluginSchema.Name, pluginSchema.ConsumerSchema,
+			"The consumer configuration of the `"+pluginSchema.Name+"` plugin.")
+	}
+
+	for _, a := range []*attribute{plugin.Attribute, plugin.ConsumerAttribute} {
+		collectImports(plugin.Imports, a)
+	}
+
+	return plugin
+}
+
+// collectImports adds the packages used by the attribute literals and the
+// models besides the validators and the defaults, added while building the
+// attributes.
+func collectImports(imports map[string]bool, a *attribute) {
+	if a == nil {
+		return
+	}
+
+	if len(a.Validators) > 0 {
+		imports["validator"] = true
+	}
+	if a.Kind != kindSingle && a.Kind != kindListNested {
+		imports["types"] = true
+	}
+	for _, nested := range a.Attributes {
+		collectImports(imports, nested)
+	}
+}
+
+func pluginAttribute(builder *attributeBuilder, name string, schema *jsonSchema, text string) *attribute {
+	sensitive := map[string]bool{}
+	for _, field := range schema.EncryptFields {
+		sensitive[field] = true
+	}
+
+	if schema.Description != "" {
+		text += " " + description(schema, false)
+	}
+
+	return &attribute{
+		Name:        strings.ReplaceAll(name, "-", "_"),
+		Kind:        kindSingle,
+		Description: text,
+		Attributes:  builder.objectAttributes(schema, sensitive),
+	}
+}
+
+// goName returns the Go name of a plugin, e.g. `LimitCount` for `limit-count`.
+func goName(name string) string {
+	var goName strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' }) {
+		goName.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return goName.String()
+}
+
+func renderPlugin(version string, plugin typedPlugin) []byte {
+	var source bytes.Buffer
+
+	writeHeader(&source, version, plugin.Imports)
+
+	fmt.Fprintf(&source, "// PluginTyped%sSchemaAttribute is the typed configuration of the %s plugin.\n", plugin.GoName, plugin.Name)
+	fmt.Fprintf(&source, "var PluginTyped%sSchemaAttribute = schema.SingleNestedAttribute{\n", plugin.GoName)
+	renderAttributeFields(&source, plugin.Attribute)
+	source.WriteString("}\n")
+
+	if plugin.ConsumerAttribute != nil {
+		fmt.Fprintf(&source, "\n// PluginTyped%sConsumerSchemaAttribute is the typed configuration of the %s plugin on the consumers.\n", plugin.GoName, plugin.Name)
+		fmt.Fprintf(&source, "var PluginTyped%sConsumerSchemaAttribute = schema.SingleNestedAttribute{\n", plugin.GoName)
+		renderAttributeFields(&source, plugin.ConsumerAttribute)
+		source.WriteString("}\n")
+	}
+
+	renderModel(&source, "PluginTyped"+plugin.GoName, "the configuration of the `"+plugin.Name+"` plugin", plugin.Name, plugin.Attribute)
+	if plugin.ConsumerAttribute != nil {
+		renderModel(&source, "PluginTyped"+plugin.GoName+"Consumer", "the consumer configuration of the `"+plugin.Name+"` plugin", plugin.Name, plugin.ConsumerAttribute)
+	}
+
+	return source.Bytes()
+}
+
+// renderModel renders the model of an object attribute, with its conversion
+// functions from and to the JSON of the Admin API, followed by the models of
+// its nested objects, named after their field like `PluginTypedProxyRewriteHeaders`.
+// The field path is the dotted path of the object from the plugin name, e.g.
+// `proxy-rewrite.headers`.
+func renderModel(source *bytes.Buffer, name string, subject string, fieldPath string, a *attribute) {
+	fmt.Fprintf(source, "\n// %sType is the model of %s.\n", name, subject)
+	fmt.Fprintf(source, "type %sType struct {\n", name)
+	for _, field := range a.Attributes {
+		fmt.Fprintf(source, "%s %s `tfsdk:%q`\n", goName(field.Name), modelFieldType(name, field), field.Name)
+	}
+	source.WriteString("}\n")
+
+	fmt.Fprintf(source, "\n// %sFromTerraformToApi converts %s into its APISIX JSON,\n// leaving out the fields which aren't set.\n", name, subject)
+	fmt.Fprintf(source, "func %sFromTerraformToApi(ctx context.Context, terraformDataModel *%sType) (apiDataModel map[string]interface{}) {\n", name, name)
+	source.WriteString("if terraformDataModel == nil {\nreturn\n}\n\n")
+	source.WriteString("result := map[string]interface{}{}\n")
+	for _, field := range a.Attributes {
+		fieldName := goName(field.Name)
+		switch field.Kind {
+		case kindJSON:
+			fmt.Fprintf(source, "setTypedJSONField(ctx, result, %q, terraformDataModel.%s)\n", field.Name, fieldName)
+		case kindSingle:
+			fmt.Fprintf(source, "if nested := %s%sFromTerraformToApi(ctx, terraformDataModel.%s); nested != nil {\n", name, fieldName, fieldName)
+			fmt.Fprintf(source, "result[%q] = nested\n}\n", field.Name)
+		case kindListNested:
+			fmt.Fprintf(source, "if terraformDataModel.%s != nil {\n", fieldName)
+			fmt.Fprintf(source, "items := make([]interface{}, 0, len(terraformDataModel.%s))\n", fieldName)
+			fmt.Fprintf(source, "for i := range terraformDataModel.%s {\n", fieldName)
+			fmt.Fprintf(source, "items = append(items, %s%sFromTerraformToApi(ctx, &terraformDataModel.%s[i]))\n}\n", name, fieldName, fieldName)
+			fmt.Fprintf(source, "result[%q] = items\n}\n", field.Name)
+		default:
+			fmt.Fprintf(source, "setTypedField(ctx, result, %q, terraformDataModel.%s)\n", field.Name, fieldName)
+		}
+	}
+	source.WriteString("\nreturn result\n}\n")
+
+	fmt.Fprintf(source, "\n// %sFromApiToTerraform converts the APISIX JSON of %s into its model.\n", name, subject)
+	fmt.Fprintf(source, "func %sFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *%sType) {\n", name, name)
+	source.WriteString("if apiDataModel == nil {\nreturn\n}\n\n")
+	fmt.Fprintf(source, "result := %sType{\n", name)
+	for _, field := range a.Attributes {
+		fieldName := goName(field.Name)
+		switch field.Kind {
+		case kindJSON:
+			fmt.Fprintf(source, "%s: typedJSONField(ctx, apiDataModel, %q),\n", fieldName, field.Name)
+		case kindSingle:
+			fmt.Fprintf(source, "%s: %s%sFromApiToTerraform(ctx, typedObjectField(ctx, apiDataModel, %q)),\n", fieldName, name, fieldName, field.Name)
+		case kindListNested:
+			// Converted after the literal, to keep the empty lists
+		case kindList, kindMap:
+			fmt.Fprintf(source, "%s: typed%sField(ctx, apiDataModel, %q, types.%sType),\n", fieldName, field.Kind, field.Name, field.ElementKind)
+		default:
+			fmt.Fprintf(source, "%s: typed%sField(ctx, apiDataModel, %q),\n", fieldName, field.Kind, field.Name)
+		}
+	}
+	source.WriteString("}\n")
+	for _, field := range a.Attributes {
+		if field.Kind != kindListNested {
+			continue
+		}
+		fieldName := goName(field.Name)
+		fmt.Fprintf(source, "if items := typedObjectsField(ctx, apiDataModel, %q); items != nil {\n", field.Name)
+		fmt.Fprintf(source, "result.%s = make([]%s%sType, 0, len(items))\n", fieldName, name, fieldName)
+		source.WriteString("for _, item := range items {\n")
+		fmt.Fprintf(source, "result.%s = append(result.%s, *%s%sFromApiToTerraform(ctx, item))\n}\n}\n", fieldName, fieldName, name, fieldName)
+	}
+	source.WriteString("\nreturn &result\n}\n")
+
+	for _, field := range a.Attributes {
+		if field.Kind == kindSingle || field.Kind == kindListNested {
+			nestedPath := fieldPath + "." + field.Name
+			renderModel(source, name+goName(field.Name), "the `"+nestedPath+"` field", nestedPath, field)
+		}
+	}
+}
+
+// modelFieldType returns the Go type of the model field of an attribute, in
+// the model of the given name.
+func modelFieldType(name string, a *attribute) string {
+	switch a.Kind {
+	case kindJSON:
+		return "types.String"
+	case kindSingle:
+		return "*" + name + goName(a.Name) + "Type"
+	case kindListNested:
+		return "[]" + name + goName(a.Name) + "Type"
+	}
+
+	return "types." + string(a.Kind)
+}
+
+func renderPlugins(version string, plugins []typedPlugin) []byte {
+	var source bytes.Buffer
+
+	writeHeader(&source, version, map[string]bool{"context": true, "schema": true, "validator": true, "types": true})
+
+	source.WriteString("// pluginsTypedNames maps the attributes of the typed plugins to the plugin names.\n")
+	source.WriteString("var pluginsTypedNames = map[string]string{\n")
+	for _, plugin := range plugins {
+		fmt.Fprintf(&source, "%q: %q,\n", plugin.AttributeName, plugin.Name)
+	}
+	source.WriteString("}\n\n")
+
+	renderPluginsTypedAttribute(&source, "PluginsTypedSchemaAttribute", version, plugins, false)
+	renderPluginsTypedAttribute(&source, "ConsumerPluginsTypedSchemaAttribute", version, plugins, true)
+
+	renderPluginsTypedModel(&source, "PluginsTyped", "the typed plugins of the routes and services", plugins, false)
+	renderPluginsTypedModel(&source, "ConsumerPluginsTyped", "the typed plugins of the consumers", plugins, true)
+
+	return source.Bytes()
+}
+
+// renderPluginsTypedModel renders the model of a `plugins_typed` attribute,
+// with its conversion functions from and to the plugins configuration of
+// APISIX, keyed by the plugin name.
+func renderPluginsTypedModel(source *bytes.Buffer, name string, subject string, plugins []typedPlugin, consumer bool) {
+	pluginModel := func(plugin typedPlugin) string {
+		if consumer && plugin.ConsumerAttribute != nil {
+			return "PluginTyped" + plugin.GoName + "Consumer"
+		}
+		return "PluginTyped" + plugin.GoName
+	}
+
+	fmt.Fprintf(source, "// %sType is the model of %s.\n", name, subject)
+	fmt.Fprintf(source, "type %sType struct {\n", name)
+	for _, plugin := range plugins {
+		fmt.Fprintf(source, "%s *%sType `tfsdk:%q`\n", plugin.GoName, pluginModel(plugin), plugin.AttributeName)
+	}
+	source.WriteString("}\n\n")
+
+	fmt.Fprintf(source, "// %sFromTerraformToApi converts %s into the plugins\n", name, subject)
+	source.WriteString("// configuration of APISIX, leaving out the plugins holding unknown values.\n")
+	fmt.Fprintf(source, "func %sFromTerraformToApi(ctx context.Context, pluginsTyped types.Object) map[string]interface{} {\n", name)
+	source.WriteString("if pluginsTyped.IsNull() || pluginsTyped.IsUnknown() {\nreturn nil\n}\n\n")
+	source.WriteString("plugins := map[string]interface{}{}\n")
+	for _, plugin := range plugins {
+		variable := strings.ToLower(plugin.GoName[:1]) + plugin.GoName[1:]
+		fmt.Fprintf(source, "var %s %sType\n", variable, pluginModel(plugin))
+		fmt.Fprintf(source, "if pluginTypedAs(ctx, pluginsTyped, %q, &%s) {\n", plugin.AttributeName, variable)
+		fmt.Fprintf(source, "plugins[%q] = %sFromTerraformToApi(ctx, &%s)\n}\n", plugin.Name, pluginModel(plugin), variable)
+	}
+	source.WriteString("\nreturn plugins\n}\n\n")
+
+	fmt.Fprintf(source, "// %sFromApiToTerraform converts the plugins configuration of APISIX\n", name)
+	fmt.Fprintf(source, "// into %s, leaving out the plugins which aren't typed.\n", subject)
+	fmt.Fprintf(source, "func %sFromApiToTerraform(ctx context.Context, plugins map[string]interface{}) types.Object {\n", name)
+	fmt.Fprintf(source, "attributeTypes := %sSchemaAttribute.GetType().(types.ObjectType).AttrTypes\n", name)
+	source.WriteString("if plugins == nil {\nreturn types.ObjectNull(attributeTypes)\n}\n\n")
+	fmt.Fprintf(source, "return pluginsTypedObject(ctx, attributeTypes, %sType{\n", name)
+	for _, plugin := range plugins {
+		fmt.Fprintf(source, "%s: %sFromApiToTerraform(ctx, typedObjectField(ctx, plugins, %q)),\n", plugin.GoName, pluginModel(plugin), plugin.Name)
+	}
+	source.WriteString("})\n}\n\n")
+}
+
+func renderPluginsTypedAttribute(source *bytes.Buffer, name string, version string, plugins []typedPlugin, consumer bool) {
+	description := "The plugins with a typed configuration, generated from the APISIX " + version + " plugin schemas, " +
+		"so that the editors complete and validate their fields. The attributes are named after the plugins, " +
+		"with underscores, like `limit_count` for `limit-count`. They are merged into the plugins of `plugins` or `plugin`, " +
+		"which configure the other plugins, and a plugin can't be configured in both."
+	if consumer {
+		description += " The authentication plugins have their consumer configuration, like the `key` of `key-auth`."
+	}
+
+	fmt.Fprintf(source, "// %s is the attribute of the typed plugins.\n", name)
+	fmt.Fprintf(source, "var %s = schema.SingleNestedAttribute{\n", name)
+	fmt.Fprintf(source, "MarkdownDescription: %q,\n", description)
+	source.WriteString("Optional: true,\n")
+	source.WriteString("Attributes: map[string]schema.Attribute{\n")
+	for _, plugin := range plugins {
+		if consumer && plugin.ConsumerAttribute != nil {
+			fmt.Fprintf(source, "%q: PluginTyped%sConsumerSchemaAttribute,\n", plugin.AttributeName, plugin.GoName)
+		} else {
+			fmt.Fprintf(source, "%q: PluginTyped%sSchemaAttribute,\n", plugin.AttributeName, plugin.GoName)
+		}
+	}
+	source.WriteString("},\n")
+	source.WriteString("Validators: []validator.Object{\nPluginsTypedValidator{},\n},\n")
+	source.WriteString("}\n\n")
+}
+
+func writeHeader(source *bytes.Buffer, version string, imports map[string]bool) {
+	fmt.Fprintf(source, "// Code generated by tools/pluginschemagen from the APISIX %s plugin schemas. DO NOT EDIT.\n\n", version)
+	source.WriteString("package model\n\n")
+
+	var standard, thirdParty []string
+	for name := range imports {
+		if strings.Contains(importPaths[name], ".") {
+			thirdParty = append(thirdParty, importPaths[name])
+		} else {
+			standard = append(standard, importPaths[name])
+		}
+	}
+	sort.Strings(standard)
+	sort.Strings(thirdParty)
+
+	source.WriteString("import (\n")
+	for _, path := range standard {
+		fmt.Fprintf(source, "%q\n", path)
+	}
+	if len(standard) > 0 {
+		source.WriteString("\n")
+	}
+	for _, path := range thirdParty {
+		fmt.Fprintf(source, "%q\n", path)
+	}
+	source.WriteString(")\n\n")
+}
+
+// renderAttributeFields renders the fields of the schema attribute literal.
+func renderAttributeFields(source *bytes.Buffer, a *attribute) {
+	if a.Description != "" {
+		fmt.Fprintf(source, "MarkdownDescription: %q,\n", a.Description)
+	}
+
+	if a.Required {
+		source.WriteString("Required: true,\n")
+	} else {
+		source.WriteString("Optional: true,\n")
+	}
+	if a.Default != "" {
+		source.WriteString("Computed: true,\n")
+		fmt.Fprintf(source, "Default: %s,\n", a.Default)
+	}
+	if a.Sensitive {
+		source.WriteString("Sensitive: true,\n")
+	}
+
+	switch a.Kind {
+	case kindList, kindMap:
+		fmt.Fprintf(source, "ElementType: types.%sType,\n", a.ElementKind)
+	case kindSingle:
+		renderAttributes(source, "Attributes: map[string]schema.Attribute{\n", a.Attributes)
+	case kindListNested:
+		source.WriteString("NestedObject: schema.NestedAttributeObject{\n")
+		renderAttributes(source, "Attributes: map[string]schema.Attribute{\n", a.Attributes)
+		source.WriteString("},\n")
+	}
+
+	if len(a.Validators) > 0 {
+		fmt.Fprintf(source, "Validators: []validator.%s{\n", validatorType(a.Kind))
+		for _, v := range a.Validators {
+			source.WriteString(v + ",\n")
+		}
+		source.WriteString("},\n")
+	}
+}
+
+func renderAttributes(source *bytes.Buffer, opening string, attributes []*attribute) {
+	source.WriteString(opening)
+	for _, a := range attributes {
+		fmt.Fprintf(source, "%q: schema.%sAttribute{\n", a.Name, attributeType(a.Kind))
+		renderAttributeFields(source, a)
+		source.WriteString("},\n")
+	}
+	source.WriteString("},\n")
+}
+
+func attributeType(kind attributeKind) attributeKind {
+	if kind == kindJSON {
+		return kindString
+	}
+
+	return kind
+}
+
+func validatorType(kind attributeKind) string {
+	switch kind {
+	case kindJSON:
+		return string(kindString)
+	case kindListNested:
+		return string(kindList)
+	case kindSingle:
+		return "Object"
+	}
+
+	return string(kind)
+}
+
+func writeGoFile(file string, source []byte) error {
+	formatted, err := format.Source(source)
+	if err != nil {
+		return fmt.Errorf("could not format %s: %w\n%s", file, err, source)
+	}
+
+	return os.WriteFile(file, formatted, 0o644)
+}
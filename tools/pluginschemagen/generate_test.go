@@ -0,0 +1,48 @@
+package main
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+)
+
+func TestRenderPlugin(t *testing.T) {
+	var schema jsonSchema
+	err := decodeJSON([]byte(`{
+		"type": "object",
+		"properties": {
+			"status_code": {"type": "integer"},
+			"headers": {"type": "object", "properties": {"set": {"type": "object", "patternProperties": {"^[^:]+$": {"type": "string"}}}}},
+			"filters": {"type": "array", "items": {"type": "object", "properties": {"regex": {"type": "string"}}}},
+			"vars": {"type": "array", "items": {"type": "array"}}
+		}
+	}`), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plugin := buildPlugin(pluginSchema{Name: "response-rewrite", Schema: &schema})
+	source, err := format.Source(renderPlugin("3.15", plugin))
+	if err != nil {
+		t.Fatalf("could not format the generated code: %s", err)
+	}
+
+	// Each object has its model and its converters, named after its field
+	for _, expected := range []string{
+		"type PluginTypedResponseRewriteType struct {",
+		"Filters    []PluginTypedResponseRewriteFiltersType `tfsdk:\"filters\"`",
+		"Headers    *PluginTypedResponseRewriteHeadersType  `tfsdk:\"headers\"`",
+		"func PluginTypedResponseRewriteFromTerraformToApi(ctx context.Context, terraformDataModel *PluginTypedResponseRewriteType) (apiDataModel map[string]interface{}) {",
+		"func PluginTypedResponseRewriteFromApiToTerraform(ctx context.Context, apiDataModel map[string]interface{}) (terraformDataModel *PluginTypedResponseRewriteType) {",
+		"type PluginTypedResponseRewriteHeadersType struct {",
+		"func PluginTypedResponseRewriteFiltersFromApiToTerraform(",
+		`StatusCode: typedInt64Field(ctx, apiDataModel, "status_code"),`,
+		`Set: typedMapField(ctx, apiDataModel, "set", types.StringType),`,
+		`setTypedJSONField(ctx, result, "vars", terraformDataModel.Vars)`,
+		`if items := typedObjectsField(ctx, apiDataModel, "filters"); items != nil {`,
+	} {
+		if !strings.Contains(string(source), expected) {
+			t.Errorf("expected the generated code to contain %q, got\n%s", expected, source)
+		}
+	}
+}
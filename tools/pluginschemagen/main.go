@@ -0,0 +1,58 @@
+// Command pluginschemagen generates the typed schemas of the APISIX plugins,
+// exposed in the `plugins_typed` attribute of the routes, services and
+// consumers, with their models and converters, from the snapshots of the
+// APISIX plugin JSON schemas.
+//
+// The snapshots of an APISIX version are the `<plugin>.json` schemas returned
+// by `/apisix/admin/schema/plugins/<plugin>`, and the `<plugin>.consumer.json`
+// schemas returned with `?schema_type=consumer` for the plugins configured on
+// the consumers, like the key of key-auth. Refresh them from a running APISIX:
+//
+//	go run ./tools/pluginschemagen -schemas apisix/plugin_schemas/3.15 -fetch http://127.0.0.1:9180 -api-key <key>
+//
+// and generate the schemas with `go generate ./apisix/model`.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	schemasDir := flag.String("schemas", "", "directory of the plugin JSON schemas of an APISIX version, named after the version")
+	outputDir := flag.String("output", "", "directory of the generated Go files, in the model package")
+	fetchURL := flag.String("fetch", "", "APISIX Admin API URL to refresh the plugin JSON schemas from, instead of generating the Go files")
+	apiKey := flag.String("api-key", os.Getenv("APISIX_API_KEY"), "APISIX Admin API key used with -fetch")
+	plugins := flag.String("plugins", "", "comma-separated names of the plugins to fetch, the plugins of the existing schemas by default")
+	flag.Parse()
+
+	if *schemasDir == "" {
+		log.Fatal("the -schemas directory is required")
+	}
+
+	if *fetchURL != "" {
+		var names []string
+		if *plugins != "" {
+			names = strings.Split(*plugins, ",")
+		}
+		if err := fetchSchemas(*fetchURL, *apiKey, *schemasDir, names); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *outputDir == "" {
+		log.Fatal("the -output directory is required")
+	}
+
+	pluginSchemas, err := loadSchemas(*schemasDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := generate(pluginSchemas, *outputDir); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// jsonSchema is the subset of the JSON schemas of the APISIX plugins the
+// generator understands. The other keywords are left to APISIX to validate.
+type jsonSchema struct {
+	Type                 schemaType                 `json:"type"`
+	Description          string                     `json:"description"`
+	Properties           map[string]*jsonSchema     `json:"properties"`
+	PatternProperties    map[string]*jsonSchema     `json:"patternProperties"`
+	AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+	Items                json.RawMessage            `json:"items"`
+	Required             []string                   `json:"required"`
+	Enum                 []interface{}              `json:"enum"`
+	Default              interface{}                `json:"default"`
+	Minimum              *json.Number               `json:"minimum"`
+	Maximum              *json.Number               `json:"maximum"`
+	ExclusiveMinimum     interface{}                `json:"exclusiveMinimum"`
+	ExclusiveMaximum     interface{}                `json:"exclusiveMaximum"`
+	MinLength            *int64                     `json:"minLength"`
+	MaxLength            *int64                     `json:"maxLength"`
+	MinItems             *int64                     `json:"minItems"`
+	MaxItems             *int64                     `json:"maxItems"`
+	MinProperties        *int64                     `json:"minProperties"`
+	UniqueItems          bool                       `json:"uniqueItems"`
+	Pattern              string                     `json:"pattern"`
+	OneOf                []*jsonSchema              `json:"oneOf"`
+	AnyOf                []*jsonSchema              `json:"anyOf"`
+	Then                 *jsonSchema                `json:"then"`
+	Else                 *jsonSchema                `json:"else"`
+	Dependencies         map[string]json.RawMessage `json:"dependencies"`
+	EncryptFields        []string                   `json:"encrypt_fields"`
+}
+
+// schemaType is the type of a JSON schema, the first type other than null
+// when the schema allows several types.
+type schemaType string
+
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaType(name)
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name != "null" {
+			*t = schemaType(name)
+			break
+		}
+	}
+
+	return nil
+}
+
+// itemsSchema returns the schema of the items of an array, nil for the arrays
+// of any items or the tuples.
+func (s *jsonSchema) itemsSchema() *jsonSchema {
+	if len(s.Items) == 0 || s.Items[0] != '{' {
+		return nil
+	}
+
+	var items jsonSchema
+	if err := decodeJSON(s.Items, &items); err != nil {
+		return nil
+	}
+
+	return &items
+}
+
+// valuesSchema returns the schema of the values of an object without
+// properties, from its pattern properties or additional properties.
+func (s *jsonSchema) valuesSchema() *jsonSchema {
+	for _, pattern := range sortedKeys(s.PatternProperties) {
+		return s.PatternProperties[pattern]
+	}
+
+	if len(s.AdditionalProperties) == 0 || s.AdditionalProperties[0] != '{' {
+		return nil
+	}
+
+	var values jsonSchema
+	if err := decodeJSON(s.AdditionalProperties, &values); err != nil {
+		return nil
+	}
+
+	return &values
+}
+
+// pluginSchema holds the JSON schemas of a plugin.
+type pluginSchema struct {
+	Name           string
+	Schema         *jsonSchema
+	ConsumerSchema *jsonSchema
+}
+
+// schemaSnapshot holds the plugin JSON schemas of an APISIX version.
+type schemaSnapshot struct {
+	Version string
+	Plugins []pluginSchema
+}
+
+// loadSchemas loads the plugin JSON schemas of the directory, named after the
+// APISIX version.
+func loadSchemas(dir string) (*schemaSnapshot, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &schemaSnapshot{Version: filepath.Base(dir)}
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		if strings.HasSuffix(name, ".consumer") {
+			continue
+		}
+
+		plugin := pluginSchema{Name: name}
+		if plugin.Schema, err = readSchema(file); err != nil {
+			return nil, err
+		}
+
+		consumerFile := filepath.Join(dir, name+".consumer.json")
+		if _, err := os.Stat(consumerFile); err == nil {
+			if plugin.ConsumerSchema, err = readSchema(consumerFile); err != nil {
+				return nil, err
+			}
+		}
+
+		snapshot.Plugins = append(snapshot.Plugins, plugin)
+	}
+
+	if len(snapshot.Plugins) == 0 {
+		return nil, fmt.Errorf("no plugin JSON schemas in %s", dir)
+	}
+
+	return snapshot, nil
+}
+
+func readSchema(file string) (*jsonSchema, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema jsonSchema
+	if err := decodeJSON(data, &schema); err != nil {
+		return nil, fmt.Errorf("could not decode %s: %w", file, err)
+	}
+
+	return &schema, nil
+}
+
+// decodeJSON decodes the JSON with the numbers kept as json.Number.
+func decodeJSON(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(value)
+}
+
+// fetchSchemas downloads the JSON schemas of the plugins from the APISIX
+// Admin API into the directory, with the consumer schemas of the plugins
+// that have one, and the names of all the plugins into `plugins.txt`.
+func fetchSchemas(adminURL string, apiKey string, dir string, names []string) error {
+	if len(names) == 0 {
+		snapshot, err := loadSchemas(dir)
+		if err != nil {
+			return err
+		}
+		for _, plugin := range snapshot.Plugins {
+			names = append(names, plugin.Name)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	for _, name := range names {
+		schema, err := fetchSchema(client, adminURL, apiKey, name, "")
+		if err != nil {
+			return err
+		}
+		if err := writeSchema(filepath.Join(dir, name+".json"), schema); err != nil {
+			return err
+		}
+
+		// The plugins without a consumer schema are rejected
+		consumerSchema, err := fetchSchema(client, adminURL, apiKey, name, "consumer")
+		if err != nil {
+			continue
+		}
+		if err := writeSchema(filepath.Join(dir, name+".consumer.json"), consumerSchema); err != nil {
+			return err
+		}
+	}
+
+	// The names of the plugins without a schema snapshot tell the unknown plugins apart
+	var allNames []string
+	if err := fetchJSON(client, adminURL, apiKey, "plugins/list", &allNames); err != nil {
+		return err
+	}
+	sort.Strings(allNames)
+
+	return os.WriteFile(filepath.Join(dir, "plugins.txt"), []byte(strings.Join(allNames, "\n")+"\n"), 0o644)
+}
+
+func fetchSchema(client *http.Client, adminURL string, apiKey string, name string, schemaType string) (interface{}, error) {
+	objectPath := "schema/plugins/" + name
+	if schemaType != "" {
+		objectPath += "?schema_type=" + schemaType
+	}
+
+	var schema interface{}
+	if err := fetchJSON(client, adminURL, apiKey, objectPath, &schema); err != nil {
+		return nil, fmt.Errorf("could not fetch the schema of %s: %w", name, err)
+	}
+
+	return schema, nil
+}
+
+// fetchJSON decodes the response of the Admin API to the GET request of the
+// path, relative to `/apisix/admin`.
+func fetchJSON(client *http.Client, adminURL string, apiKey string, objectPath string, value interface{}) error {
+	url := strings.TrimSuffix(adminURL, "/") + "/apisix/admin/" + objectPath
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-API-KEY", apiKey)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+	}
+
+	return decodeJSON(body, value)
+}
+
+// writeSchema writes the schema indented with its keys sorted, so that the
+// changes between the APISIX versions are readable.
+func writeSchema(file string, schema interface{}) error {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, append(data, '\n'), 0o644)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}